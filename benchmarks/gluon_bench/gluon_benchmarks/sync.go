@@ -77,7 +77,7 @@ func (s *Sync) setupConnector(ctx context.Context) (utils.ConnectorImpl, error)
 	mboxIDs := make([]imap.MailboxID, 0, *syncMBoxCountFlag)
 
 	for i := uint(0); i < *syncMBoxCountFlag; i++ {
-		mbox, err := c.Connector().CreateMailbox(ctx, s.nullIMAPStateWriter, []string{uuid.NewString()})
+		mbox, err := c.Connector().CreateMailbox(ctx, s.nullIMAPStateWriter, []string{uuid.NewString()}, imap.NewFlagSet())
 		if err != nil {
 			return nil, err
 		}
@@ -38,6 +38,8 @@ type serverBuilder struct {
 	disableParallelism      bool
 	imapLimits              limits.IMAP
 	disableIMAPAuthenticate bool
+	compression             bool
+	imap4rev2               bool
 	uidValidityGenerator    imap.UIDValidityGenerator
 	panicHandler            async.PanicHandler
 	dbCI                    db.ClientInterface
@@ -124,6 +126,8 @@ func (builder *serverBuilder) build() (*Server, error) {
 		reporter:                builder.reporter,
 		disableParallelism:      builder.disableParallelism,
 		disableIMAPAuthenticate: builder.disableIMAPAuthenticate,
+		compression:             builder.compression,
+		imap4rev2:               builder.imap4rev2,
 		uidValidityGenerator:    builder.uidValidityGenerator,
 		panicHandler:            builder.panicHandler,
 		observabilitySender:     builder.observabilitySender,
@@ -10,6 +10,7 @@ import (
 
 var ErrOperationNotAllowed = errors.New("operation not allowed")
 var ErrMessageSizeExceedsLimits = errors.New("message size exceeds limits")
+var ErrUnsupportedSpecialUse = errors.New("special-use attribute not supported")
 
 // Connector connects the gluon server to a remote mail store.
 type Connector interface {
@@ -19,8 +20,10 @@ type Connector interface {
 	// Authorize returns whether the given username/password combination are valid for this connector.
 	Authorize(ctx context.Context, username string, password []byte) bool
 
-	// CreateMailbox creates a mailbox with the given name.
-	CreateMailbox(ctx context.Context, cache IMAPStateWrite, name []string) (imap.Mailbox, error)
+	// CreateMailbox creates a mailbox with the given name. The special-use attributes (RFC6154) requested by the
+	// client, if any, should be set on the returned mailbox; ErrUnsupportedSpecialUse should be returned if they
+	// can't be honoured.
+	CreateMailbox(ctx context.Context, cache IMAPStateWrite, name []string, specialUse imap.FlagSet) (imap.Mailbox, error)
 
 	// GetMessageLiteral is intended to be used by Gluon when, for some reason, the local cached data no longer exists.
 	// Note: this can get called from different go routines.
@@ -63,3 +66,80 @@ type Connector interface {
 	// Close the connector will no longer be used and all resources should be closed/released.
 	Close(ctx context.Context) error
 }
+
+// NamespaceProvider can optionally be implemented by a Connector to declare the mailbox namespaces (RFC2342) exposed
+// to clients with the NAMESPACE command. Connectors which don't implement it expose a single personal namespace
+// rooted at the top of the mailbox hierarchy.
+type NamespaceProvider interface {
+	// GetNamespaces returns the personal, other users' and shared namespaces of the mailboxes.
+	GetNamespaces(ctx context.Context) imap.Namespaces
+}
+
+// QuotaProvider can optionally be implemented by a Connector to report the storage usage and limits of the account
+// (RFC9208). Messages which would exceed the limits are then rejected before reaching CreateMessage. Connectors which
+// don't implement it expose no quota to clients.
+type QuotaProvider interface {
+	// GetQuota returns the usage and limits of the account. If the usage is left at zero, it is computed from the
+	// size and number of messages known to gluon instead.
+	GetQuota(ctx context.Context) (imap.Quota, error)
+}
+
+// MetadataSyncer can optionally be implemented by a Connector to be notified of changes to the shared metadata
+// entries (RFC5464) of the mailboxes and of the server, e.g. to sync them with other devices. Private entries are only
+// ever stored locally.
+type MetadataSyncer interface {
+	// SetSharedMetadata is called before the given shared entries of the mailbox are changed. The mailbox ID is empty
+	// for server entries. Entries with a nil value are being removed. Returning an error aborts the change.
+	SetSharedMetadata(ctx context.Context, mboxID imap.MailboxID, entries []imap.MetadataEntry) error
+}
+
+// MessageBatchCreator can optionally be implemented by a Connector to create the messages of an APPEND command
+// carrying several messages (RFC3502) with a single request to the remote. Connectors which don't implement it have
+// CreateMessage called once per message instead, and the messages created before a failure are rolled back as
+// described by MessageDeleter.
+type MessageBatchCreator interface {
+	// CreateMessages creates the given messages in the mailbox with the given ID. Either all the messages are created
+	// or none of them is. The created messages and their literals are returned in the order of the requests.
+	CreateMessages(ctx context.Context, cache IMAPStateWrite, mboxID imap.MailboxID, reqs []CreateMessageReq) ([]CreatedMessage, error)
+}
+
+// CreateMessageReq holds a message to be created by MessageBatchCreator.
+type CreateMessageReq struct {
+	Literal []byte
+	Flags   imap.FlagSet
+	Date    time.Time
+}
+
+// CreatedMessage holds a message created by MessageBatchCreator, along with its literal as it was stored.
+type CreatedMessage struct {
+	Message imap.Message
+	Literal []byte
+}
+
+// MessageReplacer can optionally be implemented by a Connector to replace a message with a new version of it with a
+// single request to the remote (RFC8508), e.g. to update a draft in place. Connectors which don't implement it have
+// the new message created with CreateMessage and the old one removed with RemoveMessagesFromMailbox instead; if the
+// old one can't be removed, the new one is rolled back as described by MessageDeleter.
+type MessageReplacer interface {
+	// ReplaceMessage creates the literal in the mailbox mboxToID and removes the message with the given ID from the
+	// mailbox mboxFromID. The returned message may keep the ID of the replaced message if it was updated in place.
+	ReplaceMessage(
+		ctx context.Context,
+		cache IMAPStateWrite,
+		messageID imap.MessageID,
+		mboxFromID, mboxToID imap.MailboxID,
+		literal []byte,
+		flags imap.FlagSet,
+		date time.Time,
+	) (imap.Message, []byte, error)
+}
+
+// MessageDeleter can optionally be implemented by a Connector to permanently delete messages from the remote. It is
+// used to roll back the messages created by a MULTIAPPEND or REPLACE command which failed midway. Connectors which
+// don't implement it only have these messages removed from the mailbox they were created in with
+// RemoveMessagesFromMailbox, so they may remain on the remote: such commands are then only atomic on a best-effort
+// basis.
+type MessageDeleter interface {
+	// DeleteMessages permanently deletes the given messages.
+	DeleteMessages(ctx context.Context, cache IMAPStateWrite, messageIDs []imap.MessageID) error
+}
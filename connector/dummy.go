@@ -56,6 +56,14 @@ type Dummy struct {
 	// hiddenMailboxes holds the visibility status of the mailboxes. Mailboxes not listed are considered visible.
 	mailboxVisibilities map[imap.MailboxID]imap.MailboxVisibility
 
+	// quota holds the usage and limits reported to the mailserver. The usage is computed by the mailserver if unset.
+	quota     imap.Quota
+	quotaLock sync.Mutex
+
+	// sharedMetadata holds the shared metadata entries synced by the mailserver, indexed by mailbox ID.
+	sharedMetadata     map[imap.MailboxID]map[string][]byte
+	sharedMetadataLock sync.Mutex
+
 	allowMessageCreateWithUnknownMailboxID bool
 
 	updatesAllowedToFail int32
@@ -73,6 +81,7 @@ func NewDummy(usernames []string, password []byte, period time.Duration, flags,
 		updateQuitCh:        make(chan struct{}),
 		ticker:              ticker.New(period),
 		mailboxVisibilities: make(map[imap.MailboxID]imap.MailboxVisibility),
+		sharedMetadata:      make(map[imap.MailboxID]map[string][]byte),
 	}
 
 	go func() {
@@ -120,13 +129,13 @@ func (conn *Dummy) GetUpdates() <-chan imap.Update {
 	return conn.updateCh
 }
 
-func (conn *Dummy) CreateMailbox(_ context.Context, _ IMAPStateWrite, name []string) (imap.Mailbox, error) {
+func (conn *Dummy) CreateMailbox(_ context.Context, _ IMAPStateWrite, name []string, specialUse imap.FlagSet) (imap.Mailbox, error) {
 	exclusive, err := conn.validateName(name)
 	if err != nil {
 		return imap.Mailbox{}, err
 	}
 
-	mbox := conn.state.createMailbox(name, exclusive)
+	mbox := conn.state.createMailbox(name, exclusive, specialUse)
 
 	conn.pushUpdate(imap.NewMailboxCreated(mbox))
 
@@ -194,6 +203,98 @@ func (conn *Dummy) CreateMessage(ctx context.Context, _ IMAPStateWrite, mboxID i
 	return message, literal, nil
 }
 
+// CreateMessages creates all the messages with a single update, or none of them if any can't be parsed.
+func (conn *Dummy) CreateMessages(ctx context.Context, _ IMAPStateWrite, mboxID imap.MailboxID, reqs []CreateMessageReq) ([]CreatedMessage, error) {
+	conn.state.recordIMAPID(ctx)
+
+	parsed := make([]*imap.ParsedMessage, 0, len(reqs))
+
+	for _, req := range reqs {
+		p, err := imap.NewParsedMessage(req.Literal)
+		if err != nil {
+			return nil, err
+		}
+
+		parsed = append(parsed, p)
+	}
+
+	created := make([]CreatedMessage, 0, len(reqs))
+	updates := make([]*imap.MessageCreated, 0, len(reqs))
+
+	for i, req := range reqs {
+		message := conn.state.createMessage(
+			mboxID,
+			req.Literal,
+			parsed[i],
+			req.Flags.ContainsUnchecked(imap.FlagSeenLowerCase),
+			req.Flags.ContainsUnchecked(imap.FlagFlaggedLowerCase),
+			req.Flags,
+			req.Date,
+		)
+
+		created = append(created, CreatedMessage{Message: message, Literal: req.Literal})
+
+		updates = append(updates, &imap.MessageCreated{
+			Message:       message,
+			Literal:       req.Literal,
+			MailboxIDs:    []imap.MailboxID{mboxID},
+			ParsedMessage: parsed[i],
+		})
+	}
+
+	conn.pushUpdate(imap.NewMessagesCreated(conn.allowMessageCreateWithUnknownMailboxID, updates...))
+
+	return created, nil
+}
+
+// ReplaceMessage updates the message in place if it is replaced within the same mailbox, keeping it in its other
+// mailboxes; otherwise, the new message is created and the old one removed from its mailbox.
+func (conn *Dummy) ReplaceMessage(
+	ctx context.Context,
+	cache IMAPStateWrite,
+	messageID imap.MessageID,
+	mboxFromID, mboxToID imap.MailboxID,
+	literal []byte,
+	flags imap.FlagSet,
+	date time.Time,
+) (imap.Message, []byte, error) {
+	mboxIDs := conn.state.getMailboxIDs(messageID)
+
+	if mboxFromID != mboxToID || !slices.Contains(mboxIDs, mboxFromID) {
+		message, literal, err := conn.CreateMessage(ctx, cache, mboxToID, literal, flags, date)
+		if err != nil {
+			return imap.Message{}, nil, err
+		}
+
+		if err := conn.RemoveMessagesFromMailbox(ctx, cache, []imap.MessageID{messageID}, mboxFromID); err != nil {
+			return imap.Message{}, nil, err
+		}
+
+		return message, literal, nil
+	}
+
+	conn.state.recordIMAPID(ctx)
+
+	parsed, err := imap.NewParsedMessage(literal)
+	if err != nil {
+		return imap.Message{}, nil, err
+	}
+
+	message := conn.state.updateMessage(
+		messageID,
+		literal,
+		parsed,
+		flags.ContainsUnchecked(imap.FlagSeenLowerCase),
+		flags.ContainsUnchecked(imap.FlagFlaggedLowerCase),
+		flags,
+		date,
+	)
+
+	conn.pushUpdate(imap.NewMessageUpdated(message, literal, mboxIDs, parsed, false))
+
+	return message, literal, nil
+}
+
 func (conn *Dummy) AddMessagesToMailbox(_ context.Context, _ IMAPStateWrite, messageIDs []imap.MessageID, mboxID imap.MailboxID) error {
 	for _, messageID := range messageIDs {
 		conn.state.addMessageToMailbox(messageID, mboxID)
@@ -222,6 +323,17 @@ func (conn *Dummy) RemoveMessagesFromMailbox(_ context.Context, _ IMAPStateWrite
 	return nil
 }
 
+// DeleteMessages permanently deletes the messages, removing them from all their mailboxes.
+func (conn *Dummy) DeleteMessages(_ context.Context, _ IMAPStateWrite, messageIDs []imap.MessageID) error {
+	for _, messageID := range messageIDs {
+		conn.state.deleteMessage(messageID)
+
+		conn.pushUpdate(imap.NewMessagesDeleted(messageID))
+	}
+
+	return nil
+}
+
 func (conn *Dummy) MoveMessages(_ context.Context, _ IMAPStateWrite, messageIDs []imap.MessageID, mboxFromID, mboxToID imap.MailboxID) (bool, error) {
 	for _, messageID := range messageIDs {
 		conn.state.removeMessageFromMailbox(messageID, mboxFromID)
@@ -337,6 +449,64 @@ func (conn *Dummy) GetMailboxVisibility(_ context.Context, id imap.MailboxID) im
 	return visibility
 }
 
+// GetNamespaces exposes the folders and labels prefixes, if any, as personal namespaces.
+func (conn *Dummy) GetNamespaces(_ context.Context) imap.Namespaces {
+	namespaces := imap.DefaultNamespaces()
+
+	for _, pfx := range []string{conn.pfxFolder, conn.pfxLabel} {
+		if pfx != "" {
+			namespaces.Personal = append(namespaces.Personal, imap.Namespace{Prefix: []string{pfx}})
+		}
+	}
+
+	return namespaces
+}
+
+// GetQuota reports the quota set with SetQuota.
+func (conn *Dummy) GetQuota(_ context.Context) (imap.Quota, error) {
+	conn.quotaLock.Lock()
+	defer conn.quotaLock.Unlock()
+
+	return conn.quota, nil
+}
+
+func (conn *Dummy) SetQuota(quota imap.Quota) {
+	conn.quotaLock.Lock()
+	defer conn.quotaLock.Unlock()
+
+	conn.quota = quota
+}
+
+// SetSharedMetadata records the shared metadata entries of the mailbox.
+func (conn *Dummy) SetSharedMetadata(_ context.Context, mboxID imap.MailboxID, entries []imap.MetadataEntry) error {
+	conn.sharedMetadataLock.Lock()
+	defer conn.sharedMetadataLock.Unlock()
+
+	if _, ok := conn.sharedMetadata[mboxID]; !ok {
+		conn.sharedMetadata[mboxID] = make(map[string][]byte)
+	}
+
+	for _, entry := range entries {
+		if entry.Value == nil {
+			delete(conn.sharedMetadata[mboxID], entry.Name)
+		} else {
+			conn.sharedMetadata[mboxID][entry.Name] = entry.Value
+		}
+	}
+
+	return nil
+}
+
+// GetSharedMetadata returns the value of the shared metadata entry of the mailbox, if it was set.
+func (conn *Dummy) GetSharedMetadata(mboxID imap.MailboxID, name string) ([]byte, bool) {
+	conn.sharedMetadataLock.Lock()
+	defer conn.sharedMetadataLock.Unlock()
+
+	value, ok := conn.sharedMetadata[mboxID][name]
+
+	return value, ok
+}
+
 func (conn *Dummy) SetMailboxVisibility(id imap.MailboxID, visibility imap.MailboxVisibility) {
 	conn.mailboxVisibilities[id] = visibility
 }
@@ -9,7 +9,7 @@ func (conn *Dummy) SetFolderPrefix(pfx string) {
 
 	conn.pfxFolder = pfx
 
-	mbox := conn.state.createMailbox([]string{pfx}, true)
+	mbox := conn.state.createMailbox([]string{pfx}, true, imap.NewFlagSet())
 
 	mbox.Attributes = mbox.Attributes.Add(imap.AttrNoSelect)
 
@@ -21,7 +21,7 @@ func (conn *Dummy) SetLabelsPrefix(pfx string) {
 
 	conn.pfxLabel = pfx
 
-	mbox := conn.state.createMailbox([]string{pfx}, false)
+	mbox := conn.state.createMailbox([]string{pfx}, false, imap.NewFlagSet())
 
 	mbox.Attributes = mbox.Attributes.Add(imap.AttrNoSelect)
 
@@ -79,12 +79,13 @@ func (conn *Dummy) MessageCreated(message imap.Message, literal []byte, mboxIDs
 	}
 
 	conn.state.messages[message.ID] = &dummyMessage{
-		literal: literal,
-		seen:    message.Flags.Contains(imap.FlagSeen),
-		flagged: message.Flags.Contains(imap.FlagFlagged),
-		parsed:  parsedMessage,
-		date:    message.Date,
-		mboxIDs: mboxIDMap,
+		literal:  literal,
+		seen:     message.Flags.Contains(imap.FlagSeen),
+		flagged:  message.Flags.Contains(imap.FlagFlagged),
+		parsed:   parsedMessage,
+		date:     message.Date,
+		threadID: message.ThreadID,
+		mboxIDs:  mboxIDMap,
 	}
 
 	update := imap.NewMessagesCreated(conn.allowMessageCreateWithUnknownMailboxID, &imap.MessageCreated{
@@ -118,12 +119,13 @@ func (conn *Dummy) MessagesCreated(messages []imap.Message, literals [][]byte, m
 		}
 
 		conn.state.messages[messages[i].ID] = &dummyMessage{
-			literal: literals[i],
-			seen:    messages[i].Flags.Contains(imap.FlagSeen),
-			flagged: messages[i].Flags.Contains(imap.FlagFlagged),
-			parsed:  parsedMessage,
-			date:    messages[i].Date,
-			mboxIDs: mboxIDMap,
+			literal:  literals[i],
+			seen:     messages[i].Flags.Contains(imap.FlagSeen),
+			flagged:  messages[i].Flags.Contains(imap.FlagFlagged),
+			parsed:   parsedMessage,
+			date:     messages[i].Date,
+			threadID: messages[i].ThreadID,
+			mboxIDs:  mboxIDMap,
 		}
 
 		updates = append(updates, &imap.MessageCreated{
@@ -155,12 +157,13 @@ func (conn *Dummy) MessageUpdated(message imap.Message, literal []byte, mboxIDs
 	}
 
 	conn.state.messages[message.ID] = &dummyMessage{
-		literal: literal,
-		seen:    message.Flags.Contains(imap.FlagSeen),
-		flagged: message.Flags.Contains(imap.FlagFlagged),
-		parsed:  parsedMessage,
-		date:    message.Date,
-		mboxIDs: mboxIDMap,
+		literal:  literal,
+		seen:     message.Flags.Contains(imap.FlagSeen),
+		flagged:  message.Flags.Contains(imap.FlagFlagged),
+		parsed:   parsedMessage,
+		date:     message.Date,
+		threadID: message.ThreadID,
+		mboxIDs:  mboxIDMap,
 	}
 
 	conn.pushUpdate(imap.NewMessageUpdated(message, literal, mboxIDs, parsedMessage, false))
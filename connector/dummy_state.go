@@ -22,8 +22,9 @@ type dummyState struct {
 }
 
 type dummyMailbox struct {
-	mboxName  []string
-	exclusive bool
+	mboxName   []string
+	exclusive  bool
+	specialUse imap.FlagSet
 }
 
 type dummyMessage struct {
@@ -34,6 +35,7 @@ type dummyMessage struct {
 	forwarded bool
 	date      time.Time
 	flags     imap.FlagSet
+	threadID  string
 
 	mboxIDs map[imap.MailboxID]struct{}
 }
@@ -77,15 +79,16 @@ func (state *dummyState) getMailbox(mboxID imap.MailboxID) (imap.Mailbox, error)
 	return state.toMailbox(mboxID), nil
 }
 
-func (state *dummyState) createMailbox(name []string, exclusive bool) imap.Mailbox {
+func (state *dummyState) createMailbox(name []string, exclusive bool, specialUse imap.FlagSet) imap.Mailbox {
 	state.lock.Lock()
 	defer state.lock.Unlock()
 
 	mboxID := imap.MailboxID(uuid.NewString())
 
 	state.mailboxes[mboxID] = &dummyMailbox{
-		mboxName:  name,
-		exclusive: exclusive,
+		mboxName:   name,
+		exclusive:  exclusive,
+		specialUse: specialUse,
 	}
 
 	return state.toMailbox(mboxID)
@@ -225,6 +228,38 @@ func (state *dummyState) createMessage(
 	return state.toMessage(messageID)
 }
 
+// updateMessage replaces the content of the message, keeping its ID and mailboxes.
+func (state *dummyState) updateMessage(
+	messageID imap.MessageID,
+	literal []byte,
+	parsed *imap.ParsedMessage,
+	seen, flagged bool,
+	otherFlags imap.FlagSet,
+	date time.Time,
+) imap.Message {
+	state.lock.Lock()
+	defer state.lock.Unlock()
+
+	if seen {
+		otherFlags.RemoveFromSelf(imap.FlagSeen)
+	}
+
+	if flagged {
+		otherFlags.RemoveFromSelf(imap.FlagFlagged)
+	}
+
+	message := state.messages[messageID]
+
+	message.literal = literal
+	message.parsed = parsed
+	message.seen = seen
+	message.flagged = flagged
+	message.flags = otherFlags
+	message.date = date
+
+	return state.toMessage(messageID)
+}
+
 func (state *dummyState) addMessageToMailbox(messageID imap.MessageID, mboxID imap.MailboxID) {
 	state.lock.Lock()
 	defer state.lock.Unlock()
@@ -243,6 +278,13 @@ func (state *dummyState) removeMessageFromMailbox(messageID imap.MessageID, mbox
 	delete(state.messages[messageID].mboxIDs, mboxID)
 }
 
+func (state *dummyState) deleteMessage(messageID imap.MessageID) {
+	state.lock.Lock()
+	defer state.lock.Unlock()
+
+	delete(state.messages, messageID)
+}
+
 func (state *dummyState) setSeen(messageID imap.MessageID, seen bool) {
 	state.lock.Lock()
 	defer state.lock.Unlock()
@@ -311,12 +353,18 @@ func (state *dummyState) getMessageFlags(messageID imap.MessageID) imap.FlagSet
 }
 
 func (state *dummyState) toMailbox(mboxID imap.MailboxID) imap.Mailbox {
+	attrs := state.attrs
+
+	if specialUse := state.mailboxes[mboxID].specialUse; specialUse.Len() > 0 {
+		attrs = attrs.AddFlagSet(specialUse)
+	}
+
 	return imap.Mailbox{
 		ID:             mboxID,
 		Name:           state.mailboxes[mboxID].mboxName,
 		Flags:          state.flags,
 		PermanentFlags: state.permFlags,
-		Attributes:     state.attrs,
+		Attributes:     attrs,
 	}
 }
 
@@ -334,8 +382,9 @@ func (state *dummyState) toMessage(messageID imap.MessageID) imap.Message {
 	flags.AddFlagSetToSelf(state.messages[messageID].flags)
 
 	return imap.Message{
-		ID:    messageID,
-		Flags: flags,
-		Date:  state.messages[messageID].date,
+		ID:       messageID,
+		Flags:    flags,
+		Date:     state.messages[messageID].date,
+		ThreadID: state.messages[messageID].threadID,
 	}
 }
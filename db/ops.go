@@ -6,6 +6,8 @@ type ReadOnly interface {
 	MailboxReadOps
 	MessageReadOps
 	SubscriptionReadOps
+	MetadataReadOps
+	AccessKeyReadOps
 
 	// GetConnectorSettings returns true if no previous setting was ever stored before.
 	GetConnectorSettings(ctx context.Context) (string, bool, error)
@@ -16,6 +18,8 @@ type Transaction interface {
 	MailboxWriteOps
 	MessageWriteOps
 	SubscriptionWriteOps
+	MetadataWriteOps
+	AccessKeyWriteOps
 
 	StoreConnectorSettings(ctx context.Context, settings string) error
 }
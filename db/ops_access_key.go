@@ -0,0 +1,19 @@
+package db
+
+import (
+	"context"
+
+	"github.com/ProtonMail/gluon/imap"
+)
+
+type AccessKeyReadOps interface {
+	// GetMailboxAccessKey returns the key used to authorize URLAUTH (RFC4467) URLs of the mailbox with the given ID.
+	GetMailboxAccessKey(ctx context.Context, mboxID imap.InternalMailboxID) ([]byte, error)
+}
+
+type AccessKeyWriteOps interface {
+	SetMailboxAccessKey(ctx context.Context, mboxID imap.InternalMailboxID, key []byte) error
+
+	// DeleteMailboxAccessKeys removes the access keys of the given mailboxes, or of all mailboxes if none is given.
+	DeleteMailboxAccessKeys(ctx context.Context, mboxIDs ...imap.InternalMailboxID) error
+}
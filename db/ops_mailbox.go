@@ -39,10 +39,14 @@ type MailboxReadOps interface {
 
 	GetMailboxRecentCount(ctx context.Context, mboxID imap.InternalMailboxID) (int, error)
 
+	GetMailboxUnseenCount(ctx context.Context, mboxID imap.InternalMailboxID) (int, error)
+
 	GetMailboxMessageCount(ctx context.Context, mboxID imap.InternalMailboxID) (int, error)
 
 	GetMailboxMessageCountWithRemoteID(ctx context.Context, mboxID imap.MailboxID) (int, error)
 
+	GetMailboxSize(ctx context.Context, mboxID imap.InternalMailboxID) (int64, error)
+
 	GetMailboxFlags(ctx context.Context, mboxID imap.InternalMailboxID) (imap.FlagSet, error)
 
 	GetMailboxPermanentFlags(ctx context.Context, mboxID imap.InternalMailboxID) (imap.FlagSet, error)
@@ -53,8 +57,18 @@ type MailboxReadOps interface {
 
 	GetMailboxMessageCountAndUID(ctx context.Context, mboxID imap.InternalMailboxID) (int, imap.UID, error)
 
+	GetMailboxHighestModSeq(ctx context.Context, mboxID imap.InternalMailboxID) (imap.ModSeq, error)
+
+	GetMailboxExpungedUIDsSince(ctx context.Context, mboxID imap.InternalMailboxID, modSeq imap.ModSeq) ([]imap.UID, error)
+
+	// GetMailboxPrunedExpungedModSeq returns the highest mod sequence of the expunged UIDs which were pruned from the
+	// mailbox. The UIDs expunged since an older mod sequence are no longer all known.
+	GetMailboxPrunedExpungedModSeq(ctx context.Context, mboxID imap.InternalMailboxID) (imap.ModSeq, error)
+
 	GetMailboxMessageForNewSnapshot(ctx context.Context, mboxID imap.InternalMailboxID) ([]SnapshotMessageResult, error)
 
+	GetMailboxMessageIDWithUID(ctx context.Context, mboxID imap.InternalMailboxID, uid imap.UID) (MessageIDPair, error)
+
 	MailboxTranslateRemoteIDs(ctx context.Context, mboxIDs []imap.MailboxID) ([]imap.InternalMailboxID, error)
 
 	MailboxFilterContains(ctx context.Context, mboxID imap.InternalMailboxID, messageIDs []MessageIDPair) ([]imap.InternalMessageID, error)
@@ -16,12 +16,27 @@ type MessageReadOps interface {
 
 	GetTotalMessageCount(ctx context.Context) (int, error)
 
+	// GetTotalActiveMessageCount returns the number of messages that are not marked as deleted.
+	GetTotalActiveMessageCount(ctx context.Context) (int, error)
+
+	GetTotalMessageSize(ctx context.Context) (int64, error)
+
 	GetMessageRemoteID(ctx context.Context, id imap.InternalMessageID) (imap.MessageID, error)
 
 	GetImportedMessageData(ctx context.Context, id imap.InternalMessageID) (*MessageWithFlags, error)
 
 	GetMessageDateAndSize(ctx context.Context, id imap.InternalMessageID) (time.Time, int, error)
 
+	// GetMessagesModSeq returns the mod sequences of the messages in the mailbox. Besides the changes of the messages
+	// themselves, these account for the changes which only affected them in the mailbox, such as their \Deleted flag.
+	GetMessagesModSeq(ctx context.Context, mboxID imap.InternalMailboxID, ids []imap.InternalMessageID) (map[imap.InternalMessageID]imap.ModSeq, error)
+
+	GetMessagesThreadID(ctx context.Context, ids []imap.InternalMessageID) (map[imap.InternalMessageID]string, error)
+
+	// GetMessagesSaveDate returns the dates the messages were saved in the mailbox. Messages whose save date is not
+	// known have their internal date instead.
+	GetMessagesSaveDate(ctx context.Context, mboxID imap.InternalMailboxID, ids []imap.InternalMessageID) (map[imap.InternalMessageID]time.Time, error)
+
 	GetMessageMailboxIDs(ctx context.Context, id imap.InternalMessageID) ([]imap.InternalMailboxID, error)
 
 	GetMessagesFlags(ctx context.Context, ids []imap.InternalMessageID) ([]MessageFlagSet, error)
@@ -52,6 +67,8 @@ type MessageWriteOps interface {
 
 	UpdateRemoteMessageID(ctx context.Context, internalID imap.InternalMessageID, remoteID imap.MessageID) error
 
+	SetMessagePreview(ctx context.Context, id imap.InternalMessageID, preview string) error
+
 	AddFlagToMessages(ctx context.Context, ids []imap.InternalMessageID, flag string) error
 
 	RemoveFlagFromMessages(ctx context.Context, ids []imap.InternalMessageID, flag string) error
@@ -66,6 +83,9 @@ type CreateMessageReq struct {
 	Body        string
 	Structure   string
 	Envelope    string
+
+	// Preview is nil if the preview of the message couldn't be built.
+	Preview *string
 }
 
 type MessageFlagSet struct {
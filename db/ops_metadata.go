@@ -0,0 +1,19 @@
+package db
+
+import (
+	"context"
+
+	"github.com/ProtonMail/gluon/imap"
+)
+
+type MetadataReadOps interface {
+	// GetMetadata returns the metadata entries (RFC5464) of the mailbox with the given ID, or of the server if the ID
+	// is zero. The entries are sorted by name.
+	GetMetadata(ctx context.Context, mboxID imap.InternalMailboxID) ([]imap.MetadataEntry, error)
+}
+
+type MetadataWriteOps interface {
+	// SetMetadata sets the metadata entries of the mailbox with the given ID, or of the server if the ID is zero.
+	// Entries with a nil value are removed.
+	SetMetadata(ctx context.Context, mboxID imap.InternalMailboxID, entries []imap.MetadataEntry) error
+}
@@ -85,11 +85,12 @@ type MailboxAttr struct {
 }
 
 type Mailbox struct {
-	ID          imap.InternalMailboxID
-	RemoteID    imap.MailboxID
-	Name        string
-	UIDValidity imap.UID
-	Subscribed  bool
+	ID            imap.InternalMailboxID
+	RemoteID      imap.MailboxID
+	Name          string
+	UIDValidity   imap.UID
+	Subscribed    bool
+	HighestModSeq imap.ModSeq
 }
 
 type MailboxWithAttr struct {
@@ -106,6 +107,11 @@ type Message struct {
 	BodyStructure string
 	Envelope      string
 	Deleted       bool
+	ModSeq        imap.ModSeq
+	ThreadID      string
+
+	// Preview is nil for messages created before previews were stored.
+	Preview *string
 }
 
 type MessageWithFlags struct {
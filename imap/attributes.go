@@ -1,11 +1,20 @@
 package imap
 
+import "strings"
+
 const (
 	AttrNoSelect    = `\Noselect`
 	AttrNoInferiors = `\Noinferiors`
 	AttrMarked      = `\Marked`
 	AttrUnmarked    = `\Unmarked`
 
+	// LIST-EXTENDED attributes as defined in RFC-5258.
+	AttrNonExistent   = `\NonExistent`
+	AttrSubscribed    = `\Subscribed`
+	AttrRemote        = `\Remote`
+	AttrHasChildren   = `\HasChildren`
+	AttrHasNoChildren = `\HasNoChildren`
+
 	// Special Use attributes as defined in RFC-6154.
 	AttrAll     = `\All`
 	AttrArchive = `\Archive`
@@ -15,3 +24,20 @@ const (
 	AttrSent    = `\Sent`
 	AttrTrash   = `\Trash`
 )
+
+// IsSpecialUseAttribute returns whether the given mailbox attribute denotes a special use as defined in RFC-6154.
+func IsSpecialUseAttribute(attr string) bool {
+	switch {
+	case
+		strings.EqualFold(attr, AttrAll),
+		strings.EqualFold(attr, AttrArchive),
+		strings.EqualFold(attr, AttrDrafts),
+		strings.EqualFold(attr, AttrFlagged),
+		strings.EqualFold(attr, AttrJunk),
+		strings.EqualFold(attr, AttrSent),
+		strings.EqualFold(attr, AttrTrash):
+		return true
+	}
+
+	return false
+}
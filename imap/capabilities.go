@@ -4,6 +4,7 @@ type Capability string
 
 const (
 	IMAP4rev1 Capability = `IMAP4rev1`
+	IMAP4rev2 Capability = `IMAP4rev2`
 	StartTLS  Capability = `STARTTLS`
 	IDLE      Capability = `IDLE`
 	UNSELECT  Capability = `UNSELECT`
@@ -11,15 +12,80 @@ const (
 	MOVE      Capability = `MOVE`
 	ID        Capability = `ID`
 	AUTHPLAIN Capability = `AUTH=PLAIN`
+	CONDSTORE Capability = `CONDSTORE`
+	QRESYNC   Capability = `QRESYNC`
+	ENABLE    Capability = `ENABLE`
+	NAMESPACE Capability = `NAMESPACE`
+
+	SPECIALUSE       Capability = `SPECIAL-USE`
+	CREATESPECIALUSE Capability = `CREATE-SPECIAL-USE`
+
+	LISTEXTENDED Capability = `LIST-EXTENDED`
+	LISTSTATUS   Capability = `LIST-STATUS`
+
+	LITERALPLUS  Capability = `LITERAL+`
+	LITERALMINUS Capability = `LITERAL-`
+
+	COMPRESSDEFLATE Capability = `COMPRESS=DEFLATE`
+
+	ESEARCH   Capability = `ESEARCH`
+	SEARCHRES Capability = `SEARCHRES`
+
+	QUOTA           Capability = `QUOTA`
+	QUOTARESSTORAGE Capability = `QUOTA=RES-STORAGE`
+	QUOTARESMESSAGE Capability = `QUOTA=RES-MESSAGE`
+	STATUSSIZE      Capability = `STATUS=SIZE`
+
+	METADATA Capability = `METADATA`
+
+	BINARY Capability = `BINARY`
+
+	UTF8ACCEPT Capability = `UTF8=ACCEPT`
+
+	NOTIFY Capability = `NOTIFY`
+
+	MULTIAPPEND Capability = `MULTIAPPEND`
+	CATENATE    Capability = `CATENATE`
+	URLAUTH     Capability = `URLAUTH`
+
+	OBJECTID Capability = `OBJECTID`
+
+	PREVIEW Capability = `PREVIEW`
+
+	SAVEDATE Capability = `SAVEDATE`
+	WITHIN   Capability = `WITHIN`
+
+	UIDONLY Capability = `UIDONLY`
+
+	PARTIAL Capability = `PARTIAL`
+
+	REPLACE Capability = `REPLACE`
+
+	SORT                 Capability = `SORT`
+	THREADORDEREDSUBJECT Capability = `THREAD=ORDEREDSUBJECT`
+	THREADREFERENCES     Capability = `THREAD=REFERENCES`
 )
 
 func IsCapabilityAvailableBeforeAuth(c Capability) bool {
 	switch c {
-	case IMAP4rev1, StartTLS, IDLE, ID, AUTHPLAIN:
+	case IMAP4rev1, IMAP4rev2, StartTLS, IDLE, ID, AUTHPLAIN, LITERALPLUS, LITERALMINUS:
 		return true
-	case UNSELECT, UIDPLUS, MOVE:
+	case UNSELECT, UIDPLUS, MOVE, CONDSTORE, QRESYNC, ENABLE, NAMESPACE, SPECIALUSE, CREATESPECIALUSE, LISTEXTENDED, LISTSTATUS, COMPRESSDEFLATE, SORT, THREADORDEREDSUBJECT, THREADREFERENCES,
+		ESEARCH, SEARCHRES, QUOTA, QUOTARESSTORAGE, QUOTARESMESSAGE, STATUSSIZE,
+		METADATA, BINARY, UTF8ACCEPT, NOTIFY, MULTIAPPEND, CATENATE, URLAUTH, OBJECTID, PREVIEW, SAVEDATE, WITHIN, UIDONLY, PARTIAL, REPLACE:
 		return false
 	}
 
 	return false
 }
+
+// IsCapabilityEnableable returns whether the client must enable the given capability with ENABLE (RFC5161) before
+// the server may send the responses it introduces.
+func IsCapabilityEnableable(c Capability) bool {
+	switch c {
+	case CONDSTORE, QRESYNC, IMAP4rev2, UTF8ACCEPT, UIDONLY:
+		return true
+	}
+
+	return false
+}
@@ -2,6 +2,7 @@ package command
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/ProtonMail/gluon/rfcparser"
@@ -12,22 +13,65 @@ type Append struct {
 	Flags    []string
 	DateTime time.Time
 	Literal  []byte
+
+	// UTF8 is set when the message was sent with the UTF8 data extension (RFC6855) and may have UTF-8 headers.
+	UTF8 bool
+
+	// Catenate holds the parts the message is built from with CATENATE (RFC4469), in which case Literal is empty.
+	Catenate []CatenatePart
+
+	// Additional holds the messages following the first one when the client appends several messages at once
+	// with MULTIAPPEND (RFC3502).
+	Additional []AppendMessage
+}
+
+// AppendMessage is one of the messages of an APPEND command.
+type AppendMessage struct {
+	Flags    []string
+	DateTime time.Time
+	Literal  []byte
+	UTF8     bool
+	Catenate []CatenatePart
+}
+
+// CatenatePart is a part of a message built with CATENATE (RFC4469): either some text or the URL of (a part of) an
+// existing message.
+type CatenatePart struct {
+	Text []byte
+	URL  string
+}
+
+func (m AppendMessage) HasDateTime() bool {
+	return m.DateTime != time.Time{}
+}
+
+// Messages returns all the messages of the command, in order.
+func (l Append) Messages() []AppendMessage {
+	return append([]AppendMessage{{
+		Flags:    l.Flags,
+		DateTime: l.DateTime,
+		Literal:  l.Literal,
+		UTF8:     l.UTF8,
+		Catenate: l.Catenate,
+	}}, l.Additional...)
 }
 
 func (l Append) String() string {
-	return fmt.Sprintf("APPEND '%v' Flags='%v' DateTime='%v' Literal=%v",
+	return fmt.Sprintf("APPEND '%v' Flags='%v' DateTime='%v' Literal=%v Additional=%v",
 		l.Mailbox,
 		l.Flags,
 		l.DateTime,
 		l.Literal,
+		len(l.Additional),
 	)
 }
 
 func (l Append) SanitizedString() string {
-	return fmt.Sprintf("APPEND '%v' Flags='%v' DateTime='%v'",
+	return fmt.Sprintf("APPEND '%v' Flags='%v' DateTime='%v' Additional=%v",
 		sanitizeString(l.Mailbox),
 		l.Flags,
 		l.DateTime,
+		len(l.Additional),
 	)
 }
 
@@ -38,6 +82,8 @@ func (l Append) HasDateTime() bool {
 type AppendCommandParser struct{}
 
 func (AppendCommandParser) FromParser(p *rfcparser.Parser) (Payload, error) {
+	// append          = "APPEND" SP mailbox 1*append-message
+	// append-message  = SP [flag-list SP] [date-time SP] append-data
 	if err := p.Consume(rfcparser.TokenTypeSP, "expected space after command"); err != nil {
 		return nil, err
 	}
@@ -51,47 +97,198 @@ func (AppendCommandParser) FromParser(p *rfcparser.Parser) (Payload, error) {
 		return nil, err
 	}
 
-	var appendFlags []string
+	first, err := parseAppendMessage(p)
+	if err != nil {
+		return nil, err
+	}
+
+	var additional []AppendMessage
+
+	// Further messages may follow with MULTIAPPEND (RFC3502).
+	for {
+		if ok, err := p.Matches(rfcparser.TokenTypeSP); err != nil {
+			return nil, err
+		} else if !ok {
+			break
+		}
+
+		message, err := parseAppendMessage(p)
+		if err != nil {
+			return nil, err
+		}
+
+		additional = append(additional, message)
+	}
+
+	return &Append{
+		Mailbox:    mailbox.Value,
+		Literal:    first.Literal,
+		Flags:      first.Flags,
+		DateTime:   first.DateTime,
+		UTF8:       first.UTF8,
+		Catenate:   first.Catenate,
+		Additional: additional,
+	}, nil
+}
+
+func parseAppendMessage(p *rfcparser.Parser) (AppendMessage, error) {
+	var message AppendMessage
 
 	// check if we have flags.
 	flagList, hasFlagList, err := TryParseFlagList(p)
 	if err != nil {
-		return nil, err
+		return AppendMessage{}, err
 	} else if hasFlagList {
-		appendFlags = flagList
+		message.Flags = flagList
 	}
 
 	if hasFlagList {
 		if err := p.Consume(rfcparser.TokenTypeSP, "expected space after flag list"); err != nil {
-			return nil, err
+			return AppendMessage{}, err
 		}
 	}
 
-	var dateTime time.Time
 	// check date time.
-	if !p.Check(rfcparser.TokenTypeLCurly) {
+	if p.Check(rfcparser.TokenTypeDQuote) {
 		dt, err := ParseDateTime(p)
 		if err != nil {
-			return nil, err
+			return AppendMessage{}, err
 		}
 
-		dateTime = dt
+		message.DateTime = dt
 
 		if err := p.Consume(rfcparser.TokenTypeSP, "expected space after flag list"); err != nil {
+			return AppendMessage{}, err
+		}
+	}
+
+	// read literal, which may be a literal8 (RFC3516), wrapped by the UTF8 data extension (RFC6855) or built from
+	// several parts with CATENATE (RFC4469).
+	//  append-data =/ "UTF8" SP "(" literal8 ")"
+	//  append-data =/ "CATENATE" SP "(" cat-part *(SP cat-part) ")"
+	if p.Check(rfcparser.TokenTypeChar) {
+		keyword, err := p.ParseAtom()
+		if err != nil {
+			return AppendMessage{}, err
+		}
+
+		switch strings.ToUpper(keyword) {
+		case "UTF8":
+			if err := p.Consume(rfcparser.TokenTypeSP, "expected space after UTF8"); err != nil {
+				return AppendMessage{}, err
+			}
+
+			if err := p.Consume(rfcparser.TokenTypeLParen, "expected ( for UTF8 data start"); err != nil {
+				return AppendMessage{}, err
+			}
+
+			l, err := p.ParseLiteral8()
+			if err != nil {
+				return AppendMessage{}, err
+			}
+
+			if err := p.Consume(rfcparser.TokenTypeRParen, "expected ) for UTF8 data end"); err != nil {
+				return AppendMessage{}, err
+			}
+
+			message.Literal, message.UTF8 = l, true
+
+		case "CATENATE":
+			parts, err := parseCatenateParts(p)
+			if err != nil {
+				return AppendMessage{}, err
+			}
+
+			message.Catenate = parts
+
+		default:
+			return AppendMessage{}, p.MakeError(fmt.Sprintf("unknown append data '%v'", keyword))
+		}
+	} else if p.Check(rfcparser.TokenTypeTilde) {
+		l, err := p.ParseLiteral8()
+		if err != nil {
+			return AppendMessage{}, err
+		}
+
+		message.Literal = l
+	} else {
+		l, err := p.ParseLiteral()
+		if err != nil {
+			return AppendMessage{}, err
+		}
+
+		message.Literal = l
+	}
+
+	return message, nil
+}
+
+func parseCatenateParts(p *rfcparser.Parser) ([]CatenatePart, error) {
+	// cat-part        = text-literal / url
+	// text-literal    = "TEXT" SP literal
+	// url             = "URL" SP astring
+	if err := p.Consume(rfcparser.TokenTypeSP, "expected space after CATENATE"); err != nil {
+		return nil, err
+	}
+
+	if err := p.Consume(rfcparser.TokenTypeLParen, "expected ( for CATENATE parts start"); err != nil {
+		return nil, err
+	}
+
+	var parts []CatenatePart
+
+	for {
+		kind, err := p.ParseAtom()
+		if err != nil {
 			return nil, err
 		}
+
+		if err := p.Consume(rfcparser.TokenTypeSP, "expected space after CATENATE part type"); err != nil {
+			return nil, err
+		}
+
+		switch strings.ToUpper(kind) {
+		case "TEXT":
+			// The text may be a literal8 when BINARY (RFC3516) is supported.
+			var (
+				text []byte
+				err  error
+			)
+
+			if p.Check(rfcparser.TokenTypeTilde) {
+				text, err = p.ParseLiteral8()
+			} else {
+				text, err = p.ParseLiteral()
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			parts = append(parts, CatenatePart{Text: text})
+
+		case "URL":
+			url, err := p.ParseAString()
+			if err != nil {
+				return nil, err
+			}
+
+			parts = append(parts, CatenatePart{URL: url.Value})
+
+		default:
+			return nil, p.MakeError(fmt.Sprintf("unknown CATENATE part '%v'", kind))
+		}
+
+		if ok, err := p.Matches(rfcparser.TokenTypeSP); err != nil {
+			return nil, err
+		} else if !ok {
+			break
+		}
 	}
 
-	// read literal.
-	literal, err := p.ParseLiteral()
-	if err != nil {
+	if err := p.Consume(rfcparser.TokenTypeRParen, "expected ) for CATENATE parts end"); err != nil {
 		return nil, err
 	}
 
-	return &Append{
-		Mailbox:  mailbox.Value,
-		Literal:  literal,
-		Flags:    appendFlags,
-		DateTime: dateTime,
-	}, nil
+	return parts, nil
 }
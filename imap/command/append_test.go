@@ -59,6 +59,21 @@ func TestParser_AppendCommandWithLiteralOnly(t *testing.T) {
 	require.Equal(t, "A003", p.LastParsedTag())
 }
 
+func TestParser_AppendCommandWithLiteral8(t *testing.T) {
+	input := toIMAPLine("A003 APPEND saved-messages ~{5}", "a\x00b\r\n")
+	s := rfcparser.NewScanner(bytes.NewReader(input))
+	p := NewParser(s)
+
+	expected := Command{Tag: "A003", Payload: &Append{
+		Mailbox: "saved-messages",
+		Literal: []byte("a\x00b\r\n"),
+	}}
+
+	cmd, err := p.Parse()
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
 func TestParser_AppendCommandWithFlagAndLiteral(t *testing.T) {
 	input := toIMAPLine(`A003 APPEND saved-messages (\Seen) {23}`, `My message body is here`)
 	s := rfcparser.NewScanner(bytes.NewReader(input))
@@ -112,3 +127,82 @@ func TestParser_AppendWithUTF8Literal(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, expected, cmd)
 }
+
+func TestParser_AppendCommandWithUTF8Data(t *testing.T) {
+	const literal = "Subject: Grüße\r\n\r\nHallo"
+	input := toIMAPLine(fmt.Sprintf(`A003 APPEND saved-messages (\Seen) "15-Nov-1984 13:37:01 +0730" UTF8 (~{%v}`, len(literal)), literal+")")
+
+	s := rfcparser.NewScanner(bytes.NewReader(input))
+	p := NewParser(s)
+
+	expected := Command{Tag: "A003", Payload: &Append{
+		Mailbox:  "saved-messages",
+		Flags:    []string{`\Seen`},
+		Literal:  []byte(literal),
+		DateTime: buildAppendDateTime(1984, time.November, 15, 13, 37, 1, 07, 30, false),
+		UTF8:     true,
+	}}
+
+	cmd, err := p.Parse()
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_AppendCommandWithMultipleMessages(t *testing.T) {
+	input := toIMAPLine(
+		`A003 APPEND saved-messages (\Seen) {5}`,
+		`first {6}`,
+		`second (\Flagged) "15-Nov-1984 13:37:01 +0730" ~{5}`,
+		`third`,
+	)
+
+	s := rfcparser.NewScanner(bytes.NewReader(input))
+	p := NewParser(s)
+
+	expected := Command{Tag: "A003", Payload: &Append{
+		Mailbox: "saved-messages",
+		Flags:   []string{`\Seen`},
+		Literal: []byte("first"),
+		Additional: []AppendMessage{
+			{Literal: []byte("second")},
+			{
+				Flags:    []string{`\Flagged`},
+				DateTime: buildAppendDateTime(1984, time.November, 15, 13, 37, 1, 07, 30, false),
+				Literal:  []byte("third"),
+			},
+		},
+	}}
+
+	cmd, err := p.Parse()
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+	require.Len(t, cmd.Payload.(*Append).Messages(), 3)
+}
+
+func TestParser_AppendCommandWithCatenate(t *testing.T) {
+	input := toIMAPLine(
+		`A003 APPEND Drafts (\Seen) CATENATE (URL "/Drafts;UIDVALIDITY=385759045/;UID=20/;section=HEADER" TEXT {4}`,
+		`body URL /Drafts/;UID=20/;section=1.2) CATENATE (TEXT ~{5}`,
+		`other)`,
+	)
+
+	s := rfcparser.NewScanner(bytes.NewReader(input))
+	p := NewParser(s)
+
+	expected := Command{Tag: "A003", Payload: &Append{
+		Mailbox: "Drafts",
+		Flags:   []string{`\Seen`},
+		Catenate: []CatenatePart{
+			{URL: "/Drafts;UIDVALIDITY=385759045/;UID=20/;section=HEADER"},
+			{Text: []byte("body")},
+			{URL: "/Drafts/;UID=20/;section=1.2"},
+		},
+		Additional: []AppendMessage{
+			{Catenate: []CatenatePart{{Text: []byte("other")}}},
+		},
+	}}
+
+	cmd, err := p.Parse()
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
@@ -0,0 +1,41 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ProtonMail/gluon/rfcparser"
+)
+
+type Compress struct {
+	Mechanism string
+}
+
+func (l Compress) String() string {
+	return fmt.Sprintf("COMPRESS %v", l.Mechanism)
+}
+
+func (l Compress) SanitizedString() string {
+	return l.String()
+}
+
+type CompressCommandParser struct{}
+
+func (CompressCommandParser) FromParser(p *rfcparser.Parser) (Payload, error) {
+	// compress    = "COMPRESS" SP algorithm
+	// algorithm   = "DEFLATE"
+	if err := p.Consume(rfcparser.TokenTypeSP, "expected space after command"); err != nil {
+		return nil, err
+	}
+
+	mechanism, err := p.ParseAtom()
+	if err != nil {
+		return nil, err
+	}
+
+	if mechanism = strings.ToUpper(mechanism); mechanism != "DEFLATE" {
+		return nil, p.MakeError(fmt.Sprintf("unsupported compression mechanism '%v'", mechanism))
+	}
+
+	return &Compress{Mechanism: mechanism}, nil
+}
@@ -0,0 +1,20 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_CompressCommand(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &Compress{Mechanism: "DEFLATE"}}
+
+	cmd, err := testParseCommand(`tag COMPRESS deflate`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_CompressCommandUnsupportedMechanism(t *testing.T) {
+	_, err := testParseCommand(`tag COMPRESS LZ4`)
+	require.Error(t, err)
+}
@@ -2,15 +2,23 @@ package command
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/ProtonMail/gluon/rfcparser"
 )
 
 type Create struct {
 	Mailbox string
+
+	// SpecialUse holds the special-use attributes requested for the new mailbox (RFC6154).
+	SpecialUse []string
 }
 
 func (l Create) String() string {
+	if len(l.SpecialUse) > 0 {
+		return fmt.Sprintf("CREATE '%v' (USE (%v))", l.Mailbox, strings.Join(l.SpecialUse, " "))
+	}
+
 	return fmt.Sprintf("CREATE '%v'", l.Mailbox)
 }
 
@@ -21,7 +29,7 @@ func (l Create) SanitizedString() string {
 type CreateCommandParser struct{}
 
 func (CreateCommandParser) FromParser(p *rfcparser.Parser) (Payload, error) {
-	// create          = "CREATE" SP mailbox
+	// create          = "CREATE" SP mailbox [create-params]
 	if err := p.Consume(rfcparser.TokenTypeSP, "expected space after command"); err != nil {
 		return nil, err
 	}
@@ -31,7 +39,100 @@ func (CreateCommandParser) FromParser(p *rfcparser.Parser) (Payload, error) {
 		return nil, err
 	}
 
+	specialUse, err := parseCreateParams(p)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Create{
-		Mailbox: mailbox.Value,
+		Mailbox:    mailbox.Value,
+		SpecialUse: specialUse,
 	}, nil
 }
+
+func parseCreateParams(p *rfcparser.Parser) ([]string, error) {
+	// create-params   = SP "(" create-param *( SP create-param) ")"
+	// create-param    = "USE" SP "(" [use-attr *(SP use-attr)] ")"
+	// use-attr        = "\All" / "\Archive" / "\Drafts" / "\Flagged" / "\Junk" / "\Sent" / "\Trash" / use-attr-ext
+	// use-attr-ext    = "\" atom
+	if ok, err := p.Matches(rfcparser.TokenTypeSP); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, nil
+	}
+
+	if err := p.Consume(rfcparser.TokenTypeLParen, "expected ( for create params start"); err != nil {
+		return nil, err
+	}
+
+	var specialUse []string
+
+	for {
+		param, err := p.ParseAtom()
+		if err != nil {
+			return nil, err
+		}
+
+		if !strings.EqualFold(param, "use") {
+			return nil, p.MakeError(fmt.Sprintf("unknown create param '%v'", param))
+		}
+
+		if err := p.Consume(rfcparser.TokenTypeSP, "expected space after USE"); err != nil {
+			return nil, err
+		}
+
+		attrs, err := parseUseAttrList(p)
+		if err != nil {
+			return nil, err
+		}
+
+		specialUse = append(specialUse, attrs...)
+
+		if ok, err := p.Matches(rfcparser.TokenTypeSP); err != nil {
+			return nil, err
+		} else if !ok {
+			break
+		}
+	}
+
+	if err := p.Consume(rfcparser.TokenTypeRParen, "expected ) for create params end"); err != nil {
+		return nil, err
+	}
+
+	return specialUse, nil
+}
+
+func parseUseAttrList(p *rfcparser.Parser) ([]string, error) {
+	if err := p.Consume(rfcparser.TokenTypeLParen, "expected ( for use attributes start"); err != nil {
+		return nil, err
+	}
+
+	var attrs []string
+
+	if !p.Check(rfcparser.TokenTypeRParen) {
+		for {
+			if err := p.Consume(rfcparser.TokenTypeBackslash, `expected \ at start of use attribute`); err != nil {
+				return nil, err
+			}
+
+			attr, err := p.ParseAtom()
+			if err != nil {
+				return nil, err
+			}
+
+			attrs = append(attrs, `\`+attr)
+
+			if ok, err := p.Matches(rfcparser.TokenTypeSP); err != nil {
+				return nil, err
+			} else if !ok {
+				break
+			}
+		}
+	}
+
+	if err := p.Consume(rfcparser.TokenTypeRParen, "expected ) for use attributes end"); err != nil {
+		return nil, err
+	}
+
+	return attrs, nil
+}
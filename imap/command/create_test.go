@@ -23,3 +23,41 @@ func TestParser_CreateCommand(t *testing.T) {
 	require.Equal(t, "create", p.LastParsedCommand())
 	require.Equal(t, "tag", p.LastParsedTag())
 }
+
+func TestParser_CreateCommandWithSpecialUse(t *testing.T) {
+	input := toIMAPLine(`tag CREATE MySpecial (USE (\Drafts \Sent))`)
+	s := rfcparser.NewScanner(bytes.NewReader(input))
+	p := NewParser(s)
+
+	expected := Command{Tag: "tag", Payload: &Create{
+		Mailbox:    "MySpecial",
+		SpecialUse: []string{`\Drafts`, `\Sent`},
+	}}
+
+	cmd, err := p.Parse()
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_CreateCommandWithEmptyUse(t *testing.T) {
+	input := toIMAPLine(`tag CREATE Foo (USE ())`)
+	s := rfcparser.NewScanner(bytes.NewReader(input))
+	p := NewParser(s)
+
+	expected := Command{Tag: "tag", Payload: &Create{
+		Mailbox: "Foo",
+	}}
+
+	cmd, err := p.Parse()
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_CreateCommandWithUnknownParam(t *testing.T) {
+	input := toIMAPLine(`tag CREATE Foo (BAR (\Sent))`)
+	s := rfcparser.NewScanner(bytes.NewReader(input))
+	p := NewParser(s)
+
+	_, err := p.Parse()
+	require.Error(t, err)
+}
@@ -0,0 +1,49 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ProtonMail/gluon/rfcparser"
+)
+
+type Enable struct {
+	Capabilities []string
+}
+
+func (l Enable) String() string {
+	return fmt.Sprintf("ENABLE %v", strings.Join(l.Capabilities, " "))
+}
+
+func (l Enable) SanitizedString() string {
+	return l.String()
+}
+
+type EnableCommandParser struct{}
+
+func (EnableCommandParser) FromParser(p *rfcparser.Parser) (Payload, error) {
+	// enable          = "ENABLE" 1*(SP capability)
+	// capability      = ("AUTH=" auth-type) / atom
+	var capabilities []string
+
+	for {
+		if ok, err := p.Matches(rfcparser.TokenTypeSP); err != nil {
+			return nil, err
+		} else if !ok {
+			break
+		}
+
+		capability, err := p.ParseAtom()
+		if err != nil {
+			return nil, err
+		}
+
+		capabilities = append(capabilities, strings.ToUpper(capability))
+	}
+
+	if len(capabilities) == 0 {
+		return nil, p.MakeError("expected at least one capability")
+	}
+
+	return &Enable{Capabilities: capabilities}, nil
+}
@@ -0,0 +1,22 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_EnableCommand(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &Enable{
+		Capabilities: []string{"QRESYNC", "CONDSTORE"},
+	}}
+
+	cmd, err := testParseCommand(`tag ENABLE qresync CONDSTORE`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_EnableCommandWithoutCapabilities(t *testing.T) {
+	_, err := testParseCommand(`tag ENABLE`)
+	require.Error(t, err)
+}
@@ -23,3 +23,14 @@ func TestParser_ExamineCommand(t *testing.T) {
 	require.Equal(t, "examine", p.LastParsedCommand())
 	require.Equal(t, "tag", p.LastParsedTag())
 }
+
+func TestParser_ExamineCommandCondStore(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &Examine{
+		Mailbox:   "INBOX",
+		CondStore: true,
+	}}
+
+	cmd, err := testParseCommand(`tag EXAMINE INBOX (condstore)`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
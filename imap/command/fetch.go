@@ -14,9 +14,33 @@ type FetchAttribute interface {
 type Fetch struct {
 	SeqSet     []SeqRange
 	Attributes []FetchAttribute
+	// ChangedSince is the CHANGEDSINCE fetch modifier value (RFC7162). Zero when not present.
+	ChangedSince uint64
+	// Vanished is set when the VANISHED fetch modifier is present (RFC7162).
+	Vanished bool
+	// Partial is the PARTIAL fetch modifier value (RFC9394). Nil when not present.
+	Partial *PartialRange
 }
 
 func (f Fetch) String() string {
+	var modifiers []string
+
+	if f.ChangedSince != 0 {
+		modifiers = append(modifiers, fmt.Sprintf("CHANGEDSINCE %v", f.ChangedSince))
+	}
+
+	if f.Vanished {
+		modifiers = append(modifiers, "VANISHED")
+	}
+
+	if f.Partial != nil {
+		modifiers = append(modifiers, fmt.Sprintf("PARTIAL %v", f.Partial))
+	}
+
+	if len(modifiers) > 0 {
+		return fmt.Sprintf("FETCH %v %v (%v)", f.SeqSet, f.Attributes, strings.Join(modifiers, " "))
+	}
+
 	return fmt.Sprintf("FETCH %v %v", f.SeqSet, f.Attributes)
 }
 
@@ -29,6 +53,7 @@ type FetchCommandParser struct{}
 func (FetchCommandParser) FromParser(p *rfcparser.Parser) (Payload, error) {
 	//fetch           = "FETCH" SP sequence-set SP ("ALL" / "FULL" / "FAST" /
 	//                  fetch-att / "(" fetch-att *(SP fetch-att) ")")
+	//                  [fetch-modifiers]
 	if err := p.Consume(rfcparser.TokenTypeSP, "expected space after command"); err != nil {
 		return nil, err
 	}
@@ -42,7 +67,10 @@ func (FetchCommandParser) FromParser(p *rfcparser.Parser) (Payload, error) {
 		return nil, err
 	}
 
-	var attributes []FetchAttribute
+	var (
+		attributes []FetchAttribute
+		preview    *FetchAttributePreview
+	)
 
 	if p.Check(rfcparser.TokenTypeLParen) {
 		// Multiple list of attributes.
@@ -73,10 +101,98 @@ func (FetchCommandParser) FromParser(p *rfcparser.Parser) (Payload, error) {
 			}
 
 			attributes = []FetchAttribute{attr}
+
+			// The preview modifiers of a single PREVIEW attribute can't be told apart from the fetch modifiers.
+			preview, _ = attr.(*FetchAttributePreview)
+		}
+	}
+
+	fetch := &Fetch{SeqSet: seqSet, Attributes: attributes}
+
+	if err := parseFetchModifiers(p, fetch, preview); err != nil {
+		return nil, err
+	}
+
+	return fetch, nil
+}
+
+func parseFetchModifiers(p *rfcparser.Parser, fetch *Fetch, preview *FetchAttributePreview) error {
+	// fetch-modifiers     = SP "(" fetch-modifier *(SP fetch-modifier) ")"
+	// fetch-modifier      = chgsince-fetch-mod / "VANISHED"
+	// chgsince-fetch-mod  = "CHANGEDSINCE" SP mod-sequence-value
+	//
+	// RFC9394:
+	// fetch-modifier      =/ "PARTIAL" SP partial-range
+	//
+	// If preview is not nil, the preview modifiers of the single PREVIEW attribute are accepted as well.
+	if ok, err := p.Matches(rfcparser.TokenTypeSP); err != nil {
+		return err
+	} else if !ok {
+		return nil
+	}
+
+	if err := p.Consume(rfcparser.TokenTypeLParen, "expected ( for fetch modifiers start"); err != nil {
+		return err
+	}
+
+	for {
+		modifier, err := parseFetchAttributeName(p)
+		if err != nil {
+			return err
+		}
+
+		switch modifier.Value {
+		case "changedsince":
+			if err := p.Consume(rfcparser.TokenTypeSP, "expected space after CHANGEDSINCE"); err != nil {
+				return err
+			}
+
+			value, err := ParseModSeqValue(p)
+			if err != nil {
+				return err
+			}
+
+			fetch.ChangedSince = value
+		case "vanished":
+			fetch.Vanished = true
+		case "partial":
+			if err := p.Consume(rfcparser.TokenTypeSP, "expected space after PARTIAL"); err != nil {
+				return err
+			}
+
+			partial, err := parsePartialRange(p)
+			if err != nil {
+				return err
+			}
+
+			fetch.Partial = partial
+		case "lazy":
+			if preview == nil {
+				return p.MakeErrorAtOffset("LAZY modifier requires PREVIEW fetch attribute", modifier.Offset)
+			}
+
+			preview.Lazy = true
+		default:
+			return p.MakeErrorAtOffset(fmt.Sprintf("unknown fetch modifier '%v'", modifier.Value), modifier.Offset)
 		}
+
+		if ok, err := p.Matches(rfcparser.TokenTypeSP); err != nil {
+			return err
+		} else if !ok {
+			break
+		}
+	}
+
+	if err := p.Consume(rfcparser.TokenTypeRParen, "expected ) for fetch modifiers end"); err != nil {
+		return err
 	}
 
-	return &Fetch{SeqSet: seqSet, Attributes: attributes}, nil
+	// The VANISHED modifier is only valid in combination with CHANGEDSINCE.
+	if fetch.Vanished && fetch.ChangedSince == 0 {
+		return p.MakeError("VANISHED fetch modifier requires CHANGEDSINCE")
+	}
+
+	return nil
 }
 
 func parseFetchAttributeName(p *rfcparser.Parser) (rfcparser.String, error) {
@@ -113,6 +229,14 @@ func parseFetchAttributes(p *rfcparser.Parser) ([]FetchAttribute, error) {
 			break
 		}
 
+		if preview, ok := attributes[len(attributes)-1].(*FetchAttributePreview); ok && p.Check(rfcparser.TokenTypeLParen) {
+			if err := parsePreviewModifiers(p, preview); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
 		attribute, err := parseFetchAttribute(p)
 		if err != nil {
 			return nil, err
@@ -148,7 +272,12 @@ func handleFetchAttribute(name rfcparser.String, p *rfcparser.Parser) (FetchAttr
 	                    "RFC822" [".HEADER" / ".SIZE" / ".TEXT"] /
 	                    "BODY" ["STRUCTURE"] / "UID" /
 	                    "BODY" section ["<" number "." nz-number ">"] /
-	                    "BODY.PEEK" section ["<" number "." nz-number ">"]
+	                    "BODY.PEEK" section ["<" number "." nz-number ">"] /
+	                    "MODSEQ" /
+	                    "BINARY" [".PEEK"] section-binary [partial] /
+	                    "BINARY.SIZE" section-binary /
+	                    "EMAILID" / "THREADID" / "SAVEDATE" /
+	                    "PREVIEW" [SP "(" preview-mod *(SP preview-mod) ")"]
 	*/
 	switch name.Value {
 	case "envelope":
@@ -161,15 +290,55 @@ func handleFetchAttribute(name rfcparser.String, p *rfcparser.Parser) (FetchAttr
 		return &FetchAttributeBodyStructure{}, nil
 	case "uid":
 		return &FetchAttributeUID{}, nil
+	case "modseq":
+		return &FetchAttributeModSeq{}, nil
+	case "emailid":
+		return &FetchAttributeEmailID{}, nil
+	case "threadid":
+		return &FetchAttributeThreadID{}, nil
+	case "savedate":
+		return &FetchAttributeSaveDate{}, nil
+	case "preview":
+		return &FetchAttributePreview{}, nil
 	case "rfc":
 		return handleRFC822FetchAttribute(p)
 	case "body":
 		return handleBodyFetchAttribute(p)
+	case "binary":
+		return handleBinaryFetchAttribute(p)
 	default:
 		return nil, p.MakeErrorAtOffset(fmt.Sprintf("unknown fetch attribute '%v'", name.Value), name.Offset)
 	}
 }
 
+func parsePreviewModifiers(p *rfcparser.Parser, preview *FetchAttributePreview) error {
+	// preview-mod     = "LAZY"
+	if err := p.Consume(rfcparser.TokenTypeLParen, "expected ( for preview modifiers start"); err != nil {
+		return err
+	}
+
+	for {
+		modifier, err := parseFetchAttributeName(p)
+		if err != nil {
+			return err
+		}
+
+		if modifier.Value != "lazy" {
+			return p.MakeErrorAtOffset(fmt.Sprintf("unknown preview modifier '%v'", modifier.Value), modifier.Offset)
+		}
+
+		preview.Lazy = true
+
+		if ok, err := p.Matches(rfcparser.TokenTypeSP); err != nil {
+			return err
+		} else if !ok {
+			break
+		}
+	}
+
+	return p.Consume(rfcparser.TokenTypeRParen, "expected ) for preview modifiers end")
+}
+
 func handleRFC822FetchAttribute(p *rfcparser.Parser) (FetchAttribute, error) {
 	if err := p.ConsumeBytesFold('8', '2', '2'); err != nil {
 		return nil, err
@@ -235,36 +404,109 @@ func handleBodyFetchAttribute(p *rfcparser.Parser) (FetchAttribute, error) {
 		return nil, err
 	}
 
-	var partial *BodySectionPartial
+	partial, err := parseBodySectionPartial(p)
+	if err != nil {
+		return nil, err
+	}
 
-	if ok, err := p.Matches(rfcparser.TokenTypeLess); err != nil {
+	return &FetchAttributeBodySection{Peek: readOnly, Section: section, Partial: partial}, nil
+}
+
+func handleBinaryFetchAttribute(p *rfcparser.Parser) (FetchAttribute, error) {
+	var (
+		readOnly bool
+		size     bool
+	)
+
+	if ok, err := p.Matches(rfcparser.TokenTypePeriod); err != nil {
 		return nil, err
 	} else if ok {
-		offset, err := p.ParseNumber()
+		attribute, err := parseFetchAttributeName(p)
 		if err != nil {
 			return nil, err
 		}
 
-		if err := p.Consume(rfcparser.TokenTypePeriod, "expected '.' after partial start"); err != nil {
-			return nil, err
+		switch attribute.Value {
+		case "peek":
+			readOnly = true
+		case "size":
+			size = true
+		default:
+			return nil, p.MakeErrorAtOffset(fmt.Sprintf("unknown fetch attribute 'BINARY.%v'", attribute.Value), attribute.Offset)
 		}
+	}
+
+	section, err := parseSectionBinary(p)
+	if err != nil {
+		return nil, err
+	}
+
+	if size {
+		return &FetchAttributeBinarySize{Section: section}, nil
+	}
+
+	partial, err := parseBodySectionPartial(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FetchAttributeBinarySection{Peek: readOnly, Section: section, Partial: partial}, nil
+}
 
-		count, err := ParseNZNumber(p)
+func parseSectionBinary(p *rfcparser.Parser) ([]int, error) {
+	// section-binary  = "[" [section-part] "]"
+	if err := p.Consume(rfcparser.TokenTypeLBracket, "expected [ for binary section start"); err != nil {
+		return nil, err
+	}
+
+	var part []int
+
+	if !p.Check(rfcparser.TokenTypeRBracket) {
+		s, err := parseSectionPart(p)
 		if err != nil {
 			return nil, err
 		}
 
-		if err := p.Consume(rfcparser.TokenTypeGreater, "expected > for end of partial specification"); err != nil {
-			return nil, err
-		}
+		part = s
+	}
 
-		partial = &BodySectionPartial{
-			Offset: int64(offset),
-			Count:  int64(count),
-		}
+	if err := p.Consume(rfcparser.TokenTypeRBracket, "expected ] for binary section end"); err != nil {
+		return nil, err
 	}
 
-	return &FetchAttributeBodySection{Peek: readOnly, Section: section, Partial: partial}, nil
+	return part, nil
+}
+
+func parseBodySectionPartial(p *rfcparser.Parser) (*BodySectionPartial, error) {
+	// partial         = "<" number "." nz-number ">"
+	if ok, err := p.Matches(rfcparser.TokenTypeLess); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, nil
+	}
+
+	offset, err := p.ParseNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.Consume(rfcparser.TokenTypePeriod, "expected '.' after partial start"); err != nil {
+		return nil, err
+	}
+
+	count, err := ParseNZNumber(p)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.Consume(rfcparser.TokenTypeGreater, "expected > for end of partial specification"); err != nil {
+		return nil, err
+	}
+
+	return &BodySectionPartial{
+		Offset: int64(offset),
+		Count:  int64(count),
+	}, nil
 }
 
 func parseSectionSpec(p *rfcparser.Parser) (BodySection, error) {
@@ -86,6 +86,48 @@ func (f FetchAttributeUID) String() string {
 	return "UID"
 }
 
+type FetchAttributeModSeq struct{}
+
+func (f FetchAttributeModSeq) String() string {
+	return "MODSEQ"
+}
+
+// FetchAttributeEmailID is the EMAILID fetch attribute (RFC8474) requesting the object ID of the message.
+type FetchAttributeEmailID struct{}
+
+func (f FetchAttributeEmailID) String() string {
+	return "EMAILID"
+}
+
+// FetchAttributeThreadID is the THREADID fetch attribute (RFC8474) requesting the object ID of the message's thread.
+type FetchAttributeThreadID struct{}
+
+func (f FetchAttributeThreadID) String() string {
+	return "THREADID"
+}
+
+// FetchAttributeSaveDate is the SAVEDATE fetch attribute (RFC8514) requesting the date the message was saved in the
+// mailbox.
+type FetchAttributeSaveDate struct{}
+
+func (f FetchAttributeSaveDate) String() string {
+	return "SAVEDATE"
+}
+
+// FetchAttributePreview is the PREVIEW fetch attribute (RFC8970) requesting a short plain-text snippet of the message.
+type FetchAttributePreview struct {
+	// Lazy is set when the client only wants previews which are readily available, i.e. not computed on demand.
+	Lazy bool
+}
+
+func (f FetchAttributePreview) String() string {
+	if f.Lazy {
+		return "PREVIEW (LAZY)"
+	}
+
+	return "PREVIEW"
+}
+
 type BodySection interface {
 	String() string
 }
@@ -114,6 +156,32 @@ func (f FetchAttributeBodySection) String() string {
 	return fmt.Sprintf("%v[%v]", firstPart, f.Section)
 }
 
+// FetchAttributeBinarySection is the BINARY fetch attribute (RFC3516) requesting the content of a body part with its
+// content transfer encoding removed. An empty section designates the whole message.
+type FetchAttributeBinarySection struct {
+	Section []int
+	Peek    bool
+	Partial *BodySectionPartial
+}
+
+func (f FetchAttributeBinarySection) String() string {
+	var firstPart = "BINARY"
+	if f.Peek {
+		firstPart += ".PEEK"
+	}
+
+	return fmt.Sprintf("%v[%v]", firstPart, renderSectionPart(f.Section))
+}
+
+// FetchAttributeBinarySize is the BINARY.SIZE fetch attribute (RFC3516) requesting the decoded size of a body part.
+type FetchAttributeBinarySize struct {
+	Section []int
+}
+
+func (f FetchAttributeBinarySize) String() string {
+	return fmt.Sprintf("BINARY.SIZE[%v]", renderSectionPart(f.Section))
+}
+
 type BodySectionHeader struct{}
 
 func (b BodySectionHeader) String() string {
@@ -153,9 +221,7 @@ type BodySectionPart struct {
 }
 
 func (b BodySectionPart) String() string {
-	partText := strings.Join(xslices.Map(b.Part, func(v int) string {
-		return strconv.FormatInt(int64(v), 10)
-	}), `.`)
+	partText := renderSectionPart(b.Part)
 
 	if b.Section == nil {
 		return partText
@@ -163,3 +229,9 @@ func (b BodySectionPart) String() string {
 
 	return fmt.Sprintf("%v.%v", partText, b.Section.String())
 }
+
+func renderSectionPart(part []int) string {
+	return strings.Join(xslices.Map(part, func(v int) string {
+		return strconv.FormatInt(int64(v), 10)
+	}), `.`)
+}
@@ -422,3 +422,223 @@ func TestParser_FetchCommandBodySectionPartOnly(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, expected, cmd)
 }
+
+func TestParser_FetchCommandModSeq(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &Fetch{
+		SeqSet: []SeqRange{{Begin: 1, End: 1}},
+		Attributes: []FetchAttribute{
+			&FetchAttributeFlags{},
+			&FetchAttributeModSeq{},
+		},
+	}}
+
+	cmd, err := testParseCommand(`tag FETCH 1 (FLAGS MODSEQ)`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_FetchCommandObjectID(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &Fetch{
+		SeqSet: []SeqRange{{Begin: 1, End: 1}},
+		Attributes: []FetchAttribute{
+			&FetchAttributeEmailID{},
+			&FetchAttributeThreadID{},
+		},
+	}}
+
+	cmd, err := testParseCommand(`tag FETCH 1 (EMAILID THREADID)`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_FetchCommandPreview(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &Fetch{
+		SeqSet: []SeqRange{{Begin: 1, End: 1}},
+		Attributes: []FetchAttribute{
+			&FetchAttributeUID{},
+			&FetchAttributePreview{Lazy: true},
+			&FetchAttributeFlags{},
+		},
+	}}
+
+	cmd, err := testParseCommand(`tag FETCH 1 (UID PREVIEW (LAZY) FLAGS)`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_FetchCommandSinglePreview(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &Fetch{
+		SeqSet: []SeqRange{{Begin: 1, End: 1}},
+		Attributes: []FetchAttribute{
+			&FetchAttributePreview{},
+		},
+	}}
+
+	cmd, err := testParseCommand(`tag FETCH 1 PREVIEW`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+
+	expected.Payload.(*Fetch).Attributes = []FetchAttribute{&FetchAttributePreview{Lazy: true}}
+
+	cmd, err = testParseCommand(`tag FETCH 1 PREVIEW (LAZY)`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_FetchCommandPreviewInvalidModifier(t *testing.T) {
+	_, err := testParseCommand(`tag FETCH 1 (PREVIEW (FUZZY))`)
+	require.Error(t, err)
+
+	_, err = testParseCommand(`tag FETCH 1 (FLAGS) (LAZY)`)
+	require.Error(t, err)
+}
+
+func TestParser_FetchCommandSaveDate(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &Fetch{
+		SeqSet: []SeqRange{{Begin: 1, End: 1}},
+		Attributes: []FetchAttribute{
+			&FetchAttributeSaveDate{},
+			&FetchAttributeInternalDate{},
+		},
+	}}
+
+	cmd, err := testParseCommand(`tag FETCH 1 (SAVEDATE INTERNALDATE)`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_FetchCommandChangedSince(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &Fetch{
+		SeqSet: []SeqRange{{Begin: 1, End: SeqNumValueAsterisk}},
+		Attributes: []FetchAttribute{
+			&FetchAttributeFlags{},
+		},
+		ChangedSince: 12345,
+	}}
+
+	cmd, err := testParseCommand(`tag FETCH 1:* (FLAGS) (CHANGEDSINCE 12345)`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_FetchCommandChangedSinceSingleAttribute(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &Fetch{
+		SeqSet: []SeqRange{{Begin: 1, End: 1}},
+		Attributes: []FetchAttribute{
+			&FetchAttributeFlags{},
+		},
+		ChangedSince: 7,
+	}}
+
+	cmd, err := testParseCommand(`tag FETCH 1 FLAGS (changedsince 7)`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_FetchCommandChangedSinceZero(t *testing.T) {
+	_, err := testParseCommand(`tag FETCH 1 FLAGS (CHANGEDSINCE 0)`)
+	require.Error(t, err)
+}
+
+func TestParser_FetchCommandChangedSinceVanished(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &UID{
+		Command: &Fetch{
+			SeqSet: []SeqRange{{Begin: 300, End: 500}},
+			Attributes: []FetchAttribute{
+				&FetchAttributeFlags{},
+			},
+			ChangedSince: 12345,
+			Vanished:     true,
+		},
+	}}
+
+	cmd, err := testParseCommand(`tag UID FETCH 300:500 (FLAGS) (CHANGEDSINCE 12345 VANISHED)`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_FetchCommandVanishedWithoutChangedSince(t *testing.T) {
+	_, err := testParseCommand(`tag UID FETCH 300:500 (FLAGS) (VANISHED)`)
+	require.Error(t, err)
+}
+
+func TestParser_FetchCommandPartial(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &UID{
+		Command: &Fetch{
+			SeqSet: []SeqRange{{Begin: 1, End: SeqNumValueAsterisk}},
+			Attributes: []FetchAttribute{
+				&FetchAttributeFlags{},
+			},
+			ChangedSince: 12345,
+			Partial:      &PartialRange{First: -1, Last: -50},
+		},
+	}}
+
+	cmd, err := testParseCommand(`tag UID FETCH 1:* (FLAGS) (CHANGEDSINCE 12345 PARTIAL -50:-1)`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_FetchCommandPartialSingleAttribute(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &Fetch{
+		SeqSet: []SeqRange{{Begin: 1, End: SeqNumValueAsterisk}},
+		Attributes: []FetchAttribute{
+			&FetchAttributeUID{},
+		},
+		Partial: &PartialRange{First: 1, Last: 100},
+	}}
+
+	cmd, err := testParseCommand(`tag FETCH 1:* UID (partial 1:100)`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_FetchCommandPartialInvalid(t *testing.T) {
+	_, err := testParseCommand(`tag FETCH 1:* UID (PARTIAL 0:100)`)
+	require.Error(t, err)
+
+	_, err = testParseCommand(`tag FETCH 1:* UID (PARTIAL -1:100)`)
+	require.Error(t, err)
+
+	_, err = testParseCommand(`tag FETCH 1:* UID (PARTIAL 1)`)
+	require.Error(t, err)
+}
+
+func TestParser_FetchCommandSearchRes(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &Fetch{
+		SeqSet: []SeqRange{{Begin: SeqNumValueSearchRes, End: SeqNumValueSearchRes}},
+		Attributes: []FetchAttribute{
+			&FetchAttributeFlags{},
+		},
+	}}
+
+	cmd, err := testParseCommand(`tag FETCH $ FLAGS`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_FetchCommandBinary(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &Fetch{
+		SeqSet: []SeqRange{{Begin: 1, End: 1}},
+		Attributes: []FetchAttribute{
+			&FetchAttributeBinarySection{Section: []int{1, 2}},
+			&FetchAttributeBinarySection{Peek: true, Partial: &BodySectionPartial{Offset: 10, Count: 20}},
+			&FetchAttributeBinarySize{Section: []int{3}},
+		},
+	}}
+
+	cmd, err := testParseCommand(`tag FETCH 1 (BINARY[1.2] BINARY.PEEK[]<10.20> BINARY.SIZE[3])`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_FetchCommandBinaryInvalid(t *testing.T) {
+	_, err := testParseCommand(`tag FETCH 1 (BINARY[TEXT])`)
+	require.Error(t, err)
+
+	_, err = testParseCommand(`tag FETCH 1 (BINARY.FOO[1])`)
+	require.Error(t, err)
+
+	_, err = testParseCommand(`tag FETCH 1 (BINARY.SIZE[1]<0.5>)`)
+	require.Error(t, err)
+}
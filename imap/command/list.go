@@ -2,6 +2,7 @@ package command
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/ProtonMail/gluon/rfcparser"
 )
@@ -9,24 +10,68 @@ import (
 type List struct {
 	Mailbox     string
 	ListMailbox string
+
+	// ExtraPatterns holds the patterns following ListMailbox when several are given at once (RFC5258).
+	ExtraPatterns []string
+
+	// SelectSubscribed restricts the listed mailboxes to subscribed ones (RFC5258).
+	SelectSubscribed bool
+
+	// SelectRemote also lists remote mailboxes (RFC5258). As all the mailboxes are local, it selects no other mailbox.
+	SelectRemote bool
+
+	// SelectRecursiveMatch also lists mailboxes with inferiors matching the other selection options (RFC5258).
+	SelectRecursiveMatch bool
+
+	// SelectSpecialUse restricts the listed mailboxes to those with a special use (RFC6154).
+	SelectSpecialUse bool
+
+	// ReturnSubscribed requests the \Subscribed attribute of the listed mailboxes (RFC5258).
+	ReturnSubscribed bool
+
+	// ReturnChildren requests the \HasChildren and \HasNoChildren attributes of the listed mailboxes (RFC5258).
+	ReturnChildren bool
+
+	// ReturnSpecialUse requests the special-use attributes of the listed mailboxes (RFC6154).
+	ReturnSpecialUse bool
+
+	// ReturnStatus requests the given status attributes of the listed mailboxes (RFC5819).
+	ReturnStatus []StatusAttribute
 }
 
 func (l List) String() string {
-	return fmt.Sprintf("LIST '%v' '%v'", l.Mailbox, l.ListMailbox)
+	return fmt.Sprintf("LIST '%v' '%v'", l.Mailbox, strings.Join(l.Patterns(), "' '"))
 }
 
 func (l List) SanitizedString() string {
 	return l.String()
 }
 
+// Patterns returns all the mailbox patterns of the command.
+func (l List) Patterns() []string {
+	return append([]string{l.ListMailbox}, l.ExtraPatterns...)
+}
+
 type ListCommandParser struct{}
 
 func (ListCommandParser) FromParser(p *rfcparser.Parser) (Payload, error) {
-	// list            = "LIST" SP mailbox SP list-mailbox
+	// list            = "LIST" [SP list-select-opts] SP mailbox SP mbox-or-pat [SP list-return-opts]
 	if err := p.Consume(rfcparser.TokenTypeSP, "expected space after command"); err != nil {
 		return nil, err
 	}
 
+	var list List
+
+	if p.Check(rfcparser.TokenTypeLParen) {
+		if err := parseListSelectOpts(p, &list); err != nil {
+			return nil, err
+		}
+
+		if err := p.Consume(rfcparser.TokenTypeSP, "expected space after list select options"); err != nil {
+			return nil, err
+		}
+	}
+
 	mailbox, err := ParseMailbox(p)
 	if err != nil {
 		return nil, err
@@ -36,15 +81,166 @@ func (ListCommandParser) FromParser(p *rfcparser.Parser) (Payload, error) {
 		return nil, err
 	}
 
-	listMailbox, err := parseListMailbox(p)
+	patterns, err := parseListPatterns(p)
 	if err != nil {
 		return nil, err
 	}
 
-	return &List{
-		Mailbox:     mailbox.Value,
-		ListMailbox: listMailbox.Value,
-	}, nil
+	if ok, err := p.Matches(rfcparser.TokenTypeSP); err != nil {
+		return nil, err
+	} else if ok {
+		if err := parseListReturnOpts(p, &list); err != nil {
+			return nil, err
+		}
+	}
+
+	list.Mailbox = mailbox.Value
+	list.ListMailbox = patterns[0]
+	list.ExtraPatterns = patterns[1:]
+
+	if len(list.ExtraPatterns) == 0 {
+		list.ExtraPatterns = nil
+	}
+
+	return &list, nil
+}
+
+func parseListPatterns(p *rfcparser.Parser) ([]string, error) {
+	// mbox-or-pat      = list-mailbox / patterns
+	// patterns         = "(" list-mailbox *(SP list-mailbox) ")"
+	if ok, err := p.Matches(rfcparser.TokenTypeLParen); err != nil {
+		return nil, err
+	} else if !ok {
+		listMailbox, err := parseListMailbox(p)
+		if err != nil {
+			return nil, err
+		}
+
+		return []string{listMailbox.Value}, nil
+	}
+
+	var patterns []string
+
+	for {
+		listMailbox, err := parseListMailbox(p)
+		if err != nil {
+			return nil, err
+		}
+
+		patterns = append(patterns, listMailbox.Value)
+
+		if ok, err := p.Matches(rfcparser.TokenTypeSP); err != nil {
+			return nil, err
+		} else if !ok {
+			break
+		}
+	}
+
+	if err := p.Consume(rfcparser.TokenTypeRParen, "expected ) for list patterns end"); err != nil {
+		return nil, err
+	}
+
+	return patterns, nil
+}
+
+func parseListSelectOpts(p *rfcparser.Parser, list *List) error {
+	// list-select-opts = "(" [list-select-opt *(SP list-select-opt)] ")"
+	// list-select-opt  = "SUBSCRIBED" / "REMOTE" / "RECURSIVEMATCH" / "SPECIAL-USE"
+	if err := parseListOpts(p, func(opt string) (bool, error) {
+		switch opt {
+		case "SUBSCRIBED":
+			list.SelectSubscribed = true
+		case "REMOTE":
+			list.SelectRemote = true
+		case "RECURSIVEMATCH":
+			list.SelectRecursiveMatch = true
+		case "SPECIAL-USE":
+			list.SelectSpecialUse = true
+		default:
+			return false, nil
+		}
+
+		return true, nil
+	}); err != nil {
+		return err
+	}
+
+	// RECURSIVEMATCH can only be used along with an option selecting the inferiors.
+	if list.SelectRecursiveMatch && !list.SelectSubscribed {
+		return p.MakeError("RECURSIVEMATCH requires SUBSCRIBED")
+	}
+
+	return nil
+}
+
+func parseListReturnOpts(p *rfcparser.Parser, list *List) error {
+	// list-return-opts = "RETURN" SP "(" [return-option *(SP return-option)] ")"
+	// return-option    = "SUBSCRIBED" / "CHILDREN" / "SPECIAL-USE" / status-option
+	// status-option    = "STATUS" SP "(" status-att *(SP status-att) ")"
+	if err := p.ConsumeBytesFold('R', 'E', 'T', 'U', 'R', 'N'); err != nil {
+		return err
+	}
+
+	if err := p.Consume(rfcparser.TokenTypeSP, "expected space after RETURN"); err != nil {
+		return err
+	}
+
+	return parseListOpts(p, func(opt string) (bool, error) {
+		switch opt {
+		case "SUBSCRIBED":
+			list.ReturnSubscribed = true
+		case "CHILDREN":
+			list.ReturnChildren = true
+		case "SPECIAL-USE":
+			list.ReturnSpecialUse = true
+		case "STATUS":
+			if err := p.Consume(rfcparser.TokenTypeSP, "expected space after STATUS"); err != nil {
+				return false, err
+			}
+
+			attributes, err := parseStatusAttributes(p)
+			if err != nil {
+				return false, err
+			}
+
+			list.ReturnStatus = attributes
+		default:
+			return false, nil
+		}
+
+		return true, nil
+	})
+}
+
+// parseListOpts parses a parenthesized list of options, handing each of them to the given function in upper case.
+// The function reports whether the option is known and parses its arguments, if any.
+func parseListOpts(p *rfcparser.Parser, fn func(string) (bool, error)) error {
+	if err := p.Consume(rfcparser.TokenTypeLParen, "expected ( for list options start"); err != nil {
+		return err
+	}
+
+	if !p.Check(rfcparser.TokenTypeRParen) {
+		for {
+			opt, err := p.ParseAtom()
+			if err != nil {
+				return err
+			}
+
+			if ok, err := fn(strings.ToUpper(opt)); err != nil {
+				return err
+			} else if !ok {
+				return p.MakeError(fmt.Sprintf("unknown list option '%v'", opt))
+			}
+
+			if ok, err := p.Matches(rfcparser.TokenTypeSP); err != nil {
+				return err
+			} else if !ok {
+				break
+			}
+		}
+	}
+
+	return p.Consume(rfcparser.TokenTypeRParen, "expected ) for list options end")
 }
 
 func parseListMailbox(p *rfcparser.Parser) (rfcparser.String, error) {
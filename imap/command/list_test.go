@@ -79,3 +79,90 @@ func TestParser_ListCommandLiteral(t *testing.T) {
 	require.Equal(t, "list", p.LastParsedCommand())
 	require.Equal(t, "tag", p.LastParsedTag())
 }
+
+func TestParser_ListCommandSelectSpecialUse(t *testing.T) {
+	input := toIMAPLine(`tag LIST (SPECIAL-USE) "" "*"`)
+	s := rfcparser.NewScanner(bytes.NewReader(input))
+	p := NewParser(s)
+
+	expected := Command{Tag: "tag", Payload: &List{
+		Mailbox:          "",
+		ListMailbox:      "*",
+		SelectSpecialUse: true,
+	}}
+
+	cmd, err := p.Parse()
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_ListCommandReturnSpecialUse(t *testing.T) {
+	input := toIMAPLine(`tag LIST () "" % RETURN (special-use)`)
+	s := rfcparser.NewScanner(bytes.NewReader(input))
+	p := NewParser(s)
+
+	expected := Command{Tag: "tag", Payload: &List{
+		Mailbox:          "",
+		ListMailbox:      "%",
+		ReturnSpecialUse: true,
+	}}
+
+	cmd, err := p.Parse()
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_ListCommandUnknownOption(t *testing.T) {
+	input := toIMAPLine(`tag LIST (FOO) "" "*"`)
+	s := rfcparser.NewScanner(bytes.NewReader(input))
+	p := NewParser(s)
+
+	_, err := p.Parse()
+	require.Error(t, err)
+}
+
+func TestParser_ListCommandExtended(t *testing.T) {
+	input := toIMAPLine(`tag LIST (SUBSCRIBED RECURSIVEMATCH REMOTE) "" ("INBOX" Foo/%) RETURN (CHILDREN SUBSCRIBED)`)
+	s := rfcparser.NewScanner(bytes.NewReader(input))
+	p := NewParser(s)
+
+	expected := Command{Tag: "tag", Payload: &List{
+		Mailbox:              "",
+		ListMailbox:          "INBOX",
+		ExtraPatterns:        []string{"Foo/%"},
+		SelectSubscribed:     true,
+		SelectRemote:         true,
+		SelectRecursiveMatch: true,
+		ReturnSubscribed:     true,
+		ReturnChildren:       true,
+	}}
+
+	cmd, err := p.Parse()
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_ListCommandReturnStatus(t *testing.T) {
+	input := toIMAPLine(`tag LIST "" % RETURN (STATUS (MESSAGES UNSEEN))`)
+	s := rfcparser.NewScanner(bytes.NewReader(input))
+	p := NewParser(s)
+
+	expected := Command{Tag: "tag", Payload: &List{
+		Mailbox:      "",
+		ListMailbox:  "%",
+		ReturnStatus: []StatusAttribute{StatusAttributeMessages, StatusAttributeUnseen},
+	}}
+
+	cmd, err := p.Parse()
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_ListCommandRecursiveMatchWithoutBaseOption(t *testing.T) {
+	input := toIMAPLine(`tag LIST (RECURSIVEMATCH) "" "*"`)
+	s := rfcparser.NewScanner(bytes.NewReader(input))
+	p := NewParser(s)
+
+	_, err := p.Parse()
+	require.Error(t, err)
+}
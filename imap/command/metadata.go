@@ -0,0 +1,301 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ProtonMail/gluon/imap"
+	"github.com/ProtonMail/gluon/rfcparser"
+	"github.com/bradenaw/juniper/xslices"
+)
+
+type MetadataDepth int
+
+const (
+	MetadataDepthZero MetadataDepth = iota
+	MetadataDepthOne
+	MetadataDepthInfinity
+)
+
+func (d MetadataDepth) String() string {
+	switch d {
+	case MetadataDepthZero:
+		return "0"
+	case MetadataDepthOne:
+		return "1"
+	case MetadataDepthInfinity:
+		return "infinity"
+	default:
+		return "unknown"
+	}
+}
+
+type GetMetadata struct {
+	// Mailbox is the mailbox whose entries are requested. It is empty for server entries.
+	Mailbox string
+	Entries []string
+
+	// MaxSize is the largest value the client accepts. Nil when not present.
+	MaxSize *int
+	Depth   MetadataDepth
+}
+
+func (l GetMetadata) String() string {
+	return fmt.Sprintf("GETMETADATA '%v' %v (DEPTH %v)", l.Mailbox, l.Entries, l.Depth)
+}
+
+func (l GetMetadata) SanitizedString() string {
+	return fmt.Sprintf("GETMETADATA '%v' %v (DEPTH %v)", sanitizeString(l.Mailbox), l.Entries, l.Depth)
+}
+
+type GetMetadataCommandParser struct{}
+
+func (GetMetadataCommandParser) FromParser(p *rfcparser.Parser) (Payload, error) {
+	// getmetadata     = "GETMETADATA" [SP getmetadata-options]
+	//                   SP mailbox SP entries
+	// getmetadata-options = "(" getmetadata-option
+	//                       *(SP getmetadata-option) ")"
+	// getmetadata-option  = maxsize-opt / scope-opt
+	// maxsize-opt     = "MAXSIZE" SP number
+	// scope-opt       = "DEPTH" SP ("0" / "1" / "infinity")
+	if err := p.Consume(rfcparser.TokenTypeSP, "expected space after command"); err != nil {
+		return nil, err
+	}
+
+	cmd := &GetMetadata{}
+
+	if ok, err := p.Matches(rfcparser.TokenTypeLParen); err != nil {
+		return nil, err
+	} else if ok {
+		for {
+			if err := parseGetMetadataOption(p, cmd); err != nil {
+				return nil, err
+			}
+
+			if ok, err := p.Matches(rfcparser.TokenTypeSP); err != nil {
+				return nil, err
+			} else if !ok {
+				break
+			}
+		}
+
+		if err := p.Consume(rfcparser.TokenTypeRParen, "expected ) for metadata options end"); err != nil {
+			return nil, err
+		}
+
+		if err := p.Consume(rfcparser.TokenTypeSP, "expected space after metadata options"); err != nil {
+			return nil, err
+		}
+	}
+
+	mailbox, err := ParseMailbox(p)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.Consume(rfcparser.TokenTypeSP, "expected space after mailbox"); err != nil {
+		return nil, err
+	}
+
+	// entries         = entry / "(" entry *(SP entry) ")"
+	if ok, err := p.Matches(rfcparser.TokenTypeLParen); err != nil {
+		return nil, err
+	} else if ok {
+		for {
+			entry, err := parseMetadataEntryName(p)
+			if err != nil {
+				return nil, err
+			}
+
+			cmd.Entries = append(cmd.Entries, entry)
+
+			if ok, err := p.Matches(rfcparser.TokenTypeSP); err != nil {
+				return nil, err
+			} else if !ok {
+				break
+			}
+		}
+
+		if err := p.Consume(rfcparser.TokenTypeRParen, "expected ) for metadata entries end"); err != nil {
+			return nil, err
+		}
+	} else {
+		entry, err := parseMetadataEntryName(p)
+		if err != nil {
+			return nil, err
+		}
+
+		cmd.Entries = append(cmd.Entries, entry)
+	}
+
+	cmd.Mailbox = mailbox.Value
+
+	return cmd, nil
+}
+
+func parseGetMetadataOption(p *rfcparser.Parser, cmd *GetMetadata) error {
+	option, err := p.ParseAtom()
+	if err != nil {
+		return err
+	}
+
+	if err := p.Consume(rfcparser.TokenTypeSP, "expected space after metadata option"); err != nil {
+		return err
+	}
+
+	switch strings.ToLower(option) {
+	case "maxsize":
+		maxSize, err := p.ParseNumber()
+		if err != nil {
+			return err
+		}
+
+		cmd.MaxSize = &maxSize
+
+	case "depth":
+		depth, err := p.ParseAtom()
+		if err != nil {
+			return err
+		}
+
+		switch strings.ToLower(depth) {
+		case "0":
+			cmd.Depth = MetadataDepthZero
+		case "1":
+			cmd.Depth = MetadataDepthOne
+		case "infinity":
+			cmd.Depth = MetadataDepthInfinity
+		default:
+			return p.MakeError(fmt.Sprintf("invalid metadata depth '%v'", depth))
+		}
+
+	default:
+		return p.MakeError(fmt.Sprintf("unknown metadata option '%v'", option))
+	}
+
+	return nil
+}
+
+type SetMetadata struct {
+	// Mailbox is the mailbox whose entries are set. It is empty for server entries.
+	Mailbox string
+	Entries []imap.MetadataEntry
+}
+
+func (l SetMetadata) String() string {
+	return fmt.Sprintf("SETMETADATA '%v' %v", l.Mailbox, xslices.Map(l.Entries, func(e imap.MetadataEntry) string {
+		return e.Name
+	}))
+}
+
+func (l SetMetadata) SanitizedString() string {
+	return fmt.Sprintf("SETMETADATA '%v' %v", sanitizeString(l.Mailbox), xslices.Map(l.Entries, func(e imap.MetadataEntry) string {
+		return e.Name
+	}))
+}
+
+type SetMetadataCommandParser struct{}
+
+func (SetMetadataCommandParser) FromParser(p *rfcparser.Parser) (Payload, error) {
+	// setmetadata     = "SETMETADATA" SP mailbox
+	//                   SP list-entry-value
+	// list-entry-value = "(" entry-value *(SP entry-value) ")"
+	// entry-value     = entry SP value
+	// value           = nstring / literal8
+	if err := p.Consume(rfcparser.TokenTypeSP, "expected space after command"); err != nil {
+		return nil, err
+	}
+
+	mailbox, err := ParseMailbox(p)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.Consume(rfcparser.TokenTypeSP, "expected space after mailbox"); err != nil {
+		return nil, err
+	}
+
+	if err := p.Consume(rfcparser.TokenTypeLParen, "expected ( for metadata entries start"); err != nil {
+		return nil, err
+	}
+
+	var entries []imap.MetadataEntry
+
+	for {
+		name, err := parseMetadataEntryName(p)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.Consume(rfcparser.TokenTypeSP, "expected space after metadata entry"); err != nil {
+			return nil, err
+		}
+
+		value, err := parseMetadataValue(p)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, imap.MetadataEntry{Name: name, Value: value})
+
+		if ok, err := p.Matches(rfcparser.TokenTypeSP); err != nil {
+			return nil, err
+		} else if !ok {
+			break
+		}
+	}
+
+	if err := p.Consume(rfcparser.TokenTypeRParen, "expected ) for metadata entries end"); err != nil {
+		return nil, err
+	}
+
+	return &SetMetadata{Mailbox: mailbox.Value, Entries: entries}, nil
+}
+
+func parseMetadataEntryName(p *rfcparser.Parser) (string, error) {
+	// entry           = astring
+	//                     ; slash-separated path to entry
+	//                     ; MUST NOT contain "*" or "%"
+	entry, err := p.ParseAString()
+	if err != nil {
+		return "", err
+	}
+
+	name := strings.ToLower(entry.Value)
+
+	if !strings.HasPrefix(name, imap.MetadataPrivatePrefix) && !strings.HasPrefix(name, imap.MetadataSharedPrefix) {
+		return "", p.MakeErrorAtOffset("metadata entry must begin with /private/ or /shared/", entry.Offset)
+	}
+
+	if strings.ContainsAny(name, "*%") || strings.Contains(name, "//") || strings.HasSuffix(name, "/") {
+		return "", p.MakeErrorAtOffset(fmt.Sprintf("invalid metadata entry '%v'", entry.Value), entry.Offset)
+	}
+
+	for _, c := range name {
+		if c < 0x20 || c > 0x7e {
+			return "", p.MakeErrorAtOffset(fmt.Sprintf("invalid metadata entry '%v'", entry.Value), entry.Offset)
+		}
+	}
+
+	return name, nil
+}
+
+func parseMetadataValue(p *rfcparser.Parser) ([]byte, error) {
+	// value           = nstring / literal8
+	// nstring         = string / nil
+	if p.Check(rfcparser.TokenTypeTilde) {
+		return p.ParseLiteral8()
+	}
+
+	if value, ok, err := p.TryParseString(); err != nil {
+		return nil, err
+	} else if ok {
+		return []byte(value.Value), nil
+	}
+
+	if err := p.ConsumeBytesFold('N', 'I', 'L'); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
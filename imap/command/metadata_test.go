@@ -0,0 +1,85 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/ProtonMail/gluon/imap"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_GetMetadataCommand(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &GetMetadata{
+		Mailbox: "INBOX",
+		Entries: []string{"/private/comment"},
+	}}
+
+	cmd, err := testParseCommand(`tag GETMETADATA INBOX /Private/Comment`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_GetMetadataCommandWithOptions(t *testing.T) {
+	maxSize := 1024
+
+	expected := Command{Tag: "tag", Payload: &GetMetadata{
+		Mailbox: "",
+		Entries: []string{"/shared/comment", "/private/comment"},
+		MaxSize: &maxSize,
+		Depth:   MetadataDepthInfinity,
+	}}
+
+	cmd, err := testParseCommand(`tag GETMETADATA (MAXSIZE 1024 DEPTH infinity) "" (/shared/comment /private/comment)`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_GetMetadataCommandInvalid(t *testing.T) {
+	for _, input := range []string{
+		`tag GETMETADATA INBOX /comment`,
+		`tag GETMETADATA INBOX /private/comment/`,
+		`tag GETMETADATA INBOX /private//comment`,
+		`tag GETMETADATA INBOX /private/*`,
+		`tag GETMETADATA (DEPTH 2) INBOX /private/comment`,
+		`tag GETMETADATA (FOO 2) INBOX /private/comment`,
+		`tag GETMETADATA INBOX`,
+	} {
+		_, err := testParseCommand(input)
+		require.Error(t, err, input)
+	}
+}
+
+func TestParser_SetMetadataCommand(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &SetMetadata{
+		Mailbox: "INBOX",
+		Entries: []imap.MetadataEntry{
+			{Name: "/private/comment", Value: []byte("My new comment")},
+			{Name: "/shared/comment", Value: nil},
+			{Name: "/private/color", Value: []byte("red")},
+		},
+	}}
+
+	cmd, err := testParseCommand("tag SETMETADATA INBOX (/private/comment \"My new comment\" /shared/comment NIL /private/color {3}\r\nred)")
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_SetMetadataCommandLiteral8(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &SetMetadata{
+		Mailbox: "",
+		Entries: []imap.MetadataEntry{
+			{Name: "/private/blob", Value: []byte("a\x00b")},
+		},
+	}}
+
+	cmd, err := testParseCommand("tag SETMETADATA \"\" (/private/blob ~{3}\r\na\x00b)")
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_SetMetadataCommandInvalid(t *testing.T) {
+	_, err := testParseCommand(`tag SETMETADATA INBOX /private/comment "value"`)
+	require.Error(t, err)
+
+	_, err = testParseCommand(`tag SETMETADATA INBOX (/private/comment value)`)
+	require.Error(t, err)
+}
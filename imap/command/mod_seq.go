@@ -0,0 +1,34 @@
+package command
+
+import (
+	"github.com/ProtonMail/gluon/rfcparser"
+)
+
+// ParseModSeqValue parses a mod-sequence value as defined in RFC7162.
+func ParseModSeqValue(p *rfcparser.Parser) (uint64, error) {
+	// mod-sequence-value  = 1*DIGIT
+	//                        ;; Positive unsigned 63-bit integer
+	//                        ;; (mod-sequence)
+	//                        ;; (1 <= n <= 9,223,372,036,854,775,807).
+	num, err := p.ParseNumber()
+	if err != nil {
+		return 0, err
+	}
+
+	if num == 0 {
+		return 0, p.MakeError("expected non-zero mod-sequence value")
+	}
+
+	return uint64(num), nil
+}
+
+// ParseModSeqValzer parses a mod-sequence value which can be zero as defined in RFC7162.
+func ParseModSeqValzer(p *rfcparser.Parser) (uint64, error) {
+	// mod-sequence-valzer = "0" / mod-sequence-value
+	num, err := p.ParseNumber()
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(num), nil
+}
@@ -0,0 +1,24 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/ProtonMail/gluon/rfcparser"
+)
+
+type Namespace struct{}
+
+func (l Namespace) String() string {
+	return fmt.Sprintf("NAMESPACE")
+}
+
+func (l Namespace) SanitizedString() string {
+	return l.String()
+}
+
+type NamespaceCommandParser struct{}
+
+func (NamespaceCommandParser) FromParser(p *rfcparser.Parser) (Payload, error) {
+	// namespace         = "NAMESPACE"
+	return &Namespace{}, nil
+}
@@ -0,0 +1,23 @@
+package command
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ProtonMail/gluon/rfcparser"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_NamespaceCommand(t *testing.T) {
+	input := toIMAPLine(`tag NAMESPACE`)
+	s := rfcparser.NewScanner(bytes.NewReader(input))
+	p := NewParser(s)
+
+	expected := Command{Tag: "tag", Payload: &Namespace{}}
+
+	cmd, err := p.Parse()
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+	require.Equal(t, "namespace", p.LastParsedCommand())
+	require.Equal(t, "tag", p.LastParsedTag())
+}
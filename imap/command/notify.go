@@ -0,0 +1,348 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ProtonMail/gluon/rfcparser"
+)
+
+type NotifyFilter int
+
+const (
+	NotifyFilterSelected NotifyFilter = iota
+	NotifyFilterSelectedDelayed
+	NotifyFilterInboxes
+	NotifyFilterPersonal
+	NotifyFilterSubscribed
+	NotifyFilterSubtree
+	NotifyFilterMailboxes
+)
+
+func (f NotifyFilter) String() string {
+	switch f {
+	case NotifyFilterSelected:
+		return "SELECTED"
+	case NotifyFilterSelectedDelayed:
+		return "SELECTED-DELAYED"
+	case NotifyFilterInboxes:
+		return "INBOXES"
+	case NotifyFilterPersonal:
+		return "PERSONAL"
+	case NotifyFilterSubscribed:
+		return "SUBSCRIBED"
+	case NotifyFilterSubtree:
+		return "SUBTREE"
+	case NotifyFilterMailboxes:
+		return "MAILBOXES"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// NotifyEvent is the name of an event a client can ask to be notified about (RFC5465).
+// Unknown event names are kept as sent by the client.
+type NotifyEvent string
+
+const (
+	NotifyEventMessageNew            NotifyEvent = "MessageNew"
+	NotifyEventMessageExpunge        NotifyEvent = "MessageExpunge"
+	NotifyEventFlagChange            NotifyEvent = "FlagChange"
+	NotifyEventAnnotationChange      NotifyEvent = "AnnotationChange"
+	NotifyEventMailboxName           NotifyEvent = "MailboxName"
+	NotifyEventSubscriptionChange    NotifyEvent = "SubscriptionChange"
+	NotifyEventMailboxMetadataChange NotifyEvent = "MailboxMetadataChange"
+	NotifyEventServerMetadataChange  NotifyEvent = "ServerMetadataChange"
+)
+
+var notifyEvents = []NotifyEvent{
+	NotifyEventMessageNew,
+	NotifyEventMessageExpunge,
+	NotifyEventFlagChange,
+	NotifyEventAnnotationChange,
+	NotifyEventMailboxName,
+	NotifyEventSubscriptionChange,
+	NotifyEventMailboxMetadataChange,
+	NotifyEventServerMetadataChange,
+}
+
+type NotifyEventGroup struct {
+	Filter NotifyFilter
+
+	// Mailboxes holds the mailboxes of the SUBTREE and MAILBOXES filters.
+	Mailboxes []string
+
+	// Events is empty when the client asked for no events (NONE).
+	Events []NotifyEvent
+
+	// FetchAttributes holds the attributes to fetch for MessageNew events of the SELECTED and SELECTED-DELAYED filters.
+	FetchAttributes []FetchAttribute
+}
+
+type Notify struct {
+	// Status is set when the client asked for the current status of the mailboxes when the notifications are set.
+	Status bool
+
+	// EventGroups is empty for NOTIFY NONE.
+	EventGroups []NotifyEventGroup
+}
+
+func (l Notify) String() string {
+	if len(l.EventGroups) == 0 {
+		return "NOTIFY NONE"
+	}
+
+	return fmt.Sprintf("NOTIFY SET Status=%v EventGroups=%v", l.Status, l.EventGroups)
+}
+
+func (l Notify) SanitizedString() string {
+	if len(l.EventGroups) == 0 {
+		return "NOTIFY NONE"
+	}
+
+	return fmt.Sprintf("NOTIFY SET Status=%v EventGroups=%v", l.Status, len(l.EventGroups))
+}
+
+type NotifyCommandParser struct{}
+
+func (NotifyCommandParser) FromParser(p *rfcparser.Parser) (Payload, error) {
+	// notify          = "NOTIFY" SP (notify-set / notify-none)
+	// notify-none     = "NONE"
+	// notify-set      = "SET" [status-indicator] SP event-groups
+	// status-indicator = SP "STATUS"
+	// event-groups    = event-group *(SP event-group)
+	if err := p.Consume(rfcparser.TokenTypeSP, "expected space after command"); err != nil {
+		return nil, err
+	}
+
+	keyword, err := p.ParseAtom()
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToUpper(keyword) {
+	case "NONE":
+		return &Notify{}, nil
+
+	case "SET":
+		// continue below.
+
+	default:
+		return nil, p.MakeError(fmt.Sprintf("unknown notify operation '%v'", keyword))
+	}
+
+	if err := p.Consume(rfcparser.TokenTypeSP, "expected space after SET"); err != nil {
+		return nil, err
+	}
+
+	cmd := &Notify{}
+
+	if !p.Check(rfcparser.TokenTypeLParen) {
+		if err := p.ConsumeBytesFold('S', 'T', 'A', 'T', 'U', 'S'); err != nil {
+			return nil, err
+		}
+
+		if err := p.Consume(rfcparser.TokenTypeSP, "expected space after STATUS"); err != nil {
+			return nil, err
+		}
+
+		cmd.Status = true
+	}
+
+	for {
+		group, err := parseNotifyEventGroup(p)
+		if err != nil {
+			return nil, err
+		}
+
+		cmd.EventGroups = append(cmd.EventGroups, group)
+
+		if ok, err := p.Matches(rfcparser.TokenTypeSP); err != nil {
+			return nil, err
+		} else if !ok {
+			break
+		}
+	}
+
+	return cmd, nil
+}
+
+func parseNotifyEventGroup(p *rfcparser.Parser) (NotifyEventGroup, error) {
+	// event-group     = "(" filter-mailboxes SP events ")"
+	if err := p.Consume(rfcparser.TokenTypeLParen, "expected ( for event group start"); err != nil {
+		return NotifyEventGroup{}, err
+	}
+
+	var group NotifyEventGroup
+
+	if err := parseNotifyFilter(p, &group); err != nil {
+		return NotifyEventGroup{}, err
+	}
+
+	if err := p.Consume(rfcparser.TokenTypeSP, "expected space after filter"); err != nil {
+		return NotifyEventGroup{}, err
+	}
+
+	if err := parseNotifyEvents(p, &group); err != nil {
+		return NotifyEventGroup{}, err
+	}
+
+	if err := p.Consume(rfcparser.TokenTypeRParen, "expected ) for event group end"); err != nil {
+		return NotifyEventGroup{}, err
+	}
+
+	return group, nil
+}
+
+func parseNotifyFilter(p *rfcparser.Parser, group *NotifyEventGroup) error {
+	// filter-mailboxes = filter-mailboxes-selected / filter-mailboxes-other
+	// filter-mailboxes-selected = "selected" / "selected-delayed"
+	// filter-mailboxes-other = "inboxes" / "personal" / "subscribed" /
+	//                   ( "subtree" SP one-or-more-mailbox ) /
+	//                   ( "mailboxes" SP one-or-more-mailbox )
+	filter, err := p.ParseAtom()
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(filter) {
+	case "selected":
+		group.Filter = NotifyFilterSelected
+
+	case "selected-delayed":
+		group.Filter = NotifyFilterSelectedDelayed
+
+	case "inboxes":
+		group.Filter = NotifyFilterInboxes
+
+	case "personal":
+		group.Filter = NotifyFilterPersonal
+
+	case "subscribed":
+		group.Filter = NotifyFilterSubscribed
+
+	case "subtree", "mailboxes":
+		if strings.EqualFold(filter, "subtree") {
+			group.Filter = NotifyFilterSubtree
+		} else {
+			group.Filter = NotifyFilterMailboxes
+		}
+
+		if err := p.Consume(rfcparser.TokenTypeSP, "expected space after filter"); err != nil {
+			return err
+		}
+
+		mailboxes, err := parseOneOrMoreMailbox(p)
+		if err != nil {
+			return err
+		}
+
+		group.Mailboxes = mailboxes
+
+	default:
+		return p.MakeError(fmt.Sprintf("unknown mailbox filter '%v'", filter))
+	}
+
+	return nil
+}
+
+func parseOneOrMoreMailbox(p *rfcparser.Parser) ([]string, error) {
+	// one-or-more-mailbox = mailbox / many-mailboxes
+	// many-mailboxes  = "(" mailbox *(SP mailbox) ")"
+	if ok, err := p.Matches(rfcparser.TokenTypeLParen); err != nil {
+		return nil, err
+	} else if !ok {
+		mailbox, err := ParseMailbox(p)
+		if err != nil {
+			return nil, err
+		}
+
+		return []string{mailbox.Value}, nil
+	}
+
+	var mailboxes []string
+
+	for {
+		mailbox, err := ParseMailbox(p)
+		if err != nil {
+			return nil, err
+		}
+
+		mailboxes = append(mailboxes, mailbox.Value)
+
+		if ok, err := p.Matches(rfcparser.TokenTypeSP); err != nil {
+			return nil, err
+		} else if !ok {
+			break
+		}
+	}
+
+	if err := p.Consume(rfcparser.TokenTypeRParen, "expected ) for mailbox list end"); err != nil {
+		return nil, err
+	}
+
+	return mailboxes, nil
+}
+
+func parseNotifyEvents(p *rfcparser.Parser, group *NotifyEventGroup) error {
+	// events          = ( "(" event *(SP event) ")" ) / "NONE"
+	// event           = message-event / mailbox-event / server-event / event-ext
+	// message-event   = ( "MessageNew" [SP "(" fetch-att *(SP fetch-att) ")" ] ) /
+	//                   "MessageExpunge" / "FlagChange" / "AnnotationChange"
+	// mailbox-event   = "MailboxName" / "SubscriptionChange" / "MailboxMetadataChange"
+	// server-event    = "ServerMetadataChange"
+	if ok, err := p.Matches(rfcparser.TokenTypeLParen); err != nil {
+		return err
+	} else if !ok {
+		return p.ConsumeBytesFold('N', 'O', 'N', 'E')
+	}
+
+	for {
+		event, err := p.ParseAtom()
+		if err != nil {
+			return err
+		}
+
+		group.Events = append(group.Events, toNotifyEvent(event))
+
+		if ok, err := p.Matches(rfcparser.TokenTypeSP); err != nil {
+			return err
+		} else if !ok {
+			break
+		}
+
+		if toNotifyEvent(event) != NotifyEventMessageNew || !p.Check(rfcparser.TokenTypeLParen) {
+			continue
+		}
+
+		// Fetch attributes are only allowed for the selected mailbox (RFC5465).
+		if group.Filter != NotifyFilterSelected && group.Filter != NotifyFilterSelectedDelayed {
+			return p.MakeError("fetch attributes are only allowed with the SELECTED filters")
+		}
+
+		attributes, err := parseFetchAttributes(p)
+		if err != nil {
+			return err
+		}
+
+		group.FetchAttributes = attributes
+
+		if ok, err := p.Matches(rfcparser.TokenTypeSP); err != nil {
+			return err
+		} else if !ok {
+			break
+		}
+	}
+
+	return p.Consume(rfcparser.TokenTypeRParen, "expected ) for events end")
+}
+
+func toNotifyEvent(name string) NotifyEvent {
+	for _, event := range notifyEvents {
+		if strings.EqualFold(name, string(event)) {
+			return event
+		}
+	}
+
+	return NotifyEvent(name)
+}
@@ -0,0 +1,80 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_NotifyNone(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &Notify{}}
+
+	cmd, err := testParseCommand(`tag NOTIFY NONE`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_NotifySet(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &Notify{
+		Status: true,
+		EventGroups: []NotifyEventGroup{
+			{
+				Filter: NotifyFilterSelected,
+				Events: []NotifyEvent{NotifyEventMessageNew, NotifyEventMessageExpunge, NotifyEventFlagChange},
+			},
+			{
+				Filter:    NotifyFilterMailboxes,
+				Mailboxes: []string{"INBOX", "Sent Items"},
+				Events:    []NotifyEvent{NotifyEventMessageNew, NotifyEventMessageExpunge},
+			},
+			{
+				Filter:    NotifyFilterSubtree,
+				Mailboxes: []string{"Lists"},
+				Events:    []NotifyEvent{NotifyEventMailboxName, "FooBar"},
+			},
+			{
+				Filter: NotifyFilterPersonal,
+			},
+		},
+	}}
+
+	cmd, err := testParseCommand(`tag NOTIFY SET STATUS (selected (MessageNew messageexpunge FlagChange)) (mailboxes (inbox "Sent Items") (MessageNew MessageExpunge)) (SUBTREE Lists (MailboxName FooBar)) (personal NONE)`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_NotifyFetchAttributes(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &Notify{
+		EventGroups: []NotifyEventGroup{
+			{
+				Filter: NotifyFilterSelectedDelayed,
+				Events: []NotifyEvent{NotifyEventMessageNew, NotifyEventMessageExpunge},
+				FetchAttributes: []FetchAttribute{
+					&FetchAttributeUID{},
+					&FetchAttributeBodySection{Peek: true, Section: &BodySectionHeader{}},
+				},
+			},
+		},
+	}}
+
+	cmd, err := testParseCommand(`tag NOTIFY SET (selected-delayed (MessageNew (UID BODY.PEEK[HEADER]) MessageExpunge))`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_NotifyInvalid(t *testing.T) {
+	for _, input := range []string{
+		`tag NOTIFY`,
+		`tag NOTIFY SET`,
+		`tag NOTIFY SET STATUS`,
+		`tag NOTIFY SET (selected)`,
+		`tag NOTIFY SET (foo (MessageNew))`,
+		`tag NOTIFY SET (mailboxes (MessageNew))`,
+		`tag NOTIFY SET (personal (MessageNew (UID) MessageExpunge))`,
+		`tag NOTIFY SET (selected (MessageNew (FOO)))`,
+		`tag NOTIFY FOO`,
+	} {
+		_, err := testParseCommand(input)
+		require.Error(t, err, input)
+	}
+}
@@ -14,6 +14,7 @@ type Builder interface {
 type parserBuilder struct {
 	continuationCallback    func(string) error
 	disableIMAPAuthenticate bool
+	maxNonSyncLiteralSize   int
 }
 
 type Option interface {
@@ -44,6 +45,21 @@ func WithDisableIMAPAuthenticate() Option {
 	return &withDisableIMAPAuthenticate{}
 }
 
+type withMaxNonSyncLiteralSize struct {
+	size int
+}
+
+func (opt withMaxNonSyncLiteralSize) config(builder *parserBuilder) {
+	builder.maxNonSyncLiteralSize = opt.size
+}
+
+// WithMaxNonSyncLiteralSize limits the size of non-synchronizing literals (RFC7888). A size of 0 means no limit.
+func WithMaxNonSyncLiteralSize(size int) Option {
+	return &withMaxNonSyncLiteralSize{
+		size: size,
+	}
+}
+
 // Parser parses IMAP Commands.
 type Parser struct {
 	parser   *rfcparser.Parser
@@ -60,43 +76,60 @@ func NewParser(s *rfcparser.Scanner, options ...Option) *Parser {
 	}
 
 	commands := map[string]Builder{
-		"list":        &ListCommandParser{},
-		"append":      &AppendCommandParser{},
-		"search":      &SearchCommandParser{},
-		"fetch":       &FetchCommandParser{},
-		"capability":  &CapabilityCommandParser{},
-		"idle":        &IdleCommandParser{},
-		"noop":        &NoopCommandParser{},
-		"logout":      &LogoutCommandParser{},
-		"check":       &CheckCommandParser{},
-		"close":       &CloseCommandParser{},
-		"expunge":     &ExpungeCommandParser{},
-		"unselect":    &UnselectCommandParser{},
-		"starttls":    &StartTLSCommandParser{},
-		"status":      &StatusCommandParser{},
-		"select":      &SelectCommandParser{},
-		"examine":     &ExamineCommandParser{},
-		"create":      &CreateCommandParser{},
-		"delete":      &DeleteCommandParser{},
-		"subscribe":   &SubscribeCommandParser{},
-		"unsubscribe": &UnsubscribeCommandParser{},
-		"rename":      &RenameCommandParser{},
-		"lsub":        &LSubCommandParser{},
-		"login":       &LoginCommandParser{},
-		"store":       &StoreCommandParser{},
-		"copy":        &CopyCommandParser{},
-		"move":        &MoveCommandParser{},
-		"uid":         NewUIDCommandParser(),
-		"id":          &IDCommandParser{},
+		"list":         &ListCommandParser{},
+		"append":       &AppendCommandParser{},
+		"search":       &SearchCommandParser{},
+		"fetch":        &FetchCommandParser{},
+		"capability":   &CapabilityCommandParser{},
+		"idle":         &IdleCommandParser{},
+		"noop":         &NoopCommandParser{},
+		"logout":       &LogoutCommandParser{},
+		"check":        &CheckCommandParser{},
+		"close":        &CloseCommandParser{},
+		"expunge":      &ExpungeCommandParser{},
+		"unselect":     &UnselectCommandParser{},
+		"starttls":     &StartTLSCommandParser{},
+		"status":       &StatusCommandParser{},
+		"select":       &SelectCommandParser{},
+		"examine":      &ExamineCommandParser{},
+		"create":       &CreateCommandParser{},
+		"delete":       &DeleteCommandParser{},
+		"subscribe":    &SubscribeCommandParser{},
+		"unsubscribe":  &UnsubscribeCommandParser{},
+		"rename":       &RenameCommandParser{},
+		"lsub":         &LSubCommandParser{},
+		"login":        &LoginCommandParser{},
+		"store":        &StoreCommandParser{},
+		"copy":         &CopyCommandParser{},
+		"move":         &MoveCommandParser{},
+		"uid":          NewUIDCommandParser(),
+		"id":           &IDCommandParser{},
+		"enable":       &EnableCommandParser{},
+		"namespace":    &NamespaceCommandParser{},
+		"compress":     &CompressCommandParser{},
+		"sort":         &SortCommandParser{},
+		"thread":       &ThreadCommandParser{},
+		"getquota":     &GetQuotaCommandParser{},
+		"getquotaroot": &GetQuotaRootCommandParser{},
+		"getmetadata":  &GetMetadataCommandParser{},
+		"setmetadata":  &SetMetadataCommandParser{},
+		"notify":       &NotifyCommandParser{},
+		"resetkey":     &ResetKeyCommandParser{},
+		"genurlauth":   &GenURLAuthCommandParser{},
+		"urlfetch":     &URLFetchCommandParser{},
+		"replace":      &ReplaceCommandParser{},
 	}
 
 	if !builder.disableIMAPAuthenticate {
 		commands["authenticate"] = &AuthenticateCommandParser{}
 	}
 
+	parser := rfcparser.NewParserWithLiteralContinuationCb(s, builder.continuationCallback)
+	parser.SetMaxNonSyncLiteralSize(builder.maxNonSyncLiteralSize)
+
 	return &Parser{
 		scanner:  s,
-		parser:   rfcparser.NewParserWithLiteralContinuationCb(s, builder.continuationCallback),
+		parser:   parser,
 		commands: commands,
 	}
 }
@@ -0,0 +1,78 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/ProtonMail/gluon/rfcparser"
+)
+
+// PartialRange is a range of the messages of a SEARCH or FETCH result (RFC9394), e.g. 1:100 for its first 100
+// messages. Negative ranges count from the end of the result, e.g. -1:-100 for its last 100 messages.
+type PartialRange struct {
+	// First and Last are both positive or both negative, with |First| <= |Last|.
+	First, Last int
+}
+
+func (r PartialRange) String() string {
+	return fmt.Sprintf("%v:%v", r.First, r.Last)
+}
+
+// Bounds returns the slice bounds of the range within a result of the given length.
+func (r PartialRange) Bounds(length int) (int, int) {
+	var begin, end int
+
+	if r.First > 0 {
+		begin, end = r.First-1, r.Last
+	} else {
+		begin, end = length+r.Last, length+r.First+1
+	}
+
+	begin, end = max(begin, 0), min(end, length)
+
+	if begin >= end {
+		return 0, 0
+	}
+
+	return begin, end
+}
+
+func parsePartialRange(p *rfcparser.Parser) (*PartialRange, error) {
+	// partial-range       = partial-range-first / partial-range-last
+	// partial-range-first = nz-number ":" nz-number
+	// partial-range-last  = MINUS nz-number ":" MINUS nz-number
+	negative, err := p.Matches(rfcparser.TokenTypeMinus)
+	if err != nil {
+		return nil, err
+	}
+
+	first, err := ParseNZNumber(p)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.Consume(rfcparser.TokenTypeColon, "expected : for partial range"); err != nil {
+		return nil, err
+	}
+
+	if negative {
+		if err := p.Consume(rfcparser.TokenTypeMinus, "expected - for partial range end"); err != nil {
+			return nil, err
+		}
+	}
+
+	last, err := ParseNZNumber(p)
+	if err != nil {
+		return nil, err
+	}
+
+	// The range 500:400 is the same as 400:500.
+	if first > last {
+		first, last = last, first
+	}
+
+	if negative {
+		return &PartialRange{First: -first, Last: -last}, nil
+	}
+
+	return &PartialRange{First: first, Last: last}, nil
+}
@@ -0,0 +1,30 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartialRange_Bounds(t *testing.T) {
+	tests := []struct {
+		partial    PartialRange
+		length     int
+		begin, end int
+	}{
+		{partial: PartialRange{First: 1, Last: 100}, length: 250, begin: 0, end: 100},
+		{partial: PartialRange{First: 101, Last: 200}, length: 150, begin: 100, end: 150},
+		{partial: PartialRange{First: 300, Last: 400}, length: 150, begin: 0, end: 0},
+		{partial: PartialRange{First: -1, Last: -100}, length: 250, begin: 150, end: 250},
+		{partial: PartialRange{First: -101, Last: -200}, length: 150, begin: 0, end: 50},
+		{partial: PartialRange{First: -300, Last: -400}, length: 150, begin: 0, end: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.partial.String(), func(t *testing.T) {
+			begin, end := test.partial.Bounds(test.length)
+			require.Equal(t, test.begin, begin)
+			require.Equal(t, test.end, end)
+		})
+	}
+}
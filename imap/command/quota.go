@@ -0,0 +1,64 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/ProtonMail/gluon/rfcparser"
+)
+
+type GetQuota struct {
+	Root string
+}
+
+func (l GetQuota) String() string {
+	return fmt.Sprintf("GETQUOTA '%v'", l.Root)
+}
+
+func (l GetQuota) SanitizedString() string {
+	return fmt.Sprintf("GETQUOTA '%v'", sanitizeString(l.Root))
+}
+
+type GetQuotaCommandParser struct{}
+
+func (GetQuotaCommandParser) FromParser(p *rfcparser.Parser) (Payload, error) {
+	// getquota        = "GETQUOTA" SP quota-root-name
+	// quota-root-name = astring
+	if err := p.Consume(rfcparser.TokenTypeSP, "expected space after command"); err != nil {
+		return nil, err
+	}
+
+	root, err := p.ParseAString()
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetQuota{Root: root.Value}, nil
+}
+
+type GetQuotaRoot struct {
+	Mailbox string
+}
+
+func (l GetQuotaRoot) String() string {
+	return fmt.Sprintf("GETQUOTAROOT '%v'", l.Mailbox)
+}
+
+func (l GetQuotaRoot) SanitizedString() string {
+	return fmt.Sprintf("GETQUOTAROOT '%v'", sanitizeString(l.Mailbox))
+}
+
+type GetQuotaRootCommandParser struct{}
+
+func (GetQuotaRootCommandParser) FromParser(p *rfcparser.Parser) (Payload, error) {
+	// getquotaroot    = "GETQUOTAROOT" SP mailbox
+	if err := p.Consume(rfcparser.TokenTypeSP, "expected space after command"); err != nil {
+		return nil, err
+	}
+
+	mailbox, err := ParseMailbox(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetQuotaRoot{Mailbox: mailbox.Value}, nil
+}
@@ -0,0 +1,28 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_GetQuotaCommand(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &GetQuota{Root: ""}}
+
+	cmd, err := testParseCommand(`tag GETQUOTA ""`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_GetQuotaCommandMissingRoot(t *testing.T) {
+	_, err := testParseCommand(`tag GETQUOTA`)
+	require.Error(t, err)
+}
+
+func TestParser_GetQuotaRootCommand(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &GetQuotaRoot{Mailbox: "INBOX"}}
+
+	cmd, err := testParseCommand(`tag GETQUOTAROOT inbox`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
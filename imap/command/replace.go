@@ -0,0 +1,71 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/ProtonMail/gluon/rfcparser"
+)
+
+// Replace replaces a message of the selected mailbox with a new message appended to the given mailbox (RFC8508).
+type Replace struct {
+	SeqNum  SeqNum
+	Mailbox string
+	Message AppendMessage
+}
+
+func (l Replace) String() string {
+	return fmt.Sprintf("REPLACE %v '%v' Flags='%v' DateTime='%v' Literal=%v",
+		l.SeqNum,
+		l.Mailbox,
+		l.Message.Flags,
+		l.Message.DateTime,
+		l.Message.Literal,
+	)
+}
+
+func (l Replace) SanitizedString() string {
+	return fmt.Sprintf("REPLACE %v '%v' Flags='%v' DateTime='%v'",
+		l.SeqNum,
+		sanitizeString(l.Mailbox),
+		l.Message.Flags,
+		l.Message.DateTime,
+	)
+}
+
+type ReplaceCommandParser struct{}
+
+func (ReplaceCommandParser) FromParser(p *rfcparser.Parser) (Payload, error) {
+	// replace         = "REPLACE" SP seq-number SP mailbox append-message
+	if err := p.Consume(rfcparser.TokenTypeSP, "expected space after command"); err != nil {
+		return nil, err
+	}
+
+	seqNum, err := ParseSeqNumber(p)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.Consume(rfcparser.TokenTypeSP, "expected space after sequence number"); err != nil {
+		return nil, err
+	}
+
+	mailbox, err := ParseMailbox(p)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.Consume(rfcparser.TokenTypeSP, "expected space after mailbox"); err != nil {
+		return nil, err
+	}
+
+	message, err := parseAppendMessage(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Replace{
+		SeqNum:  seqNum,
+		Mailbox: mailbox.Value,
+		Message: message,
+	}, nil
+}
@@ -0,0 +1,63 @@
+package command
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/gluon/rfcparser"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_ReplaceCommand(t *testing.T) {
+	input := toIMAPLine(`tag REPLACE 4 Drafts (\Seen \Draft) "15-Nov-1984 13:37:01 +0730" {23}`, `My message body is here`)
+	s := rfcparser.NewScanner(bytes.NewReader(input))
+	p := NewParser(s)
+
+	expected := Command{Tag: "tag", Payload: &Replace{
+		SeqNum:  4,
+		Mailbox: "Drafts",
+		Message: AppendMessage{
+			Flags:    []string{`\Seen`, `\Draft`},
+			DateTime: buildAppendDateTime(1984, time.November, 15, 13, 37, 1, 07, 30, false),
+			Literal:  []byte("My message body is here"),
+		},
+	}}
+
+	cmd, err := p.Parse()
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+	require.Equal(t, "replace", p.LastParsedCommand())
+	require.Equal(t, "tag", p.LastParsedTag())
+}
+
+func TestParser_ReplaceCommandUID(t *testing.T) {
+	input := toIMAPLine(`tag UID REPLACE 25 INBOX {23}`, `My message body is here`)
+	s := rfcparser.NewScanner(bytes.NewReader(input))
+	p := NewParser(s)
+
+	expected := Command{Tag: "tag", Payload: &UID{
+		Command: &Replace{
+			SeqNum:  25,
+			Mailbox: "INBOX",
+			Message: AppendMessage{
+				Literal: []byte("My message body is here"),
+			},
+		},
+	}}
+
+	cmd, err := p.Parse()
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_ReplaceCommandInvalid(t *testing.T) {
+	_, err := testParseCommand(`tag REPLACE 1:2 INBOX {0}`)
+	require.Error(t, err)
+
+	_, err = testParseCommand(`tag REPLACE 0 INBOX {0}`)
+	require.Error(t, err)
+
+	_, err = testParseCommand(`tag REPLACE 1 INBOX`)
+	require.Error(t, err)
+}
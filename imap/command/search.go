@@ -7,13 +7,33 @@ import (
 
 	"github.com/ProtonMail/gluon/rfcparser"
 	"github.com/bradenaw/juniper/xslices"
+	"golang.org/x/exp/slices"
 )
 
 type Search struct {
 	Charset string
 	Keys    []SearchKey
+
+	// Return holds the requested result options (RFC4731). It is nil if the client expects a plain SEARCH response.
+	Return []SearchReturnOption
+
+	// Partial is the range of the result returned by the PARTIAL result option (RFC9394). It is nil if the option is
+	// not requested.
+	Partial *PartialRange
 }
 
+type SearchReturnOption string
+
+const (
+	SearchReturnOptionMin   SearchReturnOption = "MIN"
+	SearchReturnOptionMax   SearchReturnOption = "MAX"
+	SearchReturnOptionAll   SearchReturnOption = "ALL"
+	SearchReturnOptionCount SearchReturnOption = "COUNT"
+	SearchReturnOptionSave  SearchReturnOption = "SAVE"
+
+	SearchReturnOptionPartial SearchReturnOption = "PARTIAL"
+)
+
 type SearchKey interface {
 	String() string
 	SanitizedString() string
@@ -25,6 +45,10 @@ func (s Search) String() string {
 		charsetStr = s.Charset
 	}
 
+	if s.Return != nil {
+		return fmt.Sprintf("SEARCH RETURN %v CHARSET=%v %v", s.Return, charsetStr, s.Keys)
+	}
+
 	return fmt.Sprintf("SEARCH CHARSET=%v %v", charsetStr, s.Keys)
 }
 
@@ -34,9 +58,15 @@ func (s Search) SanitizedString() string {
 		charsetStr = s.Charset
 	}
 
-	return fmt.Sprintf("SEARCH CHARSET=%v %v", charsetStr, xslices.Map(s.Keys, func(v SearchKey) string {
+	keys := xslices.Map(s.Keys, func(v SearchKey) string {
 		return v.SanitizedString()
-	}))
+	})
+
+	if s.Return != nil {
+		return fmt.Sprintf("SEARCH RETURN %v CHARSET=%v %v", s.Return, charsetStr, keys)
+	}
+
+	return fmt.Sprintf("SEARCH CHARSET=%v %v", charsetStr, keys)
 }
 
 type SearchCommandParser struct{}
@@ -44,14 +74,21 @@ type SearchCommandParser struct{}
 func (scp *SearchCommandParser) FromParser(p *rfcparser.Parser) (Payload, error) {
 	//search          = "SEARCH" [SP "CHARSET" SP astring] 1*(SP search-key)
 	//                     ; CHARSET argument to MUST be registered with IANA
-	var keys []SearchKey
-
-	var charset string
-
+	//
+	// RFC4731:
+	//search          =/ "SEARCH" [search-return-opts] SP search-program
 	if err := p.Consume(rfcparser.TokenTypeSP, "expected space after command"); err != nil {
 		return nil, err
 	}
 
+	return parseSearchProgram(p, true)
+}
+
+func parseSearchProgram(p *rfcparser.Parser, allowReturn bool) (*Search, error) {
+	var keys []SearchKey
+
+	var charset string
+
 	// Check for optional charset.
 	if ok, err := p.Matches(rfcparser.TokenTypeChar); err != nil {
 		return nil, err
@@ -103,6 +140,10 @@ func (scp *SearchCommandParser) FromParser(p *rfcparser.Parser) (Payload, error)
 				Offset: firstChar.Offset,
 			}
 
+			if allowReturn && keywordStr.Value == "return" {
+				return parseSearchWithReturnOpts(p)
+			}
+
 			key, err := handleSearchKey(keywordStr, p)
 			if err != nil {
 				return nil, err
@@ -144,6 +185,102 @@ func (scp *SearchCommandParser) FromParser(p *rfcparser.Parser) (Payload, error)
 	}, nil
 }
 
+func parseSearchWithReturnOpts(p *rfcparser.Parser) (*Search, error) {
+	// search-return-opts = SP "RETURN" SP "(" [search-return-opt *(SP search-return-opt)] ")"
+	// search-return-opt  = "MIN" / "MAX" / "ALL" / "COUNT"
+	//
+	// RFC5182:
+	// search-return-opt  =/ "SAVE"
+	//
+	// RFC9394:
+	// search-return-opt  =/ "PARTIAL" SP partial-range
+	if err := p.Consume(rfcparser.TokenTypeSP, "expected space after RETURN"); err != nil {
+		return nil, err
+	}
+
+	if err := p.Consume(rfcparser.TokenTypeLParen, "expected ( for search return options"); err != nil {
+		return nil, err
+	}
+
+	var (
+		options []SearchReturnOption
+		partial *PartialRange
+	)
+
+	if !p.Check(rfcparser.TokenTypeRParen) {
+		for {
+			option, err := parseSearchReturnOption(p)
+			if err != nil {
+				return nil, err
+			}
+
+			if option == SearchReturnOptionPartial {
+				if err := p.Consume(rfcparser.TokenTypeSP, "expected space after PARTIAL"); err != nil {
+					return nil, err
+				}
+
+				if partial, err = parsePartialRange(p); err != nil {
+					return nil, err
+				}
+			}
+
+			if !slices.Contains(options, option) {
+				options = append(options, option)
+			}
+
+			if ok, err := p.Matches(rfcparser.TokenTypeSP); err != nil {
+				return nil, err
+			} else if !ok {
+				break
+			}
+		}
+	}
+
+	if err := p.Consume(rfcparser.TokenTypeRParen, "expected ) for search return options"); err != nil {
+		return nil, err
+	}
+
+	if err := p.Consume(rfcparser.TokenTypeSP, "expected space after search return options"); err != nil {
+		return nil, err
+	}
+
+	// An empty list of options is equivalent to ALL.
+	if len(options) == 0 {
+		options = []SearchReturnOption{SearchReturnOptionAll}
+	}
+
+	// PARTIAL returns a part of the result which ALL would return whole.
+	if partial != nil && slices.Contains(options, SearchReturnOptionAll) {
+		return nil, p.MakeError("PARTIAL and ALL search return options are mutually exclusive")
+	}
+
+	search, err := parseSearchProgram(p, false)
+	if err != nil {
+		return nil, err
+	}
+
+	search.Return = options
+	search.Partial = partial
+
+	return search, nil
+}
+
+func parseSearchReturnOption(p *rfcparser.Parser) (SearchReturnOption, error) {
+	atom, err := p.ParseAtom()
+	if err != nil {
+		return "", err
+	}
+
+	switch option := SearchReturnOption(strings.ToUpper(atom)); option {
+	case SearchReturnOptionMin, SearchReturnOptionMax, SearchReturnOptionAll, SearchReturnOptionCount, SearchReturnOptionSave,
+		SearchReturnOptionPartial:
+		return option, nil
+
+	default:
+		return "", p.MakeError(fmt.Sprintf("unknown search return option %v", atom))
+	}
+}
+
 func parseSearchKey(p *rfcparser.Parser) (SearchKey, error) {
 	if ok, err := p.Matches(rfcparser.TokenTypeLParen); err != nil {
 		return nil, err
@@ -151,7 +288,7 @@ func parseSearchKey(p *rfcparser.Parser) (SearchKey, error) {
 		return parseSearchKeyList(p)
 	}
 
-	if p.Check(rfcparser.TokenTypeDigit) || p.Check(rfcparser.TokenTypeAsterisk) {
+	if p.Check(rfcparser.TokenTypeDigit) || p.Check(rfcparser.TokenTypeAsterisk) || p.Check(rfcparser.TokenTypeDollar) {
 		seqSet, err := ParseSeqSet(p)
 		if err != nil {
 			return nil, err
@@ -232,7 +369,10 @@ func handleSearchKey(keyword rfcparser.String, p *rfcparser.Parser) (SearchKey,
 	                    "SENTBEFORE" SP date / "SENTON" SP date /
 	                    "SENTSINCE" SP date / "SMALLER" SP number /
 	                    "UID" SP sequence-set / "UNDRAFT" / sequence-set /
-	                    "(" search-key *(SP search-key) ")"     	*/
+	                    "(" search-key *(SP search-key) ")" /
+	                    search-modsequence /
+	                    "EMAILID" SP objectid / "THREADID" SP objectid
+	*/
 	switch keyword.Value {
 	case "all":
 		return &SearchKeyAll{}, nil
@@ -470,11 +610,127 @@ func handleSearchKey(keyword rfcparser.String, p *rfcparser.Parser) (SearchKey,
 	case "undraft":
 		return &SearchKeyUndraft{}, nil
 
+	case "modseq":
+		return parseSearchKeyModSeq(p)
+
+	case "emailid":
+		value, err := parseStringKeyAtom(p)
+		if err != nil {
+			return nil, err
+		}
+
+		return &SearchKeyEmailID{Value: value}, nil
+
+	case "threadid":
+		value, err := parseStringKeyAtom(p)
+		if err != nil {
+			return nil, err
+		}
+
+		return &SearchKeyThreadID{Value: value}, nil
+
+	case "savedbefore":
+		value, err := parseStringKeyDate(p)
+		if err != nil {
+			return nil, err
+		}
+
+		return &SearchKeySavedBefore{Value: value}, nil
+
+	case "savedon":
+		value, err := parseStringKeyDate(p)
+		if err != nil {
+			return nil, err
+		}
+
+		return &SearchKeySavedOn{Value: value}, nil
+
+	case "savedsince":
+		value, err := parseStringKeyDate(p)
+		if err != nil {
+			return nil, err
+		}
+
+		return &SearchKeySavedSince{Value: value}, nil
+
+	case "savedatesupported":
+		return &SearchKeySaveDateSupported{}, nil
+
+	case "older":
+		value, err := parseStringKeyNZNumber(p)
+		if err != nil {
+			return nil, err
+		}
+
+		return &SearchKeyOlder{Value: value}, nil
+
+	case "younger":
+		value, err := parseStringKeyNZNumber(p)
+		if err != nil {
+			return nil, err
+		}
+
+		return &SearchKeyYounger{Value: value}, nil
+
 	default:
 		return nil, p.MakeErrorAtOffset(fmt.Sprintf("unknown search key '%v'", keyword.Value), keyword.Offset)
 	}
 }
 
+func parseSearchKeyModSeq(p *rfcparser.Parser) (SearchKey, error) {
+	// search-modsequence = "MODSEQ" [search-modseq-ext] SP
+	//                      mod-sequence-valzer
+	// search-modseq-ext  = SP entry-name SP entry-type-req
+	// entry-name         = DQUOTE "/flags/" attr-flag DQUOTE
+	// entry-type-req     = entry-type-resp / "all"
+	// entry-type-resp    = "priv" / "shared"
+	if err := p.Consume(rfcparser.TokenTypeSP, "expected space"); err != nil {
+		return nil, err
+	}
+
+	var key SearchKeyModSeq
+
+	if p.Check(rfcparser.TokenTypeDQuote) {
+		entryName, err := p.ParseQuoted()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.Consume(rfcparser.TokenTypeSP, "expected space after entry name"); err != nil {
+			return nil, err
+		}
+
+		entryType, err := p.CollectBytesWhileMatches(rfcparser.TokenTypeChar)
+		if err != nil {
+			return nil, err
+		}
+
+		entryTypeStr := entryType.IntoString().ToLower()
+
+		switch entryTypeStr.Value {
+		case "priv", "shared", "all":
+		default:
+			return nil, p.MakeErrorAtOffset(fmt.Sprintf("unknown entry type '%v'", entryTypeStr.Value), entryTypeStr.Offset)
+		}
+
+		if err := p.Consume(rfcparser.TokenTypeSP, "expected space after entry type"); err != nil {
+			return nil, err
+		}
+
+		key.EntryName = entryName.Value
+		key.EntryType = entryTypeStr.Value
+	}
+
+	value, err := ParseModSeqValzer(p)
+	if err != nil {
+		return nil, err
+	}
+
+	key.Value = value
+
+	return &key, nil
+}
+
 func parseStringKeyAString(p *rfcparser.Parser) (string, error) {
 	if err := p.Consume(rfcparser.TokenTypeSP, "expected space"); err != nil {
 		return "", err
@@ -496,6 +752,14 @@ func parseStringKeyNumber(p *rfcparser.Parser) (int, error) {
 	return p.ParseNumber()
 }
 
+func parseStringKeyNZNumber(p *rfcparser.Parser) (int, error) {
+	if err := p.Consume(rfcparser.TokenTypeSP, "expected space"); err != nil {
+		return 0, err
+	}
+
+	return ParseNZNumber(p)
+}
+
 func parseStringKeyDate(p *rfcparser.Parser) (time.Time, error) {
 	if err := p.Consume(rfcparser.TokenTypeSP, "expected space"); err != nil {
 		return time.Time{}, err
@@ -402,6 +402,126 @@ func (s SearchKeyUndraft) SanitizedString() string {
 	return s.String()
 }
 
+type SearchKeyModSeq struct {
+	EntryName string
+	EntryType string
+	Value     uint64
+}
+
+func (s SearchKeyModSeq) String() string {
+	if len(s.EntryName) != 0 {
+		return fmt.Sprintf("MODSEQ %v %v %v", s.EntryName, s.EntryType, s.Value)
+	}
+
+	return fmt.Sprintf("MODSEQ %v", s.Value)
+}
+
+func (s SearchKeyModSeq) SanitizedString() string {
+	return s.String()
+}
+
+// SearchKeyEmailID matches the message with the given EMAILID (RFC8474).
+type SearchKeyEmailID struct {
+	Value string
+}
+
+func (s SearchKeyEmailID) String() string {
+	return fmt.Sprintf("EMAILID %v", s.Value)
+}
+
+func (s SearchKeyEmailID) SanitizedString() string {
+	return s.String()
+}
+
+// SearchKeyThreadID matches the messages with the given THREADID (RFC8474).
+type SearchKeyThreadID struct {
+	Value string
+}
+
+func (s SearchKeyThreadID) String() string {
+	return fmt.Sprintf("THREADID %v", s.Value)
+}
+
+func (s SearchKeyThreadID) SanitizedString() string {
+	return s.String()
+}
+
+// SearchKeySavedBefore matches the messages saved in the mailbox before the given date (RFC8514).
+type SearchKeySavedBefore struct {
+	Value time.Time
+}
+
+func (s SearchKeySavedBefore) String() string {
+	return fmt.Sprintf("SAVEDBEFORE %v", s.Value)
+}
+
+func (s SearchKeySavedBefore) SanitizedString() string {
+	return fmt.Sprintf("SAVEDBEFORE <DATE>")
+}
+
+// SearchKeySavedOn matches the messages saved in the mailbox on the given date (RFC8514).
+type SearchKeySavedOn struct {
+	Value time.Time
+}
+
+func (s SearchKeySavedOn) String() string {
+	return fmt.Sprintf("SAVEDON %v", s.Value)
+}
+
+func (s SearchKeySavedOn) SanitizedString() string {
+	return fmt.Sprintf("SAVEDON <DATE>")
+}
+
+// SearchKeySavedSince matches the messages saved in the mailbox on or after the given date (RFC8514).
+type SearchKeySavedSince struct {
+	Value time.Time
+}
+
+func (s SearchKeySavedSince) String() string {
+	return fmt.Sprintf("SAVEDSINCE %v", s.Value)
+}
+
+func (s SearchKeySavedSince) SanitizedString() string {
+	return fmt.Sprintf("SAVEDSINCE <DATE>")
+}
+
+// SearchKeySaveDateSupported matches all the messages if the mailbox supports save dates (RFC8514).
+type SearchKeySaveDateSupported struct{}
+
+func (s SearchKeySaveDateSupported) String() string {
+	return "SAVEDATESUPPORTED"
+}
+
+func (s SearchKeySaveDateSupported) SanitizedString() string {
+	return s.String()
+}
+
+// SearchKeyOlder matches the messages whose internal date is at least the given number of seconds ago (RFC5032).
+type SearchKeyOlder struct {
+	Value int
+}
+
+func (s SearchKeyOlder) String() string {
+	return fmt.Sprintf("OLDER %v", s.Value)
+}
+
+func (s SearchKeyOlder) SanitizedString() string {
+	return s.String()
+}
+
+// SearchKeyYounger matches the messages whose internal date is at most the given number of seconds ago (RFC5032).
+type SearchKeyYounger struct {
+	Value int
+}
+
+func (s SearchKeyYounger) String() string {
+	return fmt.Sprintf("YOUNGER %v", s.Value)
+}
+
+func (s SearchKeyYounger) SanitizedString() string {
+	return s.String()
+}
+
 type SearchKeyList struct {
 	Keys []SearchKey
 }
@@ -644,6 +644,74 @@ func TestParser_Search_ISO_8859_1_String(t *testing.T) {
 	require.Equal(t, expected, cmd)
 }
 
+func TestParser_SearchCommandModSeq(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &Search{
+		Keys: []SearchKey{
+			&SearchKeyModSeq{Value: 620162338},
+		},
+	}}
+
+	cmd, err := testParseCommand(`tag SEARCH MODSEQ 620162338`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_SearchCommandModSeqWithEntry(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &Search{
+		Keys: []SearchKey{
+			&SearchKeyModSeq{EntryName: `/flags/\draft`, EntryType: "all", Value: 620162338},
+		},
+	}}
+
+	cmd, err := testParseCommand(`tag SEARCH MODSEQ "/flags/\\draft" all 620162338`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_SearchCommandObjectID(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &Search{
+		Keys: []SearchKey{
+			&SearchKeyEmailID{Value: "M6d99ac3275bb4e"},
+			&SearchKeyThreadID{Value: "T64b478a75b7ea9"},
+		},
+	}}
+
+	cmd, err := testParseCommand(`tag SEARCH EMAILID M6d99ac3275bb4e THREADID T64b478a75b7ea9`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_SearchCommandSaveDate(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &Search{
+		Keys: []SearchKey{
+			&SearchKeySavedBefore{Value: buildSearchTestDate(2009, time.January, 01)},
+			&SearchKeySavedOn{Value: buildSearchTestDate(2009, time.February, 02)},
+			&SearchKeySavedSince{Value: buildSearchTestDate(2009, time.March, 03)},
+			&SearchKeySaveDateSupported{},
+		},
+	}}
+
+	cmd, err := testParseCommand(`tag SEARCH SAVEDBEFORE 1-Jan-2009 SAVEDON 2-Feb-2009 SAVEDSINCE 3-Mar-2009 SAVEDATESUPPORTED`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_SearchCommandWithin(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &Search{
+		Keys: []SearchKey{
+			&SearchKeyOlder{Value: 2592000},
+			&SearchKeyYounger{Value: 60},
+		},
+	}}
+
+	cmd, err := testParseCommand(`tag SEARCH OLDER 2592000 YOUNGER 60`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+
+	_, err = testParseCommand(`tag SEARCH OLDER 0`)
+	require.Error(t, err)
+}
+
 func enc(text, encoding string) []byte {
 	enc, err := htmlindex.Get(encoding)
 	if err != nil {
@@ -657,3 +725,79 @@ func enc(text, encoding string) []byte {
 
 	return b
 }
+
+func TestParser_SearchReturn(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &Search{
+		Charset: "",
+		Keys: []SearchKey{
+			&SearchKeyUnseen{},
+		},
+		Return: []SearchReturnOption{SearchReturnOptionMin, SearchReturnOptionCount, SearchReturnOptionSave},
+	}}
+
+	cmd, err := testParseCommand(`tag SEARCH RETURN (MIN count SAVE) UNSEEN`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_SearchReturnEmpty(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &Search{
+		Charset: "UTF-8",
+		Keys: []SearchKey{
+			&SearchKeyRecent{},
+		},
+		Return: []SearchReturnOption{SearchReturnOptionAll},
+	}}
+
+	cmd, err := testParseCommand(`tag SEARCH RETURN () CHARSET UTF-8 RECENT`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_SearchReturnInvalid(t *testing.T) {
+	_, err := testParseCommand(`tag SEARCH RETURN (FOO) ALL`)
+	require.Error(t, err)
+
+	_, err = testParseCommand(`tag SEARCH RETURN (MIN)`)
+	require.Error(t, err)
+
+	_, err = testParseCommand(`tag SEARCH ALL RETURN (MIN)`)
+	require.Error(t, err)
+}
+
+func TestParser_SearchReturnPartial(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &Search{
+		Charset: "",
+		Keys: []SearchKey{
+			&SearchKeyUnseen{},
+		},
+		Return:  []SearchReturnOption{SearchReturnOptionCount, SearchReturnOptionPartial},
+		Partial: &PartialRange{First: 1, Last: 100},
+	}}
+
+	cmd, err := testParseCommand(`tag SEARCH RETURN (COUNT PARTIAL 100:1) UNSEEN`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_SearchReturnPartialInvalid(t *testing.T) {
+	_, err := testParseCommand(`tag SEARCH RETURN (PARTIAL) UNSEEN`)
+	require.Error(t, err)
+
+	_, err = testParseCommand(`tag SEARCH RETURN (PARTIAL -1:-100 ALL) UNSEEN`)
+	require.Error(t, err)
+}
+
+func TestParser_SearchSearchRes(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &Search{
+		Charset: "",
+		Keys: []SearchKey{
+			&SearchKeySeqSet{SeqSet: []SeqRange{{Begin: SeqNumValueSearchRes, End: SeqNumValueSearchRes}}},
+			&SearchKeyUID{SeqSet: []SeqRange{{Begin: SeqNumValueSearchRes, End: SeqNumValueSearchRes}}},
+		},
+	}}
+
+	cmd, err := testParseCommand(`tag SEARCH $ UID $`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
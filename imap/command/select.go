@@ -7,7 +7,9 @@ import (
 )
 
 type Select struct {
-	Mailbox string
+	Mailbox   string
+	CondStore bool
+	QResync   *QResync
 }
 
 func (l Select) String() string {
@@ -21,7 +23,7 @@ func (l Select) SanitizedString() string {
 type SelectCommandParser struct{}
 
 func (SelectCommandParser) FromParser(p *rfcparser.Parser) (Payload, error) {
-	// select          = "SELECT" SP mailbox
+	// select          = "SELECT" SP mailbox [select-params]
 	if err := p.Consume(rfcparser.TokenTypeSP, "expected space after command"); err != nil {
 		return nil, err
 	}
@@ -31,7 +33,164 @@ func (SelectCommandParser) FromParser(p *rfcparser.Parser) (Payload, error) {
 		return nil, err
 	}
 
+	params, err := parseSelectParams(p)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Select{
-		Mailbox: mailbox.Value,
+		Mailbox:   mailbox.Value,
+		CondStore: params.condStore,
+		QResync:   params.qResync,
 	}, nil
 }
+
+// QResync holds the QRESYNC select parameters as defined in RFC7162.
+type QResync struct {
+	UIDValidity uint32
+	ModSeq      uint64
+	// KnownUIDs is the optional set of UIDs the client knows about.
+	KnownUIDs []SeqRange
+	// KnownSeqSet and KnownUIDSet are the optional sequence match data. Both are either nil or of equal length.
+	KnownSeqSet []SeqRange
+	KnownUIDSet []SeqRange
+}
+
+type selectParams struct {
+	condStore bool
+	qResync   *QResync
+}
+
+func parseSelectParams(p *rfcparser.Parser) (selectParams, error) {
+	// select-params   = SP "(" select-param *(SP select-param) ")"
+	// select-param    = "CONDSTORE" / "QRESYNC" SP "(" qresync-params ")"
+	if ok, err := p.Matches(rfcparser.TokenTypeSP); err != nil {
+		return selectParams{}, err
+	} else if !ok {
+		return selectParams{}, nil
+	}
+
+	if err := p.Consume(rfcparser.TokenTypeLParen, "expected ( for select params start"); err != nil {
+		return selectParams{}, err
+	}
+
+	var params selectParams
+
+	for {
+		param, err := p.CollectBytesWhileMatches(rfcparser.TokenTypeChar)
+		if err != nil {
+			return selectParams{}, err
+		}
+
+		paramStr := param.IntoString().ToLower()
+
+		switch paramStr.Value {
+		case "condstore":
+			params.condStore = true
+		case "qresync":
+			qResync, err := parseQResyncParams(p)
+			if err != nil {
+				return selectParams{}, err
+			}
+
+			params.qResync = qResync
+		default:
+			return selectParams{}, p.MakeErrorAtOffset(fmt.Sprintf("unknown select param '%v'", paramStr.Value), paramStr.Offset)
+		}
+
+		if ok, err := p.Matches(rfcparser.TokenTypeSP); err != nil {
+			return selectParams{}, err
+		} else if !ok {
+			break
+		}
+	}
+
+	if err := p.Consume(rfcparser.TokenTypeRParen, "expected ) for select params end"); err != nil {
+		return selectParams{}, err
+	}
+
+	return params, nil
+}
+
+func parseQResyncParams(p *rfcparser.Parser) (*QResync, error) {
+	// qresync-params  = SP "(" uidvalidity SP mod-sequence-value [SP known-uids] [SP seq-match-data] ")"
+	// known-uids      = sequence-set
+	// seq-match-data  = "(" known-sequence-set SP known-uid-set ")"
+	if err := p.Consume(rfcparser.TokenTypeSP, "expected space after QRESYNC"); err != nil {
+		return nil, err
+	}
+
+	if err := p.Consume(rfcparser.TokenTypeLParen, "expected ( for QRESYNC params start"); err != nil {
+		return nil, err
+	}
+
+	uidValidity, err := ParseNZNumber(p)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.Consume(rfcparser.TokenTypeSP, "expected space after uidvalidity"); err != nil {
+		return nil, err
+	}
+
+	modSeq, err := ParseModSeqValue(p)
+	if err != nil {
+		return nil, err
+	}
+
+	qResync := &QResync{
+		UIDValidity: uint32(uidValidity),
+		ModSeq:      modSeq,
+	}
+
+	hasMore, err := p.Matches(rfcparser.TokenTypeSP)
+	if err != nil {
+		return nil, err
+	}
+
+	if hasMore && !p.Check(rfcparser.TokenTypeLParen) {
+		knownUIDs, err := ParseSeqSet(p)
+		if err != nil {
+			return nil, err
+		}
+
+		qResync.KnownUIDs = knownUIDs
+
+		if hasMore, err = p.Matches(rfcparser.TokenTypeSP); err != nil {
+			return nil, err
+		}
+	}
+
+	if hasMore {
+		if err := p.Consume(rfcparser.TokenTypeLParen, "expected ( for sequence match data start"); err != nil {
+			return nil, err
+		}
+
+		knownSeqSet, err := ParseSeqSet(p)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.Consume(rfcparser.TokenTypeSP, "expected space after known sequence set"); err != nil {
+			return nil, err
+		}
+
+		knownUIDSet, err := ParseSeqSet(p)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.Consume(rfcparser.TokenTypeRParen, "expected ) for sequence match data end"); err != nil {
+			return nil, err
+		}
+
+		qResync.KnownSeqSet = knownSeqSet
+		qResync.KnownUIDSet = knownUIDSet
+	}
+
+	if err := p.Consume(rfcparser.TokenTypeRParen, "expected ) for QRESYNC params end"); err != nil {
+		return nil, err
+	}
+
+	return qResync, nil
+}
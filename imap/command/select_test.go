@@ -23,3 +23,66 @@ func TestParser_SelectCommand(t *testing.T) {
 	require.Equal(t, "select", p.LastParsedCommand())
 	require.Equal(t, "tag", p.LastParsedTag())
 }
+
+func TestParser_SelectCommandCondStore(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &Select{
+		Mailbox:   "INBOX",
+		CondStore: true,
+	}}
+
+	cmd, err := testParseCommand(`tag SELECT INBOX (CONDSTORE)`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_SelectCommandUnknownParam(t *testing.T) {
+	_, err := testParseCommand(`tag SELECT INBOX (FOO)`)
+	require.Error(t, err)
+}
+
+func TestParser_SelectCommandQResync(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &Select{
+		Mailbox: "INBOX",
+		QResync: &QResync{
+			UIDValidity: 67890007,
+			ModSeq:      20050715194045000,
+			KnownUIDs:   []SeqRange{{Begin: 41, End: 211}},
+			KnownSeqSet: []SeqRange{{Begin: 1, End: 1}, {Begin: 5, End: 5}},
+			KnownUIDSet: []SeqRange{{Begin: 50, End: 50}, {Begin: 210, End: 210}},
+		},
+	}}
+
+	cmd, err := testParseCommand(`tag SELECT INBOX (QRESYNC (67890007 20050715194045000 41:211 (1,5 50,210)))`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_SelectCommandQResyncMinimal(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &Select{
+		Mailbox: "INBOX",
+		QResync: &QResync{
+			UIDValidity: 67890007,
+			ModSeq:      90060115194045000,
+		},
+	}}
+
+	cmd, err := testParseCommand(`tag SELECT INBOX (QRESYNC (67890007 90060115194045000))`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_SelectCommandQResyncSeqMatchOnly(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &Select{
+		Mailbox: "INBOX",
+		QResync: &QResync{
+			UIDValidity: 67890007,
+			ModSeq:      90060115194045000,
+			KnownSeqSet: []SeqRange{{Begin: 1, End: 3}},
+			KnownUIDSet: []SeqRange{{Begin: 1, End: 3}},
+		},
+	}}
+
+	cmd, err := testParseCommand(`tag SELECT INBOX (QRESYNC (67890007 90060115194045000 (1:3 1:3)))`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
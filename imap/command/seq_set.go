@@ -8,6 +8,9 @@ import (
 
 const SeqNumValueAsterisk = SeqNum(0)
 
+// SeqNumValueSearchRes marks the "$" sequence set which refers to the last saved search result (RFC5182).
+const SeqNumValueSearchRes = SeqNum(-1)
+
 type SeqNum int
 
 func (s SeqNum) IsAsterisk() bool {
@@ -19,9 +22,17 @@ func (s SeqNum) String() string {
 		return "*"
 	}
 
+	if s.IsSearchRes() {
+		return "$"
+	}
+
 	return fmt.Sprintf("%v", int(s))
 }
 
+func (s SeqNum) IsSearchRes() bool {
+	return s == SeqNumValueSearchRes
+}
+
 type SeqRange struct {
 	Begin SeqNum
 	End   SeqNum
@@ -85,8 +96,23 @@ func ParseSeqRange(p *rfcparser.Parser) (SeqRange, error) {
 	}, nil
 }
 
+// IsSearchResSeqSet returns whether the sequence set is "$", the reference to the last saved search result (RFC5182).
+func IsSearchResSeqSet(seqSet []SeqRange) bool {
+	return len(seqSet) == 1 && seqSet[0].Begin.IsSearchRes()
+}
+
 func ParseSeqSet(p *rfcparser.Parser) ([]SeqRange, error) {
 	// sequence-set    = (seq-number / seq-range) *("," sequence-set)
+	//
+	// RFC5182:
+	// sequence-set    =/ seq-last-command
+	// seq-last-command = "$"
+	if ok, err := p.Matches(rfcparser.TokenTypeDollar); err != nil {
+		return nil, err
+	} else if ok {
+		return []SeqRange{{Begin: SeqNumValueSearchRes, End: SeqNumValueSearchRes}}, nil
+	}
+
 	var result []SeqRange
 
 	{
@@ -0,0 +1,184 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ProtonMail/gluon/rfcparser"
+	"github.com/bradenaw/juniper/xslices"
+)
+
+type SortKey string
+
+const (
+	SortKeyArrival SortKey = "ARRIVAL"
+	SortKeyCC      SortKey = "CC"
+	SortKeyDate    SortKey = "DATE"
+	SortKeyFrom    SortKey = "FROM"
+	SortKeySize    SortKey = "SIZE"
+	SortKeySubject SortKey = "SUBJECT"
+	SortKeyTo      SortKey = "TO"
+)
+
+type SortCriterion struct {
+	Key     SortKey
+	Reverse bool
+}
+
+func (c SortCriterion) String() string {
+	if c.Reverse {
+		return fmt.Sprintf("REVERSE %v", c.Key)
+	}
+
+	return string(c.Key)
+}
+
+type Sort struct {
+	Criteria []SortCriterion
+	Charset  string
+	Keys     []SearchKey
+}
+
+func (s Sort) String() string {
+	return fmt.Sprintf("SORT %v CHARSET=%v %v", s.Criteria, s.Charset, s.Keys)
+}
+
+func (s Sort) SanitizedString() string {
+	return fmt.Sprintf("SORT %v CHARSET=%v %v", s.Criteria, s.Charset, xslices.Map(s.Keys, func(v SearchKey) string {
+		return v.SanitizedString()
+	}))
+}
+
+type SortCommandParser struct{}
+
+func (SortCommandParser) FromParser(p *rfcparser.Parser) (Payload, error) {
+	// sort            = ["UID" SP] "SORT" SP sort-criteria SP search-criteria
+	// sort-criteria   = "(" sort-criterion *(SP sort-criterion) ")"
+	if err := p.Consume(rfcparser.TokenTypeSP, "expected space after command"); err != nil {
+		return nil, err
+	}
+
+	if err := p.Consume(rfcparser.TokenTypeLParen, "expected ( for sort criteria"); err != nil {
+		return nil, err
+	}
+
+	var criteria []SortCriterion
+
+	for {
+		criterion, err := parseSortCriterion(p)
+		if err != nil {
+			return nil, err
+		}
+
+		criteria = append(criteria, criterion)
+
+		if ok, err := p.Matches(rfcparser.TokenTypeSP); err != nil {
+			return nil, err
+		} else if !ok {
+			break
+		}
+	}
+
+	if err := p.Consume(rfcparser.TokenTypeRParen, "expected ) for sort criteria end"); err != nil {
+		return nil, err
+	}
+
+	if err := p.Consume(rfcparser.TokenTypeSP, "expected space after sort criteria"); err != nil {
+		return nil, err
+	}
+
+	charset, keys, err := parseSearchCriteria(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Sort{
+		Criteria: criteria,
+		Charset:  charset,
+		Keys:     keys,
+	}, nil
+}
+
+func parseSortCriterion(p *rfcparser.Parser) (SortCriterion, error) {
+	// sort-criterion  = ["REVERSE" SP] sort-key
+	// sort-key        = "ARRIVAL" / "CC" / "DATE" / "FROM" / "SIZE" /
+	//                   "SUBJECT" / "TO"
+	var criterion SortCriterion
+
+	offset := p.CurrentToken().Offset
+
+	key, err := p.ParseAtom()
+	if err != nil {
+		return SortCriterion{}, err
+	}
+
+	if strings.EqualFold(key, "REVERSE") {
+		if err := p.Consume(rfcparser.TokenTypeSP, "expected space after REVERSE"); err != nil {
+			return SortCriterion{}, err
+		}
+
+		criterion.Reverse = true
+
+		offset = p.CurrentToken().Offset
+
+		if key, err = p.ParseAtom(); err != nil {
+			return SortCriterion{}, err
+		}
+	}
+
+	switch sortKey := SortKey(strings.ToUpper(key)); sortKey {
+	case SortKeyArrival, SortKeyCC, SortKeyDate, SortKeyFrom, SortKeySize, SortKeySubject, SortKeyTo:
+		criterion.Key = sortKey
+
+	default:
+		return SortCriterion{}, p.MakeErrorAtOffset(fmt.Sprintf("unknown sort key '%v'", key), offset)
+	}
+
+	return criterion, nil
+}
+
+// parseSearchCriteria parses the search criteria of the SORT and THREAD commands as defined in RFC5256.
+func parseSearchCriteria(p *rfcparser.Parser) (string, []SearchKey, error) {
+	// search-criteria = charset 1*(SP search-key)
+	// charset         = atom / quoted
+	var charset string
+
+	if p.Check(rfcparser.TokenTypeDQuote) {
+		quoted, err := p.ParseQuoted()
+		if err != nil {
+			return "", nil, err
+		}
+
+		charset = quoted.Value
+	} else {
+		atom, err := p.ParseAtom()
+		if err != nil {
+			return "", nil, err
+		}
+
+		charset = atom
+	}
+
+	var keys []SearchKey
+
+	for {
+		if ok, err := p.Matches(rfcparser.TokenTypeSP); err != nil {
+			return "", nil, err
+		} else if !ok {
+			break
+		}
+
+		key, err := parseSearchKey(p)
+		if err != nil {
+			return "", nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	if len(keys) == 0 {
+		return "", nil, p.MakeError("no search keys specified")
+	}
+
+	return charset, keys, nil
+}
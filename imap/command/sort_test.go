@@ -0,0 +1,50 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_SortCommand(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &Sort{
+		Criteria: []SortCriterion{
+			{Key: SortKeySubject},
+			{Key: SortKeyDate, Reverse: true},
+		},
+		Charset: "UTF-8",
+		Keys: []SearchKey{
+			&SearchKeyFrom{Value: "foo"},
+			&SearchKeySeen{},
+		},
+	}}
+
+	cmd, err := testParseCommand(`tag SORT (subject REVERSE DATE) UTF-8 FROM foo SEEN`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_SortCommandQuotedCharset(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &UID{Command: &Sort{
+		Criteria: []SortCriterion{{Key: SortKeyArrival}},
+		Charset:  "US-ASCII",
+		Keys:     []SearchKey{&SearchKeyAll{}},
+	}}}
+
+	cmd, err := testParseCommand(`tag UID SORT (ARRIVAL) "US-ASCII" ALL`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_SortCommandInvalid(t *testing.T) {
+	for _, input := range []string{
+		`tag SORT () UTF-8 ALL`,
+		`tag SORT (FOO) UTF-8 ALL`,
+		`tag SORT (REVERSE) UTF-8 ALL`,
+		`tag SORT (DATE) UTF-8`,
+		`tag SORT DATE UTF-8 ALL`,
+	} {
+		_, err := testParseCommand(input)
+		require.Error(t, err, input)
+	}
+}
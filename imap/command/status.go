@@ -15,6 +15,9 @@ const (
 	StatusAttributeUIDNext
 	StatusAttributeUIDValidity
 	StatusAttributeUnseen
+	StatusAttributeHighestModSeq
+	StatusAttributeSize
+	StatusAttributeMailboxID
 )
 
 func (s StatusAttribute) String() string {
@@ -29,6 +32,12 @@ func (s StatusAttribute) String() string {
 		return "UIDVALIDITY"
 	case StatusAttributeUnseen:
 		return "UNSEEN"
+	case StatusAttributeHighestModSeq:
+		return "HIGHESTMODSEQ"
+	case StatusAttributeSize:
+		return "SIZE"
+	case StatusAttributeMailboxID:
+		return "MAILBOXID"
 	default:
 		return "UNKNOWN"
 	}
@@ -67,6 +76,19 @@ func (StatusCommandParser) FromParser(p *rfcparser.Parser) (Payload, error) {
 		return nil, err
 	}
 
+	attributes, err := parseStatusAttributes(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Status{
+		Mailbox:    mailbox.Value,
+		Attributes: attributes,
+	}, nil
+}
+
+func parseStatusAttributes(p *rfcparser.Parser) ([]StatusAttribute, error) {
+	// "(" status-att *(SP status-att) ")"
 	if err := p.Consume(rfcparser.TokenTypeLParen, "expected ( for status attributes start"); err != nil {
 		return nil, err
 	}
@@ -103,15 +125,12 @@ func (StatusCommandParser) FromParser(p *rfcparser.Parser) (Payload, error) {
 		return nil, err
 	}
 
-	return &Status{
-		Mailbox:    mailbox.Value,
-		Attributes: attributes,
-	}, nil
+	return attributes, nil
 }
 
 func parseStatusAttribute(p *rfcparser.Parser) (StatusAttribute, error) {
 	//status-att      = "MESSAGES" / "RECENT" / "UIDNEXT" / "UIDVALIDITY" /
-	//                   "UNSEEN"
+	//                   "UNSEEN" / "HIGHESTMODSEQ" / "SIZE" / "MAILBOXID"
 	attribute, err := p.CollectBytesWhileMatches(rfcparser.TokenTypeChar)
 	if err != nil {
 		return 0, err
@@ -129,6 +148,12 @@ func parseStatusAttribute(p *rfcparser.Parser) (StatusAttribute, error) {
 		return StatusAttributeUIDValidity, nil
 	case "unseen":
 		return StatusAttributeUnseen, nil
+	case "highestmodseq":
+		return StatusAttributeHighestModSeq, nil
+	case "size":
+		return StatusAttributeSize, nil
+	case "mailboxid":
+		return StatusAttributeMailboxID, nil
 	default:
 		return 0, p.MakeErrorAtOffset(fmt.Sprintf("unknown status attribute '%v'", attributeStr), attributeStr.Offset)
 	}
@@ -79,3 +79,36 @@ func TestParser_StatusCommandMultiple(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, expected, cmd)
 }
+
+func TestParser_StatusCommandHighestModSeq(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &Status{
+		Mailbox:    "Foo",
+		Attributes: []StatusAttribute{StatusAttributeMessages, StatusAttributeHighestModSeq},
+	}}
+
+	cmd, err := testParseCommand(`tag STATUS Foo (MESSAGES HIGHESTMODSEQ)`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_StatusCommandSize(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &Status{
+		Mailbox:    "Foo",
+		Attributes: []StatusAttribute{StatusAttributeSize},
+	}}
+
+	cmd, err := testParseCommand(`tag STATUS Foo (SIZE)`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_StatusCommandMailboxID(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &Status{
+		Mailbox:    "Foo",
+		Attributes: []StatusAttribute{StatusAttributeMailboxID},
+	}}
+
+	cmd, err := testParseCommand(`tag STATUS Foo (MAILBOXID)`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
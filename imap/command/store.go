@@ -32,6 +32,8 @@ type Store struct {
 	Action StoreAction
 	Flags  []string
 	Silent bool
+	// UnchangedSince is the UNCHANGEDSINCE store modifier value (RFC7162). Nil when not present.
+	UnchangedSince *uint64
 }
 
 func (s Store) String() string {
@@ -40,7 +42,12 @@ func (s Store) String() string {
 		silentStr = ".SILENT"
 	}
 
-	return fmt.Sprintf("STORE %v %v%v %v", s.SeqSet, s.Action.String(), silentStr, s.Flags)
+	modifierStr := ""
+	if s.UnchangedSince != nil {
+		modifierStr = fmt.Sprintf(" (UNCHANGEDSINCE %v)", *s.UnchangedSince)
+	}
+
+	return fmt.Sprintf("STORE %v%v %v%v %v", s.SeqSet, modifierStr, s.Action.String(), silentStr, s.Flags)
 }
 
 func (s Store) SanitizedString() string {
@@ -51,7 +58,7 @@ type StoreCommandParser struct{}
 
 func (StoreCommandParser) FromParser(p *rfcparser.Parser) (Payload, error) {
 	//nolint:dupword
-	// store           = "STORE" SP sequence-set SP store-att-flags
+	// store           = "STORE" SP sequence-set [store-modifiers] SP store-att-flags
 	// store-att-flags = (["+" / "-"] "FLAGS" [".SILENT"]) SP
 	//                  (flag-list / (flag *(SP flag)))
 	if err := p.Consume(rfcparser.TokenTypeSP, "expected space after command"); err != nil {
@@ -67,6 +74,11 @@ func (StoreCommandParser) FromParser(p *rfcparser.Parser) (Payload, error) {
 		return nil, err
 	}
 
+	unchangedSince, err := parseStoreModifiers(p)
+	if err != nil {
+		return nil, err
+	}
+
 	var action StoreAction
 
 	if ok, err := p.Matches(rfcparser.TokenTypePlus); err != nil {
@@ -109,13 +121,67 @@ func (StoreCommandParser) FromParser(p *rfcparser.Parser) (Payload, error) {
 	}
 
 	return &Store{
-		SeqSet: seqSet,
-		Action: action,
-		Flags:  flags,
-		Silent: silent,
+		SeqSet:         seqSet,
+		Action:         action,
+		Flags:          flags,
+		Silent:         silent,
+		UnchangedSince: unchangedSince,
 	}, nil
 }
 
+func parseStoreModifiers(p *rfcparser.Parser) (*uint64, error) {
+	// store-modifiers      = "(" store-modifier *(SP store-modifier) ")" SP
+	// store-modifier       = "UNCHANGEDSINCE" SP mod-sequence-valzer
+	if ok, err := p.Matches(rfcparser.TokenTypeLParen); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, nil
+	}
+
+	var unchangedSince *uint64
+
+	for {
+		modifier, err := p.CollectBytesWhileMatches(rfcparser.TokenTypeChar)
+		if err != nil {
+			return nil, err
+		}
+
+		modifierStr := modifier.IntoString().ToLower()
+
+		switch modifierStr.Value {
+		case "unchangedsince":
+			if err := p.Consume(rfcparser.TokenTypeSP, "expected space after UNCHANGEDSINCE"); err != nil {
+				return nil, err
+			}
+
+			value, err := ParseModSeqValzer(p)
+			if err != nil {
+				return nil, err
+			}
+
+			unchangedSince = &value
+		default:
+			return nil, p.MakeErrorAtOffset(fmt.Sprintf("unknown store modifier '%v'", modifierStr.Value), modifierStr.Offset)
+		}
+
+		if ok, err := p.Matches(rfcparser.TokenTypeSP); err != nil {
+			return nil, err
+		} else if !ok {
+			break
+		}
+	}
+
+	if err := p.Consume(rfcparser.TokenTypeRParen, "expected ) for store modifiers end"); err != nil {
+		return nil, err
+	}
+
+	if err := p.Consume(rfcparser.TokenTypeSP, "expected space after store modifiers"); err != nil {
+		return nil, err
+	}
+
+	return unchangedSince, nil
+}
+
 func parseStoreFlags(p *rfcparser.Parser) ([]string, error) {
 	//                  (flag-list / (flag *(SP flag)))
 	fl, ok, err := TryParseFlagList(p)
@@ -109,3 +109,40 @@ func TestParser_StoreCommandMultipleFlagsWithParen(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, expected, cmd)
 }
+
+func TestParser_StoreCommandUnchangedSince(t *testing.T) {
+	unchangedSince := uint64(12121230045)
+
+	expected := Command{Tag: "tag", Payload: &Store{
+		SeqSet: []SeqRange{{
+			Begin: 1,
+			End:   1,
+		}},
+		Action:         StoreActionAddFlags,
+		Flags:          []string{`\Deleted`},
+		Silent:         true,
+		UnchangedSince: &unchangedSince,
+	}}
+
+	cmd, err := testParseCommand(`tag STORE 1 (UNCHANGEDSINCE 12121230045) +FLAGS.SILENT (\Deleted)`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_StoreCommandUnchangedSinceZero(t *testing.T) {
+	unchangedSince := uint64(0)
+
+	expected := Command{Tag: "tag", Payload: &Store{
+		SeqSet: []SeqRange{{
+			Begin: 1,
+			End:   1,
+		}},
+		Action:         StoreActionSetFlags,
+		Flags:          []string{`\Seen`},
+		UnchangedSince: &unchangedSince,
+	}}
+
+	cmd, err := testParseCommand(`tag STORE 1 (UNCHANGEDSINCE 0) FLAGS \Seen`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
@@ -0,0 +1,74 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ProtonMail/gluon/rfcparser"
+	"github.com/bradenaw/juniper/xslices"
+)
+
+type ThreadAlgorithm string
+
+const (
+	ThreadAlgorithmOrderedSubject ThreadAlgorithm = "ORDEREDSUBJECT"
+	ThreadAlgorithmReferences     ThreadAlgorithm = "REFERENCES"
+)
+
+type Thread struct {
+	Algorithm ThreadAlgorithm
+	Charset   string
+	Keys      []SearchKey
+}
+
+func (t Thread) String() string {
+	return fmt.Sprintf("THREAD %v CHARSET=%v %v", t.Algorithm, t.Charset, t.Keys)
+}
+
+func (t Thread) SanitizedString() string {
+	return fmt.Sprintf("THREAD %v CHARSET=%v %v", t.Algorithm, t.Charset, xslices.Map(t.Keys, func(v SearchKey) string {
+		return v.SanitizedString()
+	}))
+}
+
+type ThreadCommandParser struct{}
+
+func (ThreadCommandParser) FromParser(p *rfcparser.Parser) (Payload, error) {
+	// thread          = ["UID" SP] "THREAD" SP thread-alg SP search-criteria
+	// thread-alg      = "ORDEREDSUBJECT" / "REFERENCES" / thread-alg-ext
+	if err := p.Consume(rfcparser.TokenTypeSP, "expected space after command"); err != nil {
+		return nil, err
+	}
+
+	offset := p.CurrentToken().Offset
+
+	algorithm, err := p.ParseAtom()
+	if err != nil {
+		return nil, err
+	}
+
+	var threadAlgorithm ThreadAlgorithm
+
+	switch alg := ThreadAlgorithm(strings.ToUpper(algorithm)); alg {
+	case ThreadAlgorithmOrderedSubject, ThreadAlgorithmReferences:
+		threadAlgorithm = alg
+
+	default:
+		return nil, p.MakeErrorAtOffset(fmt.Sprintf("unknown thread algorithm '%v'", algorithm), offset)
+	}
+
+	if err := p.Consume(rfcparser.TokenTypeSP, "expected space after thread algorithm"); err != nil {
+		return nil, err
+	}
+
+	charset, keys, err := parseSearchCriteria(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Thread{
+		Algorithm: threadAlgorithm,
+		Charset:   charset,
+		Keys:      keys,
+	}, nil
+}
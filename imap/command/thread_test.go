@@ -0,0 +1,46 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_ThreadCommand(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &Thread{
+		Algorithm: ThreadAlgorithmReferences,
+		Charset:   "UTF-8",
+		Keys: []SearchKey{
+			&SearchKeyUnseen{},
+		},
+	}}
+
+	cmd, err := testParseCommand(`tag THREAD references UTF-8 UNSEEN`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_UIDThreadCommand(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &UID{Command: &Thread{
+		Algorithm: ThreadAlgorithmOrderedSubject,
+		Charset:   "US-ASCII",
+		Keys: []SearchKey{
+			&SearchKeyAll{},
+		},
+	}}}
+
+	cmd, err := testParseCommand(`tag UID THREAD ORDEREDSUBJECT US-ASCII ALL`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParser_ThreadCommandInvalid(t *testing.T) {
+	for _, input := range []string{
+		`tag THREAD FOO UTF-8 ALL`,
+		`tag THREAD REFERENCES UTF-8`,
+		`tag THREAD REFERENCES`,
+	} {
+		_, err := testParseCommand(input)
+		require.Error(t, err, input)
+	}
+}
@@ -25,16 +25,19 @@ type UIDCommandParser struct {
 func NewUIDCommandParser() *UIDCommandParser {
 	return &UIDCommandParser{
 		commands: map[string]Builder{
-			"copy":   &CopyCommandParser{},
-			"fetch":  &FetchCommandParser{},
-			"search": &SearchCommandParser{},
-			"move":   &MoveCommandParser{},
-			"store":  &StoreCommandParser{},
+			"copy":    &CopyCommandParser{},
+			"fetch":   &FetchCommandParser{},
+			"search":  &SearchCommandParser{},
+			"move":    &MoveCommandParser{},
+			"store":   &StoreCommandParser{},
+			"sort":    &SortCommandParser{},
+			"thread":  &ThreadCommandParser{},
+			"replace": &ReplaceCommandParser{},
 		}}
 }
 
 func (u *UIDCommandParser) FromParser(p *rfcparser.Parser) (Payload, error) {
-	// uid             = "UID" SP (copy / fetch / search / store)
+	// uid             = "UID" SP (copy / fetch / search / store / sort / thread / replace)
 	// uidExpunge      = "UID" SP "EXPUNGE"
 	if err := p.Consume(rfcparser.TokenTypeSP, "expected space after command"); err != nil {
 		return nil, err
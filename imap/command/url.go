@@ -0,0 +1,313 @@
+package command
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/gluon/imap"
+	"github.com/ProtonMail/gluon/rfcparser"
+)
+
+// URL is an IMAP URL (RFC5092) pointing at a message or at a part of it, as used by CATENATE (RFC4469) and URLAUTH
+// (RFC4467). Only URLs which point at a message are supported.
+type URL struct {
+	// User and Host are empty for URLs which are relative to the server.
+	User, Host string
+
+	Mailbox     string
+	UIDValidity imap.UID
+	UID         imap.UID
+
+	// Section is nil if the URL points at the whole message.
+	Section BodySection
+	Partial *BodySectionPartial
+
+	// Expire is zero if the URL doesn't expire.
+	Expire time.Time
+
+	// Access is the URLAUTH access identifier, e.g. "anonymous" or "user+fred". It is empty if the URL is not
+	// authorized.
+	Access string
+
+	// Mechanism and Token are empty if the URL is only a URLAUTH rump URL, as given to GENURLAUTH.
+	Mechanism, Token string
+
+	// Rump is the URL without the mechanism and token; it is what the token authorizes.
+	Rump string
+}
+
+// IsAbsolute returns whether the URL names the server, which is required for URLAUTH.
+func (u *URL) IsAbsolute() bool {
+	return u.Host != ""
+}
+
+// ParseURL parses an absolute IMAP URL, or one relative to the server (starting with the mailbox path).
+func ParseURL(raw string) (*URL, error) {
+	// imapurl         = "imap://" iserver ipath-query
+	// iserver         = [iuserinfo "@"] host [":" port]
+	// ipath-query     = ["/" [ icommand ]]
+	// imessagepart    = imailbox-ref iuid [isection] [ipartial] [iurlauth]
+	// imailbox-ref    = enc-mailbox [uidvalidity]
+	// uidvalidity     = ";UIDVALIDITY=" nz-number
+	// iuid            = "/" ";UID=" nz-number
+	// isection        = "/" ";SECTION=" enc-section
+	// ipartial        = "/" ";PARTIAL=" partial-range
+	// iurlauth        = iurlauth-rump iua-verifier
+	// iurlauth-rump   = [expire] ";URLAUTH=" access
+	// expire          = ";EXPIRE=" date-time
+	// iua-verifier    = ":" uauth-mechanism ":" enc-urlauth
+	u := &URL{Rump: raw}
+
+	path := raw
+
+	if len(path) >= len("imap://") && strings.EqualFold(path[:len("imap://")], "imap://") {
+		path = path[len("imap://"):]
+
+		idx := strings.IndexByte(path, '/')
+		if idx < 0 {
+			return nil, fmt.Errorf("URL doesn't point at a message")
+		}
+
+		server := path[:idx]
+		path = path[idx:]
+
+		if at := strings.LastIndexByte(server, '@'); at >= 0 {
+			user, err := url.PathUnescape(cutFold(server[:at], ";AUTH="))
+			if err != nil {
+				return nil, err
+			}
+
+			u.User, server = user, server[at+1:]
+		}
+
+		if server == "" {
+			return nil, fmt.Errorf("URL has no host")
+		}
+
+		u.Host = server
+	}
+
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("URL must be absolute or relative to the server")
+	}
+
+	path, err := u.parseURLAuth(path)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := indexFold(path, "/;UID=")
+	if idx < 0 {
+		return nil, fmt.Errorf("URL doesn't point at a message")
+	}
+
+	if idx == 0 {
+		return nil, fmt.Errorf("URL has no mailbox")
+	}
+
+	mailboxRef := path[1:idx]
+
+	if idx := indexFold(mailboxRef, ";UIDVALIDITY="); idx >= 0 {
+		uidValidity, err := parseURLNumber(mailboxRef[idx+len(";UIDVALIDITY="):])
+		if err != nil {
+			return nil, err
+		}
+
+		u.UIDValidity, mailboxRef = imap.UID(uidValidity), mailboxRef[:idx]
+	}
+
+	mailbox, err := url.PathUnescape(mailboxRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if mailbox == "" {
+		return nil, fmt.Errorf("URL has no mailbox")
+	}
+
+	if strings.EqualFold(mailbox, imap.Inbox) {
+		mailbox = imap.Inbox
+	}
+
+	u.Mailbox = mailbox
+
+	for _, part := range strings.Split(path[idx+1:], "/") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid URL part '%v'", part)
+		}
+
+		value, err := url.PathUnescape(value)
+		if err != nil {
+			return nil, err
+		}
+
+		switch strings.ToUpper(name) {
+		case ";UID":
+			uid, err := parseURLNumber(value)
+			if err != nil {
+				return nil, err
+			}
+
+			u.UID = imap.UID(uid)
+
+		case ";SECTION":
+			section, err := parseURLSection(value)
+			if err != nil {
+				return nil, err
+			}
+
+			u.Section = section
+
+		case ";PARTIAL":
+			partial, err := parseURLPartial(value)
+			if err != nil {
+				return nil, err
+			}
+
+			u.Partial = partial
+
+		default:
+			return nil, fmt.Errorf("unknown URL part '%v'", name)
+		}
+	}
+
+	return u, nil
+}
+
+// parseURLAuth parses the URLAUTH components of the URL and returns the URL without them.
+func (u *URL) parseURLAuth(path string) (string, error) {
+	idx := indexFold(path, ";URLAUTH=")
+	if idx < 0 {
+		return path, nil
+	}
+
+	access := path[idx+len(";URLAUTH="):]
+
+	if access, verifier, ok := strings.Cut(access, ":"); ok {
+		mechanism, token, ok := strings.Cut(verifier, ":")
+		if !ok || mechanism == "" || len(token) < 32 {
+			return "", fmt.Errorf("invalid URLAUTH verifier")
+		}
+
+		u.Access, u.Mechanism, u.Token = access, mechanism, token
+		u.Rump = u.Rump[:len(u.Rump)-len(verifier)-1]
+	} else {
+		u.Access = access
+	}
+
+	if u.Access == "" {
+		return "", fmt.Errorf("missing URLAUTH access identifier")
+	}
+
+	path = path[:idx]
+
+	if idx := indexFold(path, ";EXPIRE="); idx >= 0 {
+		expire, err := time.Parse(time.RFC3339, path[idx+len(";EXPIRE="):])
+		if err != nil {
+			return "", err
+		}
+
+		u.Expire, path = expire, path[:idx]
+	}
+
+	return path, nil
+}
+
+func parseURLNumber(value string) (uint32, error) {
+	n, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+
+	if n == 0 {
+		return 0, fmt.Errorf("expected non-zero number")
+	}
+
+	return uint32(n), nil
+}
+
+func parseURLSection(value string) (BodySection, error) {
+	p := rfcparser.NewParser(rfcparser.NewScanner(bytes.NewReader([]byte(value))))
+
+	if err := p.Advance(); err != nil {
+		return nil, err
+	}
+
+	section, err := parseSectionSpec(p)
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.Check(rfcparser.TokenTypeEOF) {
+		return nil, p.MakeError("unexpected data after section")
+	}
+
+	return section, nil
+}
+
+func parseURLPartial(value string) (*BodySectionPartial, error) {
+	// partial-range   = number ["." nz-number]
+	offset, count, hasCount := strings.Cut(value, ".")
+
+	o, err := strconv.ParseUint(offset, 10, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	partial := &BodySectionPartial{Offset: int64(o), Count: -1}
+
+	if hasCount {
+		c, err := parseURLNumber(count)
+		if err != nil {
+			return nil, err
+		}
+
+		partial.Count = int64(c)
+	}
+
+	return partial, nil
+}
+
+// indexFold returns the index of the first case-insensitive occurrence of the ASCII keyword substr in s, or -1.
+// Only ASCII letters are folded so that the index is valid in s.
+func indexFold(s, substr string) int {
+	for i := 0; i+len(substr) <= len(s); i++ {
+		if equalFoldASCII(s[i:i+len(substr)], substr) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func equalFoldASCII(a, b string) bool {
+	for i := 0; i < len(a); i++ {
+		if toUpperASCII(a[i]) != toUpperASCII(b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func toUpperASCII(c byte) byte {
+	if 'a' <= c && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+
+	return c
+}
+
+// cutFold returns s up to the first case-insensitive occurrence of sep, or s if there is none.
+func cutFold(s, sep string) string {
+	if idx := indexFold(s, sep); idx >= 0 {
+		return s[:idx]
+	}
+
+	return s
+}
@@ -0,0 +1,164 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/ProtonMail/gluon/rfcparser"
+)
+
+// URLAuthMechanismInternal is the only URLAUTH (RFC4467) authorization mechanism, where the server signs the URLs
+// with a key of its own.
+const URLAuthMechanismInternal = "INTERNAL"
+
+// ResetKey resets the URLAUTH access keys of a mailbox, or of all the mailboxes if it is empty.
+type ResetKey struct {
+	Mailbox    string
+	Mechanisms []string
+}
+
+func (l ResetKey) String() string {
+	return fmt.Sprintf("RESETKEY '%v' Mechanisms=%v", l.Mailbox, l.Mechanisms)
+}
+
+func (l ResetKey) SanitizedString() string {
+	return fmt.Sprintf("RESETKEY '%v' Mechanisms=%v", sanitizeString(l.Mailbox), l.Mechanisms)
+}
+
+type ResetKeyCommandParser struct{}
+
+func (ResetKeyCommandParser) FromParser(p *rfcparser.Parser) (Payload, error) {
+	// resetkey        = "RESETKEY" [SP mailbox *(SP mechanism)]
+	cmd := &ResetKey{}
+
+	if ok, err := p.Matches(rfcparser.TokenTypeSP); err != nil {
+		return nil, err
+	} else if !ok {
+		return cmd, nil
+	}
+
+	mailbox, err := ParseMailbox(p)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd.Mailbox = mailbox.Value
+
+	for {
+		if ok, err := p.Matches(rfcparser.TokenTypeSP); err != nil {
+			return nil, err
+		} else if !ok {
+			break
+		}
+
+		mechanism, err := parseURLAuthMechanism(p)
+		if err != nil {
+			return nil, err
+		}
+
+		cmd.Mechanisms = append(cmd.Mechanisms, mechanism)
+	}
+
+	return cmd, nil
+}
+
+type GenURLAuthURL struct {
+	URL       string
+	Mechanism string
+}
+
+// GenURLAuth requests authorized URLs (RFC4467) from the given URLAUTH rump URLs.
+type GenURLAuth struct {
+	URLs []GenURLAuthURL
+}
+
+func (l GenURLAuth) String() string {
+	return fmt.Sprintf("GENURLAUTH %v", l.URLs)
+}
+
+func (l GenURLAuth) SanitizedString() string {
+	return fmt.Sprintf("GENURLAUTH %v", len(l.URLs))
+}
+
+type GenURLAuthCommandParser struct{}
+
+func (GenURLAuthCommandParser) FromParser(p *rfcparser.Parser) (Payload, error) {
+	// genurlauth      = "GENURLAUTH" 1*(SP url-rump SP mechanism)
+	cmd := &GenURLAuth{}
+
+	for {
+		if err := p.Consume(rfcparser.TokenTypeSP, "expected space before URL"); err != nil {
+			return nil, err
+		}
+
+		url, err := p.ParseAString()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.Consume(rfcparser.TokenTypeSP, "expected space after URL"); err != nil {
+			return nil, err
+		}
+
+		mechanism, err := parseURLAuthMechanism(p)
+		if err != nil {
+			return nil, err
+		}
+
+		cmd.URLs = append(cmd.URLs, GenURLAuthURL{URL: url.Value, Mechanism: mechanism})
+
+		if !p.Check(rfcparser.TokenTypeSP) {
+			break
+		}
+	}
+
+	return cmd, nil
+}
+
+// URLFetch fetches the content pointed at by the given authorized URLs (RFC4467).
+type URLFetch struct {
+	URLs []string
+}
+
+func (l URLFetch) String() string {
+	return fmt.Sprintf("URLFETCH %v", l.URLs)
+}
+
+func (l URLFetch) SanitizedString() string {
+	return fmt.Sprintf("URLFETCH %v", len(l.URLs))
+}
+
+type URLFetchCommandParser struct{}
+
+func (URLFetchCommandParser) FromParser(p *rfcparser.Parser) (Payload, error) {
+	// urlfetch        = "URLFETCH" 1*(SP url-full)
+	cmd := &URLFetch{}
+
+	for {
+		if err := p.Consume(rfcparser.TokenTypeSP, "expected space before URL"); err != nil {
+			return nil, err
+		}
+
+		url, err := p.ParseAString()
+		if err != nil {
+			return nil, err
+		}
+
+		cmd.URLs = append(cmd.URLs, url.Value)
+
+		if !p.Check(rfcparser.TokenTypeSP) {
+			break
+		}
+	}
+
+	return cmd, nil
+}
+
+func parseURLAuthMechanism(p *rfcparser.Parser) (string, error) {
+	// mechanism       = "INTERNAL" / 1*(ALPHA / DIGIT / "-" / ".")
+	mechanism, err := p.ParseAtom()
+	if err != nil {
+		return "", err
+	}
+
+	return mechanism, nil
+}
@@ -0,0 +1,126 @@
+package command
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/gluon/imap"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_ResetKey(t *testing.T) {
+	cmd, err := testParseCommand(`tag RESETKEY`)
+	require.NoError(t, err)
+	require.Equal(t, Command{Tag: "tag", Payload: &ResetKey{}}, cmd)
+
+	cmd, err = testParseCommand(`tag RESETKEY inbox INTERNAL`)
+	require.NoError(t, err)
+	require.Equal(t, Command{Tag: "tag", Payload: &ResetKey{Mailbox: "INBOX", Mechanisms: []string{"INTERNAL"}}}, cmd)
+}
+
+func TestParser_GenURLAuth(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &GenURLAuth{URLs: []GenURLAuthURL{
+		{URL: "imap://joe@example.com/INBOX/;uid=20/;section=1.2;urlauth=submit+fred", Mechanism: "INTERNAL"},
+		{URL: "imap://joe@example.com/Sent%20Items/;uid=3;urlauth=anonymous", Mechanism: "INTERNAL"},
+	}}}
+
+	cmd, err := testParseCommand(`tag GENURLAUTH imap://joe@example.com/INBOX/;uid=20/;section=1.2;urlauth=submit+fred INTERNAL "imap://joe@example.com/Sent%20Items/;uid=3;urlauth=anonymous" INTERNAL`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+
+	_, err = testParseCommand(`tag GENURLAUTH imap://joe@example.com/INBOX/;uid=20;urlauth=anonymous`)
+	require.Error(t, err)
+}
+
+func TestParser_URLFetch(t *testing.T) {
+	expected := Command{Tag: "tag", Payload: &URLFetch{URLs: []string{
+		"imap://joe@example.com/INBOX/;uid=20;urlauth=anonymous:internal:91354a473744909de610943775f92038",
+		"imap://joe@example.com/INBOX/;uid=21",
+	}}}
+
+	cmd, err := testParseCommand(`tag URLFETCH imap://joe@example.com/INBOX/;uid=20;urlauth=anonymous:internal:91354a473744909de610943775f92038 imap://joe@example.com/INBOX/;uid=21`)
+	require.NoError(t, err)
+	require.Equal(t, expected, cmd)
+}
+
+func TestParseURL(t *testing.T) {
+	u, err := ParseURL("imap://joe;AUTH=*@example.com/inbox;UIDVALIDITY=385759045/;UID=20/;SECTION=1.2/;PARTIAL=10.20")
+	require.NoError(t, err)
+	require.Equal(t, "joe", u.User)
+	require.Equal(t, "example.com", u.Host)
+	require.Equal(t, imap.Inbox, u.Mailbox)
+	require.Equal(t, imap.UID(385759045), u.UIDValidity)
+	require.Equal(t, imap.UID(20), u.UID)
+	require.Equal(t, &BodySectionPart{Part: []int{1, 2}}, u.Section)
+	require.Equal(t, &BodySectionPartial{Offset: 10, Count: 20}, u.Partial)
+	require.Empty(t, u.Access)
+	require.True(t, u.IsAbsolute())
+
+	u, err = ParseURL("/Sent%20Items/;uid=3/;section=HEADER")
+	require.NoError(t, err)
+	require.Equal(t, "Sent Items", u.Mailbox)
+	require.Equal(t, imap.UID(3), u.UID)
+	require.Equal(t, &BodySectionHeader{}, u.Section)
+	require.False(t, u.IsAbsolute())
+}
+
+func TestParseURLAuth(t *testing.T) {
+	const rump = "imap://joe@example.com/Foo/Bar/;uid=20;expire=2030-01-02T03:04:05Z;urlauth=user+fred"
+
+	u, err := ParseURL(rump + ":internal:91354a473744909de610943775f92038")
+	require.NoError(t, err)
+	require.Equal(t, "Foo/Bar", u.Mailbox)
+	require.Equal(t, imap.UID(20), u.UID)
+	require.Equal(t, time.Date(2030, time.January, 2, 3, 4, 5, 0, time.UTC), u.Expire)
+	require.Equal(t, "user+fred", u.Access)
+	require.Equal(t, "internal", u.Mechanism)
+	require.Equal(t, "91354a473744909de610943775f92038", u.Token)
+	require.Equal(t, rump, u.Rump)
+
+	u, err = ParseURL(rump)
+	require.NoError(t, err)
+	require.Equal(t, "user+fred", u.Access)
+	require.Empty(t, u.Token)
+	require.Equal(t, rump, u.Rump)
+}
+
+func TestParseURLInvalid(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"imap://example.com",
+		"imap://example.com/INBOX",
+		"INBOX/;UID=1",
+		"/;UID=1",
+		"/INBOX/;UID=0",
+		"/INBOX/;UID=1/;SECTION=FOO",
+		"/INBOX/;UID=1/;FOO=1",
+		"/INBOX/;UID=1;URLAUTH=anonymous:internal:1234",
+		"/INBOX/;UID=1;EXPIRE=tomorrow;URLAUTH=anonymous",
+	} {
+		_, err := ParseURL(input)
+		require.Error(t, err, input)
+	}
+}
+
+func TestParseURLNonASCII(t *testing.T) {
+	// Upper-casing these runes changes their length, which must not affect the offsets of the URL parts.
+	u, err := ParseURL("/ɐɐɐɐɐɐɐɐɐɐ/;UID=1")
+	require.NoError(t, err)
+	require.Equal(t, "ɐɐɐɐɐɐɐɐɐɐ", u.Mailbox)
+	require.Equal(t, imap.UID(1), u.UID)
+
+	u, err = ParseURL("imap://ıı;auth=*@example.com/ſ;uidvalidity=2/;uid=3")
+	require.NoError(t, err)
+	require.Equal(t, "ıı", u.User)
+	require.Equal(t, "ſ", u.Mailbox)
+	require.Equal(t, imap.UID(2), u.UIDValidity)
+}
+
+func FuzzParseURL(f *testing.F) {
+	f.Add("imap://joe;AUTH=*@example.com/inbox;UIDVALIDITY=385759045/;UID=20/;SECTION=1.2/;PARTIAL=10.20")
+	f.Add("/ɐɐɐɐɐɐɐɐɐɐ/;UID=1;URLAUTH=anonymous")
+
+	f.Fuzz(func(t *testing.T, input string) {
+		_, _ = ParseURL(input)
+	})
+}
@@ -8,6 +8,10 @@ type Message struct {
 	ID    MessageID
 	Flags FlagSet
 	Date  time.Time
+
+	// ThreadID optionally identifies the thread of the message, reported as its THREADID (RFC8474).
+	// It is empty if the connector doesn't group messages into threads.
+	ThreadID string
 }
 
 type Header []Field
@@ -0,0 +1,20 @@
+package imap
+
+import "strings"
+
+const (
+	MetadataPrivatePrefix = "/private/"
+	MetadataSharedPrefix  = "/shared/"
+)
+
+// MetadataEntry is an annotation of a mailbox or of the server as defined in RFC5464. Entry names are
+// case-insensitive and kept in lower case. A nil value marks an entry to be removed.
+type MetadataEntry struct {
+	Name  string
+	Value []byte
+}
+
+// IsSharedMetadataEntry returns whether the entry with the given name is shared by all users rather than private.
+func IsSharedMetadataEntry(name string) bool {
+	return strings.HasPrefix(name, MetadataSharedPrefix)
+}
@@ -0,0 +1,19 @@
+package imap
+
+// Namespace is a mailbox namespace as defined in RFC2342. It is identified by the name prefix shared by all the
+// mailboxes it contains; an empty prefix denotes the root of the mailbox hierarchy.
+type Namespace struct {
+	Prefix []string
+}
+
+// Namespaces lists the personal, other users' and shared namespaces exposed to clients.
+type Namespaces struct {
+	Personal   []Namespace
+	OtherUsers []Namespace
+	Shared     []Namespace
+}
+
+// DefaultNamespaces returns a single personal namespace rooted at the top of the mailbox hierarchy.
+func DefaultNamespaces() Namespaces {
+	return Namespaces{Personal: []Namespace{{}}}
+}
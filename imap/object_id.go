@@ -0,0 +1,41 @@
+package imap
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// maxObjectIDLength is the maximum length of object identifiers (RFC8474).
+const maxObjectIDLength = 255
+
+// ObjectID returns the given remote ID as an object identifier (RFC8474), which may only contain letters, digits, "-"
+// and "_". Other characters, including "_" itself, are escaped as "_" followed by their two-digit hex value, so that
+// distinct IDs always map to distinct object identifiers.
+//
+// IDs which would be longer than 255 characters once escaped are replaced by "__" followed by their SHA-256 hash.
+// Escaped IDs never contain "__", so these can't collide with them.
+func ObjectID(id string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(id); i++ {
+		if c := id[i]; isObjectIDChar(c) {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "_%02X", c)
+		}
+	}
+
+	if b.Len() > maxObjectIDLength {
+		hash := sha256.Sum256([]byte(id))
+
+		return "__" + hex.EncodeToString(hash[:])
+	}
+
+	return b.String()
+}
+
+func isObjectIDChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-'
+}
@@ -0,0 +1,22 @@
+package imap
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectID(t *testing.T) {
+	require.Equal(t, "0", ObjectID("0"))
+	require.Equal(t, "msg-9c5a2c6e-6d0a-4d2b-8f3e-1d2e3f4a5b6c", ObjectID("msg-9c5a2c6e-6d0a-4d2b-8f3e-1d2e3f4a5b6c"))
+	require.Equal(t, "g_2By1Ib8yD2dyh_2Fi5I4C_3D_3D", ObjectID("g+y1Ib8yD2dyh/i5I4C=="))
+	require.Equal(t, "a_5Fb", ObjectID("a_b"))
+	require.NotEqual(t, ObjectID("a_2Bb"), ObjectID("a+b"))
+
+	// Object IDs are at most 255 characters long.
+	require.Equal(t, strings.Repeat("a", 255), ObjectID(strings.Repeat("a", 255)))
+	require.Len(t, ObjectID(strings.Repeat("a", 256)), 66)
+	require.Len(t, ObjectID(strings.Repeat("+", 100)), 66)
+	require.NotEqual(t, ObjectID(strings.Repeat("a", 256)), ObjectID(strings.Repeat("a", 257)))
+}
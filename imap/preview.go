@@ -0,0 +1,205 @@
+package imap
+
+import (
+	"bytes"
+	"errors"
+	"html"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ProtonMail/gluon/rfc5322"
+	"github.com/ProtonMail/gluon/rfc822"
+)
+
+// MaxPreviewLength is the maximum number of characters of a message preview (RFC8970).
+const MaxPreviewLength = 256
+
+// Preview returns a short plain-text snippet of the message (RFC8970), taken from its first text part which is not an
+// attachment. Plain text parts are preferred over HTML ones. The preview is empty if the message has no such part.
+func Preview(root *rfc822.Section) (string, error) {
+	section, mimeType, err := findPreviewSection(root, "")
+	if err != nil || section == nil {
+		return "", err
+	}
+
+	body, err := section.DecodedBody()
+	if err != nil {
+		if errors.Is(err, rfc822.ErrUnknownEncoding) {
+			return "", nil
+		}
+
+		return "", err
+	}
+
+	text := decodePreviewCharset(section, body)
+
+	if mimeType == rfc822.TextHTML {
+		text = stripHTML(text)
+	}
+
+	return TruncatePreview(strings.Join(strings.Fields(text), " ")), nil
+}
+
+// findPreviewSection returns the first text/plain section of the message, or its first text/html section if there is
+// none. Attachments and attached messages are skipped.
+func findPreviewSection(section *rfc822.Section, parentType rfc822.MIMEType) (*rfc822.Section, rfc822.MIMEType, error) {
+	header, err := section.ParseHeader()
+	if err != nil {
+		return nil, "", err
+	}
+
+	if disp, _, err := rfc822.ParseMediaType(header.Get("Content-Disposition")); err == nil && strings.EqualFold(disp, "attachment") {
+		return nil, "", nil
+	}
+
+	mimeType, _, err := section.ContentType()
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Parts without a content type are plain text, unless they are part of a multipart/digest.
+	if mimeType == "" && parentType != "multipart/digest" {
+		mimeType = rfc822.TextPlain
+	}
+
+	switch {
+	case mimeType == rfc822.TextPlain, mimeType == rfc822.TextHTML:
+		return section, mimeType, nil
+
+	case mimeType.IsMultiPart():
+		children, err := section.Children()
+		if err != nil {
+			return nil, "", err
+		}
+
+		var (
+			htmlSection *rfc822.Section
+			htmlType    rfc822.MIMEType
+		)
+
+		for _, child := range children {
+			childSection, childType, err := findPreviewSection(child, mimeType)
+			if err != nil {
+				return nil, "", err
+			}
+
+			if childType == rfc822.TextPlain {
+				return childSection, childType, nil
+			}
+
+			if childType == rfc822.TextHTML && htmlSection == nil {
+				htmlSection, htmlType = childSection, childType
+			}
+		}
+
+		return htmlSection, htmlType, nil
+
+	default:
+		return nil, "", nil
+	}
+}
+
+// decodePreviewCharset converts the body to UTF-8 according to the charset of its section. Invalid UTF-8 sequences
+// are dropped if the charset can't be converted.
+func decodePreviewCharset(section *rfc822.Section, body []byte) string {
+	if _, params, err := section.ContentType(); err == nil && rfc5322.CharsetReader != nil {
+		if charset := strings.ToLower(params["charset"]); charset != "" && charset != "utf-8" && charset != "us-ascii" {
+			if r, err := rfc5322.CharsetReader(charset, bytes.NewReader(body)); err == nil {
+				if decoded, err := io.ReadAll(r); err == nil {
+					body = decoded
+				}
+			}
+		}
+	}
+
+	return strings.ToValidUTF8(string(body), "")
+}
+
+// stripHTML returns the text content of the HTML document, without comments and the content of its head, style and
+// script elements.
+func stripHTML(doc string) string {
+	var (
+		b    strings.Builder
+		skip string
+	)
+
+	for {
+		start := strings.IndexByte(doc, '<')
+		if start < 0 {
+			if skip == "" {
+				b.WriteString(doc)
+			}
+
+			break
+		}
+
+		if skip == "" {
+			b.WriteString(doc[:start])
+		}
+
+		doc = doc[start:]
+
+		closing := ">"
+
+		if strings.HasPrefix(doc, "<!--") {
+			closing = "-->"
+		}
+
+		end := strings.Index(doc, closing)
+		if end < 0 {
+			break
+		}
+
+		tag := strings.ToLower(doc[1:end])
+		doc = doc[end+len(closing):]
+
+		name, isEnd := getHTMLTagName(tag)
+
+		switch {
+		case skip != "":
+			if isEnd && name == skip {
+				skip = ""
+			}
+
+		case !isEnd && (name == "head" || name == "style" || name == "script"):
+			skip = name
+
+		default:
+			// Tags separate words, e.g. across paragraphs and table cells.
+			b.WriteByte(' ')
+		}
+	}
+
+	return html.UnescapeString(b.String())
+}
+
+func getHTMLTagName(tag string) (string, bool) {
+	isEnd := strings.HasPrefix(tag, "/")
+
+	fields := strings.FieldsFunc(tag, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '/'
+	})
+
+	if len(fields) == 0 {
+		return "", isEnd
+	}
+
+	return fields[0], isEnd
+}
+
+// TruncatePreview truncates the text to MaxPreviewLength characters, preferably at a word boundary.
+func TruncatePreview(text string) string {
+	if utf8.RuneCountInString(text) <= MaxPreviewLength {
+		return text
+	}
+
+	runes := []rune(text)[:MaxPreviewLength]
+
+	if idx := strings.LastIndexByte(string(runes), ' '); idx > 0 {
+		return string(runes)[:idx]
+	}
+
+	return string(runes)
+}
@@ -0,0 +1,84 @@
+package imap
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/gluon/rfc822"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreview(t *testing.T) {
+	tests := []struct {
+		name    string
+		literal string
+		want    string
+	}{
+		{
+			name:    "plain",
+			literal: "Subject: Hi\r\n\r\nHello,\r\n\r\n  how are   you?\r\n",
+			want:    "Hello, how are you?",
+		},
+		{
+			name:    "quoted-printable",
+			literal: "Content-Type: text/plain; charset=utf-8\r\nContent-Transfer-Encoding: quoted-printable\r\n\r\nGr=C3=BC=C3=9Fe\r\n",
+			want:    "Grüße",
+		},
+		{
+			name: "alternative",
+			literal: "Content-Type: multipart/alternative; boundary=b\r\n\r\n" +
+				"--b\r\nContent-Type: text/html\r\n\r\n<p>HTML</p>\r\n" +
+				"--b\r\nContent-Type: text/plain\r\n\r\nPlain\r\n" +
+				"--b--\r\n",
+			want: "Plain",
+		},
+		{
+			name: "html",
+			literal: "Content-Type: text/html\r\n\r\n" +
+				"<html><head><title>Title</title><style>p { color: red; }</style></head>" +
+				"<body><!-- <b>comment</b> --><p>Fish&nbsp;&amp; chips</p><p>today</p></body></html>\r\n",
+			want: "Fish & chips today",
+		},
+		{
+			name: "attachment",
+			literal: "Content-Type: multipart/mixed; boundary=b\r\n\r\n" +
+				"--b\r\nContent-Type: text/plain\r\nContent-Disposition: attachment; filename=a.txt\r\n\r\nAttachment\r\n" +
+				"--b\r\nContent-Type: image/png\r\n\r\nPNG\r\n" +
+				"--b--\r\n",
+			want: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			preview, err := Preview(rfc822.Parse([]byte(test.literal)))
+			require.NoError(t, err)
+			require.Equal(t, test.want, preview)
+		})
+	}
+}
+
+func TestPreviewTruncated(t *testing.T) {
+	preview, err := Preview(rfc822.Parse([]byte("\r\n" + strings.Repeat("word ", 100))))
+	require.NoError(t, err)
+	require.LessOrEqual(t, len([]rune(preview)), MaxPreviewLength)
+	require.True(t, strings.HasSuffix(preview, "word"))
+}
+
+func TestParsedMessageWithBrokenPreview(t *testing.T) {
+	literal := "Content-Type: text/plain\r\nContent-Transfer-Encoding: base64\r\n\r\n!!! not base64 !!!\r\n"
+
+	_, err := Preview(rfc822.Parse([]byte(literal)))
+	require.Error(t, err)
+
+	// Messages are still accepted if their preview can't be built.
+	parsed, err := NewParsedMessage([]byte(literal))
+	require.NoError(t, err)
+	require.Nil(t, parsed.Preview)
+}
+
+func TestTruncatePreview(t *testing.T) {
+	require.Equal(t, "Hello", TruncatePreview("Hello"))
+	require.Equal(t, strings.Repeat("é", MaxPreviewLength), TruncatePreview(strings.Repeat("é", MaxPreviewLength+1)))
+	require.Equal(t, strings.Repeat("word ", 50)+"word", TruncatePreview(strings.Repeat("word ", 100)))
+}
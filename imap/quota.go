@@ -0,0 +1,28 @@
+package imap
+
+// QuotaRoot is the name of the only quota root (RFC9208) exposed to clients. It applies to every mailbox of the
+// account, since the storage limits are enforced by the remote for the account as a whole.
+const QuotaRoot = ""
+
+// Quota holds the usage and limits of the resources of an account as defined in RFC9208.
+// Storage is counted in bytes. A limit of zero means the resource is not limited.
+type Quota struct {
+	StorageUsage int64
+	StorageLimit int64
+
+	MessageUsage int64
+	MessageLimit int64
+}
+
+// IsOverQuota returns whether adding the given number of bytes and messages would exceed one of the limits.
+func (q Quota) IsOverQuota(storage, messages int64) bool {
+	if q.StorageLimit > 0 && q.StorageUsage+storage > q.StorageLimit {
+		return true
+	}
+
+	if q.MessageLimit > 0 && q.MessageUsage+messages > q.MessageLimit {
+		return true
+	}
+
+	return false
+}
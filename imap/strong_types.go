@@ -19,6 +19,18 @@ func (m MessageID) ShortID() string {
 	return ShortID(string(m))
 }
 
+// ObjectID returns the MAILBOXID (RFC8474) of the mailbox.
+func (l MailboxID) ObjectID() string {
+	return ObjectID(string(l))
+}
+
+// ObjectID returns the EMAILID (RFC8474) of the message. As it is derived from the remote ID, it only stays the same for
+// as long as the remote ID does. Recovered messages only have a placeholder remote ID, so they get a new EMAILID once
+// they are uploaded to the connector, as do messages whose remote ID changes with a MessageIDChanged update.
+func (m MessageID) ObjectID() string {
+	return ObjectID(string(m))
+}
+
 type InternalMessageID struct {
 	uuid.UUID
 }
@@ -61,3 +73,6 @@ func (u UID) Add(v uint32) UID {
 }
 
 type SeqID uint32
+
+// ModSeq is a mod-sequence value as defined in RFC7162.
+type ModSeq uint64
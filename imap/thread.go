@@ -0,0 +1,8 @@
+package imap
+
+// Thread is a node of a message thread as returned by the THREAD command (RFC5256). The ID is the sequence number or
+// UID of the message; a zero ID denotes a placeholder for a message which isn't part of the result.
+type Thread struct {
+	ID       uint32
+	Children []Thread
+}
@@ -5,12 +5,18 @@ import (
 
 	"github.com/ProtonMail/gluon/rfc822"
 	"github.com/bradenaw/juniper/xslices"
+	"github.com/sirupsen/logrus"
 )
 
 type ParsedMessage struct {
 	Body      string
 	Structure string
 	Envelope  string
+
+	// Preview is the short plain-text snippet of the message returned by FETCH PREVIEW (RFC8970). It is nil if it
+	// couldn't be built. Connectors may replace it with their own preview, which is truncated to MaxPreviewLength
+	// characters when the message is created.
+	Preview *string
 }
 
 func NewParsedMessage(literal []byte) (*ParsedMessage, error) {
@@ -31,11 +37,20 @@ func NewParsedMessage(literal []byte) (*ParsedMessage, error) {
 		return nil, fmt.Errorf("failed to build message envelope: %w", err)
 	}
 
-	return &ParsedMessage{
+	parsed := &ParsedMessage{
 		Body:      body,
 		Structure: structure,
 		Envelope:  envelope,
-	}, nil
+	}
+
+	// The preview is only cosmetic, messages whose preview can't be built are still accepted.
+	if preview, err := Preview(root); err != nil {
+		logrus.WithError(err).Warn("Failed to build message preview")
+	} else {
+		parsed.Preview = &preview
+	}
+
+	return parsed, nil
 }
 
 type MessagesCreated struct {
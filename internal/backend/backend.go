@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"github.com/ProtonMail/gluon/connector"
 	"github.com/ProtonMail/gluon/db"
 	"github.com/ProtonMail/gluon/imap"
+	"github.com/ProtonMail/gluon/imap/command"
 	"github.com/ProtonMail/gluon/internal/state"
 	"github.com/ProtonMail/gluon/limits"
 	"github.com/ProtonMail/gluon/observability"
@@ -39,6 +41,10 @@ type Backend struct {
 	users     map[string]*user
 	usersLock sync.Mutex
 
+	// usernames holds the IDs of the users by the names they logged in with, in lower case. These names are the user
+	// component of their IMAP URLs (RFC5092).
+	usernames map[string]string
+
 	// storeBuilder builds stores for the backend users.
 	storeBuilder store.Builder
 
@@ -72,6 +78,7 @@ func New(dataDir, databaseDir string,
 		databaseDir:   databaseDir,
 		delim:         delim,
 		users:         make(map[string]*user),
+		usernames:     make(map[string]string),
 		storeBuilder:  storeBuilder,
 		loginJailTime: loginJailTime,
 		imapLimits:    imapLimits,
@@ -89,6 +96,10 @@ func (b *Backend) GetDelimiter() string {
 	return b.delim
 }
 
+func (b *Backend) GetIMAPLimits() limits.IMAP {
+	return b.imapLimits
+}
+
 // AddUser adds a new user to the backend.
 // It returns true if the user's database was created, false if it already existed.
 func (b *Backend) AddUser(ctx context.Context, userID string, conn connector.Connector, passphrase []byte, uidValidityGenerator imap.UIDValidityGenerator) (bool, error) {
@@ -191,6 +202,12 @@ func (b *Backend) RemoveUser(ctx context.Context, userID string, removeFiles boo
 
 	delete(b.users, userID)
 
+	for username, id := range b.usernames {
+		if id == userID {
+			delete(b.usernames, username)
+		}
+	}
+
 	if removeFiles {
 		if err := b.storeBuilder.Delete(b.getStoreDir(), userID); err != nil {
 			return err
@@ -249,6 +266,8 @@ func (b *Backend) GetState(ctx context.Context, username string, password []byte
 		return nil, err
 	}
 
+	b.usernames[strings.ToLower(username)] = userID
+
 	b.log.
 		WithField("userID", userID).
 		WithField("username", username).
@@ -258,6 +277,34 @@ func (b *Backend) GetState(ctx context.Context, username string, password []byte
 	return state, nil
 }
 
+// FetchURL returns the content the URLAUTH URL (RFC4467) of another user points at, if its authorization is valid.
+// Only the URLs of users who logged in since the backend was created can be resolved, as the user of the URL is the
+// name its owner logged in with.
+func (b *Backend) FetchURL(ctx context.Context, u *command.URL) ([]byte, error) {
+	st, err := func() (*state.State, error) {
+		b.usersLock.Lock()
+		defer b.usersLock.Unlock()
+
+		userID, ok := b.usernames[strings.ToLower(u.User)]
+		if !ok {
+			return nil, ErrNoSuchUser
+		}
+
+		return b.users[userID].newState() //nolint:contextcheck
+	}()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := b.ReleaseState(ctx, st); err != nil {
+			b.log.WithError(err).Error("Failed to release the state used to fetch a URL")
+		}
+	}()
+
+	return st.FetchURL(ctx, u)
+}
+
 func (b *Backend) ReleaseState(ctx context.Context, st *state.State) error {
 	b.usersLock.Lock()
 	defer b.usersLock.Unlock()
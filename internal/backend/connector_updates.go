@@ -94,14 +94,14 @@ func (user *user) applyMailboxCreated(ctx context.Context, update *imap.MailboxC
 		return err
 	}
 
-	return user.db.Write(ctx, func(ctx context.Context, tx db.Transaction) error {
+	return userDBWrite(ctx, user, func(ctx context.Context, tx db.Transaction) ([]state.Update, error) {
 		if mailboxCount, err := tx.GetMailboxCount(ctx); err != nil {
-			return err
+			return nil, err
 		} else if err := user.imapLimits.CheckMailBoxCount(mailboxCount); err != nil {
-			return err
+			return nil, err
 		}
 
-		if _, err := tx.CreateMailbox(
+		mbox, err := tx.CreateMailbox(
 			ctx,
 			update.Mailbox.ID,
 			strings.Join(update.Mailbox.Name, user.delimiter),
@@ -109,11 +109,12 @@ func (user *user) applyMailboxCreated(ctx context.Context, update *imap.MailboxC
 			update.Mailbox.PermanentFlags,
 			update.Mailbox.Attributes,
 			uidValidity,
-		); err != nil {
-			return err
+		)
+		if err != nil {
+			return nil, err
 		}
 
-		return nil
+		return []state.Update{state.NewMailboxNameStateUpdate(mbox.Name, "")}, nil
 	})
 }
 
@@ -141,7 +142,10 @@ func (user *user) applyMailboxDeleted(ctx context.Context, update *imap.MailboxD
 			return nil, err
 		}
 
-		return []state.Update{state.NewMailboxDeletedStateUpdate(mailbox.ID)}, nil
+		return []state.Update{
+			state.NewMailboxDeletedStateUpdate(mailbox.ID),
+			state.NewMailboxNameStateUpdate(mailbox.Name, ""),
+		}, nil
 	})
 }
 
@@ -151,16 +155,16 @@ func (user *user) applyMailboxUpdated(ctx context.Context, update *imap.MailboxU
 		return fmt.Errorf("attempting to rename protected mailbox (recovery)")
 	}
 
-	return user.db.Write(ctx, func(ctx context.Context, tx db.Transaction) error {
+	return userDBWrite(ctx, user, func(ctx context.Context, tx db.Transaction) ([]state.Update, error) {
 		if exists, err := tx.MailboxExistsWithRemoteID(ctx, update.MailboxID); err != nil {
-			return err
+			return nil, err
 		} else if !exists {
-			return nil
+			return nil, nil
 		}
 
 		currentName, err := tx.GetMailboxNameWithRemoteID(ctx, update.MailboxID)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		remoteName := strings.Join(update.MailboxName, user.delimiter)
@@ -169,10 +173,16 @@ func (user *user) applyMailboxUpdated(ctx context.Context, update *imap.MailboxU
 		}
 
 		if currentName == remoteName {
-			return nil
+			return nil, nil
+		}
+
+		newName := strings.Join(update.MailboxName, user.delimiter)
+
+		if err := tx.RenameMailboxWithRemoteID(ctx, update.MailboxID, newName); err != nil {
+			return nil, err
 		}
 
-		return tx.RenameMailboxWithRemoteID(ctx, update.MailboxID, strings.Join(update.MailboxName, user.delimiter))
+		return []state.Update{state.NewMailboxNameStateUpdate(newName, currentName)}, nil
 	})
 }
 
@@ -229,6 +239,7 @@ func (user *user) applyMessagesCreated(ctx context.Context, update *imap.Message
 							Body:        message.ParsedMessage.Body,
 							Structure:   message.ParsedMessage.Structure,
 							Envelope:    message.ParsedMessage.Envelope,
+							Preview:     connectorPreview(message.ParsedMessage),
 							InternalID:  internalID,
 						},
 						reader: literalReader,
@@ -688,6 +699,7 @@ func (user *user) applyMessageUpdated(ctx context.Context, update *imap.MessageU
 					Body:        update.ParsedMessage.Body,
 					Structure:   update.ParsedMessage.Structure,
 					Envelope:    update.ParsedMessage.Envelope,
+					Preview:     connectorPreview(update.ParsedMessage),
 					InternalID:  newInternalID,
 				}
 
@@ -793,3 +805,15 @@ func userDBWriteResult[T any](ctx context.Context, user *user, fn func(context.C
 
 	return result, nil
 }
+
+// connectorPreview returns the preview of a message created by the connector, which may have replaced it with a preview
+// longer than allowed.
+func connectorPreview(parsed *imap.ParsedMessage) *string {
+	if parsed.Preview == nil {
+		return nil
+	}
+
+	preview := imap.TruncatePreview(*parsed.Preview)
+
+	return &preview
+}
@@ -2,12 +2,14 @@ package backend
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/ProtonMail/gluon/connector"
 	"github.com/ProtonMail/gluon/db"
 	"github.com/ProtonMail/gluon/imap"
 	"github.com/ProtonMail/gluon/internal/state"
+	"github.com/bradenaw/juniper/xslices"
 )
 
 type stateConnectorImpl struct {
@@ -44,12 +46,17 @@ func (sc *stateConnectorImpl) ClearAllConnMetadata() {
 	sc.metadata = make(map[string]any)
 }
 
-func (sc *stateConnectorImpl) CreateMailbox(ctx context.Context, tx db.Transaction, name []string) ([]state.Update, imap.Mailbox, error) {
+func (sc *stateConnectorImpl) CreateMailbox(
+	ctx context.Context,
+	tx db.Transaction,
+	name []string,
+	specialUse imap.FlagSet,
+) ([]state.Update, imap.Mailbox, error) {
 	ctx = sc.newContextWithMetadata(ctx)
 
 	cache := sc.newDBIMAPWrite(tx)
 
-	mbox, err := sc.connector.CreateMailbox(ctx, &cache, name)
+	mbox, err := sc.connector.CreateMailbox(ctx, &cache, name, specialUse)
 	if err != nil {
 		return nil, imap.Mailbox{}, err
 	}
@@ -101,6 +108,111 @@ func (sc *stateConnectorImpl) CreateMessage(
 	return cache.stateUpdates, imap.NewInternalMessageID(), msg, newLiteral, nil
 }
 
+func (sc *stateConnectorImpl) CreateMessages(
+	ctx context.Context,
+	tx db.Transaction,
+	mboxID imap.MailboxID,
+	reqs []connector.CreateMessageReq,
+) ([]state.Update, []connector.CreatedMessage, error) {
+	ctx = sc.newContextWithMetadata(ctx)
+
+	cache := sc.newDBIMAPWrite(tx)
+
+	if creator, ok := sc.connector.(connector.MessageBatchCreator); ok {
+		created, err := creator.CreateMessages(ctx, &cache, mboxID, reqs)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if len(created) != len(reqs) {
+			return nil, nil, fmt.Errorf("connector created %v messages instead of %v", len(created), len(reqs))
+		}
+
+		return cache.stateUpdates, created, nil
+	}
+
+	// Without batch support, the messages created before a failure are rolled back.
+	created := make([]connector.CreatedMessage, 0, len(reqs))
+
+	for _, req := range reqs {
+		msg, newLiteral, err := sc.connector.CreateMessage(ctx, &cache, mboxID, req.Literal, req.Flags, req.Date)
+		if err != nil {
+			return nil, nil, sc.rollbackCreatedMessages(ctx, &cache, mboxID, err, xslices.Map(created, func(c connector.CreatedMessage) imap.MessageID {
+				return c.Message.ID
+			})...)
+		}
+
+		created = append(created, connector.CreatedMessage{Message: msg, Literal: newLiteral})
+	}
+
+	return cache.stateUpdates, created, nil
+}
+
+func (sc *stateConnectorImpl) ReplaceMessage(
+	ctx context.Context,
+	tx db.Transaction,
+	messageID imap.MessageID,
+	mboxFromID, mboxToID imap.MailboxID,
+	literal []byte,
+	flags imap.FlagSet,
+	date time.Time,
+) ([]state.Update, imap.InternalMessageID, imap.Message, []byte, error) {
+	ctx = sc.newContextWithMetadata(ctx)
+
+	cache := sc.newDBIMAPWrite(tx)
+
+	if replacer, ok := sc.connector.(connector.MessageReplacer); ok {
+		msg, newLiteral, err := replacer.ReplaceMessage(ctx, &cache, messageID, mboxFromID, mboxToID, literal, flags, date)
+		if err != nil {
+			return nil, imap.InternalMessageID{}, imap.Message{}, nil, err
+		}
+
+		return cache.stateUpdates, imap.NewInternalMessageID(), msg, newLiteral, nil
+	}
+
+	// Without replace support, the created message is rolled back if the old one can't be removed.
+	msg, newLiteral, err := sc.connector.CreateMessage(ctx, &cache, mboxToID, literal, flags, date)
+	if err != nil {
+		return nil, imap.InternalMessageID{}, imap.Message{}, nil, err
+	}
+
+	if err := sc.connector.RemoveMessagesFromMailbox(ctx, &cache, []imap.MessageID{messageID}, mboxFromID); err != nil {
+		return nil, imap.InternalMessageID{}, imap.Message{}, nil, sc.rollbackCreatedMessages(ctx, &cache, mboxToID, err, msg.ID)
+	}
+
+	return cache.stateUpdates, imap.NewInternalMessageID(), msg, newLiteral, nil
+}
+
+// rollbackCreatedMessages undoes the creation of the messages by an operation which failed midway with the given
+// error. The messages are deleted if the connector supports it; otherwise, they can only be removed from the mailbox
+// they were created in and may remain on the remote. The returned error is that of the operation, along with that of
+// the rollback if it failed too.
+func (sc *stateConnectorImpl) rollbackCreatedMessages(
+	ctx context.Context,
+	cache *DBIMAPStateWrite,
+	mboxID imap.MailboxID,
+	opErr error,
+	messageIDs ...imap.MessageID,
+) error {
+	if len(messageIDs) == 0 {
+		return opErr
+	}
+
+	var err error
+
+	if deleter, ok := sc.connector.(connector.MessageDeleter); ok {
+		err = deleter.DeleteMessages(ctx, cache, messageIDs)
+	} else {
+		err = sc.connector.RemoveMessagesFromMailbox(ctx, cache, messageIDs, mboxID)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w (failed to roll back %v created messages: %v)", opErr, len(messageIDs), err)
+	}
+
+	return opErr
+}
+
 func (sc *stateConnectorImpl) GetMessageLiteral(ctx context.Context, id imap.MessageID) ([]byte, error) {
 	ctx = sc.newContextWithMetadata(ctx)
 
@@ -191,6 +303,37 @@ func (sc *stateConnectorImpl) GetMailboxVisibility(ctx context.Context,
 	return sc.connector.GetMailboxVisibility(ctx, id)
 }
 
+func (sc *stateConnectorImpl) GetNamespaces(ctx context.Context) imap.Namespaces {
+	if provider, ok := sc.connector.(connector.NamespaceProvider); ok {
+		return provider.GetNamespaces(ctx)
+	}
+
+	return imap.DefaultNamespaces()
+}
+
+func (sc *stateConnectorImpl) GetQuota(ctx context.Context) (imap.Quota, bool, error) {
+	provider, ok := sc.connector.(connector.QuotaProvider)
+	if !ok {
+		return imap.Quota{}, false, nil
+	}
+
+	quota, err := provider.GetQuota(sc.newContextWithMetadata(ctx))
+	if err != nil {
+		return imap.Quota{}, false, err
+	}
+
+	return quota, true, nil
+}
+
+func (sc *stateConnectorImpl) SetSharedMetadata(ctx context.Context, mboxID imap.MailboxID, entries []imap.MetadataEntry) error {
+	syncer, ok := sc.connector.(connector.MetadataSyncer)
+	if !ok {
+		return nil
+	}
+
+	return syncer.SetSharedMetadata(sc.newContextWithMetadata(ctx), mboxID, entries)
+}
+
 func (sc *stateConnectorImpl) SetMessagesForwarded(
 	ctx context.Context,
 	tx db.Transaction,
@@ -60,11 +60,7 @@ func (s *StateUserInterfaceImpl) QueueOrApplyStateUpdate(ctx context.Context, tx
 				return nil
 			} else {
 				for _, update := range updates {
-					if !update.Filter(state) {
-						continue
-					}
-
-					if err := update.Apply(ctx, tx, state); err != nil {
+					if err := state.ApplyUpdateWithTx(ctx, tx, update); err != nil {
 						return err
 					}
 				}
@@ -201,6 +201,17 @@ func runAndValidateDB(t *testing.T, testDir, user string, testData *testData, ui
 			require.Equal(t, m.recent, msg[idx].Recent)
 			require.Equal(t, m.deleted, msg[idx].Deleted)
 			require.Equal(t, m.uid, msg[idx].UID)
+
+			// Messages saved before save dates were stored have their internal date instead.
+			{
+				msgIdx := slices.IndexFunc(testData.messages, func(msg message) bool {
+					return msg.ID == m.messageID
+				})
+
+				saveDates, err := rd.GetMessagesSaveDate(ctx, m.mboxID, []imap.InternalMessageID{m.messageID})
+				require.NoError(t, err)
+				require.Equal(t, testData.messages[msgIdx].Date, saveDates[m.messageID])
+			}
 		}
 
 		return nil
@@ -10,8 +10,17 @@ import (
 	"github.com/ProtonMail/gluon/internal/db_impl/sqlite3/utils"
 	v0 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v0"
 	v1 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v1"
+	v10 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v10"
+	v11 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v11"
+	v12 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v12"
 	v2 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v2"
 	v3 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v3"
+	v4 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v4"
+	v5 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v5"
+	v6 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v6"
+	v7 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v7"
+	v8 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v8"
+	v9 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v9"
 	"github.com/sirupsen/logrus"
 )
 
@@ -24,6 +33,15 @@ var migrationList = []Migration{
 	&v1.Migration{},
 	&v2.Migration{},
 	&v3.Migration{},
+	&v4.Migration{},
+	&v5.Migration{},
+	&v6.Migration{},
+	&v7.Migration{},
+	&v8.Migration{},
+	&v9.Migration{},
+	&v10.Migration{},
+	&v11.Migration{},
+	&v12.Migration{},
 }
 
 func RunMigrations(ctx context.Context, tx utils.QueryWrapper, generator imap.UIDValidityGenerator) error {
@@ -12,7 +12,15 @@ import (
 	"github.com/ProtonMail/gluon/imap"
 	"github.com/ProtonMail/gluon/internal/db_impl/sqlite3/utils"
 	v1 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v1"
+	v10 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v10"
+	v11 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v11"
+	v12 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v12"
 	v2 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v2"
+	v4 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v4"
+	v5 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v5"
+	v6 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v6"
+	v7 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v7"
+	v8 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v8"
 	"github.com/bradenaw/juniper/xmaps"
 	"github.com/bradenaw/juniper/xslices"
 )
@@ -163,6 +171,19 @@ func (r readOps) GetMailboxRecentCount(ctx context.Context, mboxID imap.Internal
 	return utils.MapQueryRow[int](ctx, r.qw, query)
 }
 
+func (r readOps) GetMailboxUnseenCount(ctx context.Context, mboxID imap.InternalMailboxID) (int, error) {
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %[1]v AS m WHERE NOT EXISTS "+
+		"(SELECT 1 FROM %[2]v AS f WHERE `f`.`%[3]v` = `m`.`%[4]v` AND LOWER(`f`.`%[5]v`) = ?)",
+		v1.MailboxMessageTableName(mboxID),
+		v1.MessageFlagsTableName,
+		v1.MessageFlagsFieldMessageID,
+		v1.MailboxMessagesFieldMessageID,
+		v1.MessageFlagsFieldValue,
+	)
+
+	return utils.MapQueryRow[int](ctx, r.qw, query, imap.FlagSeenLowerCase)
+}
+
 func (r readOps) GetMailboxMessageCount(ctx context.Context, mboxID imap.InternalMailboxID) (int, error) {
 	query := fmt.Sprintf("SELECT COUNT(*) FROM %v",
 		v1.MailboxMessageTableName(mboxID),
@@ -184,6 +205,19 @@ func (r readOps) GetMailboxMessageCountWithRemoteID(ctx context.Context, mboxID
 	return utils.MapQueryRow[int](ctx, r.qw, query)
 }
 
+func (r readOps) GetMailboxSize(ctx context.Context, mboxID imap.InternalMailboxID) (int64, error) {
+	query := fmt.Sprintf("SELECT COALESCE(SUM(`m`.`%v`), 0) FROM %v AS mbox "+
+		"JOIN %v AS m ON `m`.`%v` = `mbox`.`%v`",
+		v1.MessagesFieldSize,
+		v1.MailboxMessageTableName(mboxID),
+		v1.MessagesTableName,
+		v1.MessagesFieldID,
+		v1.MailboxMessagesFieldMessageID,
+	)
+
+	return utils.MapQueryRow[int64](ctx, r.qw, query)
+}
+
 func (r readOps) GetMailboxFlags(ctx context.Context, mboxID imap.InternalMailboxID) (imap.FlagSet, error) {
 	query := fmt.Sprintf("SELECT `%v` FROM %v WHERE `%v` = ?",
 		v1.MailboxFlagsFieldValue,
@@ -259,6 +293,47 @@ func (r readOps) GetMailboxMessageCountAndUID(ctx context.Context, mboxID imap.I
 	return count, uid, nil
 }
 
+func (r readOps) GetMailboxHighestModSeq(ctx context.Context, mboxID imap.InternalMailboxID) (imap.ModSeq, error) {
+	query := fmt.Sprintf("SELECT `%v` FROM %v WHERE `%v` = ?",
+		v4.MailboxesFieldHighestModSeq,
+		v1.MailboxesTableName,
+		v1.MailboxesFieldID,
+	)
+
+	return utils.MapQueryRow[imap.ModSeq](ctx, r.qw, query, mboxID)
+}
+
+func (r readOps) GetMailboxExpungedUIDsSince(ctx context.Context, mboxID imap.InternalMailboxID, modSeq imap.ModSeq) ([]imap.UID, error) {
+	query := fmt.Sprintf("SELECT `%v` FROM %v WHERE `%v` = ? AND `%v` > ? ORDER BY `%v`",
+		v5.ExpungedUIDsFieldUID,
+		v5.ExpungedUIDsTableName,
+		v5.ExpungedUIDsFieldMailboxID,
+		v5.ExpungedUIDsFieldModSeq,
+		v5.ExpungedUIDsFieldUID,
+	)
+
+	return utils.MapQueryRows[imap.UID](ctx, r.qw, query, mboxID, modSeq)
+}
+
+func (r readOps) GetMailboxPrunedExpungedModSeq(ctx context.Context, mboxID imap.InternalMailboxID) (imap.ModSeq, error) {
+	query := fmt.Sprintf("SELECT `%v` FROM %v WHERE `%v` = ?",
+		v12.PrunedExpungedUIDsFieldModSeq,
+		v12.PrunedExpungedUIDsTableName,
+		v12.PrunedExpungedUIDsFieldMailboxID,
+	)
+
+	modSeq, err := utils.MapQueryRow[imap.ModSeq](ctx, r.qw, query, mboxID)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	return modSeq, nil
+}
+
 func (r readOps) GetMailboxMessageForNewSnapshot(ctx context.Context, mboxID imap.InternalMailboxID) ([]db.SnapshotMessageResult, error) {
 	query := fmt.Sprintf("SELECT `m`.`%[1]v`, GROUP_CONCAT(`f`.`%[2]v`) AS `flags`, `m`.`%[3]v`, `m`.`%[4]v`, "+
 		"`m`.`%[5]v`, `m`.`%[6]v` FROM %[9]v AS m "+
@@ -289,6 +364,25 @@ func (r readOps) GetMailboxMessageForNewSnapshot(ctx context.Context, mboxID ima
 	})
 }
 
+func (r readOps) GetMailboxMessageIDWithUID(ctx context.Context, mboxID imap.InternalMailboxID, uid imap.UID) (db.MessageIDPair, error) {
+	query := fmt.Sprintf("SELECT `%v`, `%v` FROM %v WHERE `%v` = ?",
+		v1.MailboxMessagesFieldMessageID,
+		v1.MailboxMessagesFieldMessageRemoteID,
+		v1.MailboxMessageTableName(mboxID),
+		v1.MailboxMessagesFieldUID,
+	)
+
+	return utils.MapQueryRowFn(ctx, r.qw, query, func(scanner utils.RowScanner) (db.MessageIDPair, error) {
+		var id db.MessageIDPair
+
+		if err := scanner.Scan(&id.InternalID, &id.RemoteID); err != nil {
+			return db.MessageIDPair{}, err
+		}
+
+		return id, nil
+	}, uid)
+}
+
 func (r readOps) MailboxTranslateRemoteIDs(ctx context.Context, mboxIDs []imap.MailboxID) ([]imap.InternalMailboxID, error) {
 	result := make([]imap.InternalMailboxID, 0, len(mboxIDs))
 
@@ -414,6 +508,22 @@ func (r readOps) GetTotalMessageCount(ctx context.Context) (int, error) {
 	return utils.MapQueryRow[int](ctx, r.qw, query)
 }
 
+func (r readOps) GetTotalActiveMessageCount(ctx context.Context) (int, error) {
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %v WHERE `%v` = FALSE", v1.MessagesTableName, v1.MessagesFieldDeleted)
+
+	return utils.MapQueryRow[int](ctx, r.qw, query)
+}
+
+func (r readOps) GetTotalMessageSize(ctx context.Context) (int64, error) {
+	query := fmt.Sprintf("SELECT COALESCE(SUM(`%v`), 0) FROM %v WHERE `%v` = FALSE",
+		v1.MessagesFieldSize,
+		v1.MessagesTableName,
+		v1.MessagesFieldDeleted,
+	)
+
+	return utils.MapQueryRow[int64](ctx, r.qw, query)
+}
+
 func (r readOps) GetMessageRemoteID(ctx context.Context, id imap.InternalMessageID) (imap.MessageID, error) {
 	query := fmt.Sprintf("SELECT `%v` FROM %v WHERE `%v` = ?", v1.MessagesFieldRemoteID, v1.MessagesTableName, v1.MessagesFieldID)
 
@@ -476,6 +586,145 @@ func (r readOps) GetMessageDateAndSize(ctx context.Context, id imap.InternalMess
 	return dt.Date, dt.Size, nil
 }
 
+func (r readOps) GetMessagesThreadID(ctx context.Context, ids []imap.InternalMessageID) (map[imap.InternalMessageID]string, error) {
+	result := make(map[imap.InternalMessageID]string, len(ids))
+
+	for _, chunk := range xslices.Chunk(ids, db.ChunkLimit) {
+		query := fmt.Sprintf("SELECT `%v`, `%v` FROM %v WHERE `%v` IN (%v)",
+			v1.MessagesFieldID,
+			v8.MessagesFieldThreadID,
+			v1.MessagesTableName,
+			v1.MessagesFieldID,
+			utils.GenSQLIn(len(chunk)),
+		)
+
+		type MessageThreadID struct {
+			ID       imap.InternalMessageID
+			ThreadID string
+		}
+
+		threadIDs, err := utils.MapQueryRowsFn(ctx, r.qw, query, func(scanner utils.RowScanner) (MessageThreadID, error) {
+			var m MessageThreadID
+
+			if err := scanner.Scan(&m.ID, &m.ThreadID); err != nil {
+				return MessageThreadID{}, err
+			}
+
+			return m, nil
+		}, utils.MapSliceToAny(chunk)...)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, m := range threadIDs {
+			result[m.ID] = m.ThreadID
+		}
+	}
+
+	return result, nil
+}
+
+func (r readOps) GetMessagesModSeq(ctx context.Context, mboxID imap.InternalMailboxID, ids []imap.InternalMessageID) (map[imap.InternalMessageID]imap.ModSeq, error) {
+	result := make(map[imap.InternalMessageID]imap.ModSeq, len(ids))
+
+	for _, chunk := range xslices.Chunk(ids, db.ChunkLimit-1) {
+		query := fmt.Sprintf("SELECT m.`%v`, MAX(m.`%v`, mm.`%v`) FROM %v AS m "+
+			"JOIN %v AS mm ON mm.`%v` = m.`%v` "+
+			"WHERE mm.`%v` = ? AND m.`%v` IN (%v)",
+			v1.MessagesFieldID,
+			v4.MessagesFieldModSeq,
+			v11.MessageToMailboxFieldModSeq,
+			v1.MessagesTableName,
+			v1.MessageToMailboxTableName,
+			v1.MessageToMailboxFieldMessageID,
+			v1.MessagesFieldID,
+			v1.MessageToMailboxFieldMailboxID,
+			v1.MessagesFieldID,
+			utils.GenSQLIn(len(chunk)),
+		)
+
+		type MessageModSeq struct {
+			ID     imap.InternalMessageID
+			ModSeq imap.ModSeq
+		}
+
+		modSeqs, err := utils.MapQueryRowsFn(ctx, r.qw, query, func(scanner utils.RowScanner) (MessageModSeq, error) {
+			var m MessageModSeq
+
+			if err := scanner.Scan(&m.ID, &m.ModSeq); err != nil {
+				return MessageModSeq{}, err
+			}
+
+			return m, nil
+		}, append([]any{mboxID}, utils.MapSliceToAny(chunk)...)...)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, m := range modSeqs {
+			result[m.ID] = m.ModSeq
+		}
+	}
+
+	return result, nil
+}
+
+func (r readOps) GetMessagesSaveDate(ctx context.Context, mboxID imap.InternalMailboxID, ids []imap.InternalMessageID) (map[imap.InternalMessageID]time.Time, error) {
+	result := make(map[imap.InternalMessageID]time.Time, len(ids))
+
+	for _, chunk := range xslices.Chunk(ids, db.ChunkLimit-1) {
+		query := fmt.Sprintf("SELECT mm.`%v`, mm.`%v`, m.`%v` FROM %v AS mm "+
+			"JOIN %v AS m ON m.`%v` = mm.`%v` "+
+			"WHERE mm.`%v` = ? AND mm.`%v` IN (%v)",
+			v1.MessageToMailboxFieldMessageID,
+			v10.MessageToMailboxFieldSaveDate,
+			v1.MessagesFieldDate,
+			v1.MessageToMailboxTableName,
+			v1.MessagesTableName,
+			v1.MessagesFieldID,
+			v1.MessageToMailboxFieldMessageID,
+			v1.MessageToMailboxFieldMailboxID,
+			v1.MessageToMailboxFieldMessageID,
+			utils.GenSQLIn(len(chunk)),
+		)
+
+		type MessageSaveDate struct {
+			ID       imap.InternalMessageID
+			SaveDate time.Time
+		}
+
+		saveDates, err := utils.MapQueryRowsFn(ctx, r.qw, query, func(scanner utils.RowScanner) (MessageSaveDate, error) {
+			var (
+				m        MessageSaveDate
+				saveDate sql.NullTime
+				date     time.Time
+			)
+
+			if err := scanner.Scan(&m.ID, &saveDate, &date); err != nil {
+				return MessageSaveDate{}, err
+			}
+
+			// Messages which were already in the mailbox when save dates were introduced use their internal date.
+			if saveDate.Valid {
+				m.SaveDate = saveDate.Time
+			} else {
+				m.SaveDate = date
+			}
+
+			return m, nil
+		}, append([]any{mboxID}, utils.MapSliceToAny(chunk)...)...)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, m := range saveDates {
+			result[m.ID] = m.SaveDate
+		}
+	}
+
+	return result, nil
+}
+
 func (r readOps) GetMessageMailboxIDs(ctx context.Context, id imap.InternalMessageID) ([]imap.InternalMailboxID, error) {
 	query := fmt.Sprintf("SELECT `%[3]v` FROM %[1]v WHERE `%[2]v` = ?",
 		v1.MessageToMailboxTableName,
@@ -653,3 +902,48 @@ func (r readOps) GetAllMailboxesNameAndRemoteID(ctx context.Context) ([]db.Mailb
 		return r, nil
 	})
 }
+
+func (r readOps) GetMetadata(ctx context.Context, mboxID imap.InternalMailboxID) ([]imap.MetadataEntry, error) {
+	query := fmt.Sprintf("SELECT `%v`, `%v` FROM %v WHERE `%v` IS ? ORDER BY `%v`",
+		v6.MetadataFieldName,
+		v6.MetadataFieldValue,
+		v6.MetadataTableName,
+		v6.MetadataFieldMailboxID,
+		v6.MetadataFieldName,
+	)
+
+	return utils.MapQueryRowsFn(ctx, r.qw, query, func(scanner utils.RowScanner) (imap.MetadataEntry, error) {
+		var entry imap.MetadataEntry
+
+		if err := scanner.Scan(&entry.Name, &entry.Value); err != nil {
+			return entry, err
+		}
+
+		// Empty values are stored as empty blobs, make sure they are not mistaken for removed entries.
+		if entry.Value == nil {
+			entry.Value = []byte{}
+		}
+
+		return entry, nil
+	}, metadataMailboxID(mboxID))
+}
+
+// metadataMailboxID returns the value stored in the mailbox ID column of the metadata table: server entries have no
+// mailbox.
+func metadataMailboxID(mboxID imap.InternalMailboxID) any {
+	if mboxID == 0 {
+		return nil
+	}
+
+	return mboxID
+}
+
+func (r readOps) GetMailboxAccessKey(ctx context.Context, mboxID imap.InternalMailboxID) ([]byte, error) {
+	query := fmt.Sprintf("SELECT `%v` FROM %v WHERE `%v` = ?",
+		v7.AccessKeysFieldValue,
+		v7.AccessKeysTableName,
+		v7.AccessKeysFieldMailboxID,
+	)
+
+	return utils.MapQueryRow[[]byte](ctx, r.qw, query, mboxID)
+}
@@ -8,7 +8,7 @@ import (
 func ScanMailbox(scanner utils.RowScanner) (*db.Mailbox, error) {
 	mbox := new(db.Mailbox)
 
-	if err := scanner.Scan(&mbox.ID, &mbox.RemoteID, &mbox.Name, &mbox.UIDValidity, &mbox.Subscribed); err != nil {
+	if err := scanner.Scan(&mbox.ID, &mbox.RemoteID, &mbox.Name, &mbox.UIDValidity, &mbox.Subscribed, &mbox.HighestModSeq); err != nil {
 		return nil, err
 	}
 
@@ -18,7 +18,7 @@ func ScanMailbox(scanner utils.RowScanner) (*db.Mailbox, error) {
 func ScanMailboxWithAttr(scanner utils.RowScanner) (*db.MailboxWithAttr, error) {
 	mbox := new(db.MailboxWithAttr)
 
-	if err := scanner.Scan(&mbox.ID, &mbox.RemoteID, &mbox.Name, &mbox.UIDValidity, &mbox.Subscribed); err != nil {
+	if err := scanner.Scan(&mbox.ID, &mbox.RemoteID, &mbox.Name, &mbox.UIDValidity, &mbox.Subscribed, &mbox.HighestModSeq); err != nil {
 		return nil, err
 	}
 
@@ -28,7 +28,7 @@ func ScanMailboxWithAttr(scanner utils.RowScanner) (*db.MailboxWithAttr, error)
 func ScanMessage(scanner utils.RowScanner) (*db.Message, error) {
 	msg := new(db.Message)
 
-	if err := scanner.Scan(&msg.ID, &msg.RemoteID, &msg.Date, &msg.Size, &msg.Body, &msg.BodyStructure, &msg.Envelope, &msg.Deleted); err != nil {
+	if err := scanner.Scan(&msg.ID, &msg.RemoteID, &msg.Date, &msg.Size, &msg.Body, &msg.BodyStructure, &msg.Envelope, &msg.Deleted, &msg.ModSeq, &msg.ThreadID, &msg.Preview); err != nil {
 		return nil, err
 	}
 
@@ -38,7 +38,7 @@ func ScanMessage(scanner utils.RowScanner) (*db.Message, error) {
 func ScanMessageWithFlags(scanner utils.RowScanner) (*db.MessageWithFlags, error) {
 	msg := new(db.MessageWithFlags)
 
-	if err := scanner.Scan(&msg.ID, &msg.RemoteID, &msg.Date, &msg.Size, &msg.Body, &msg.BodyStructure, &msg.Envelope, &msg.Deleted); err != nil {
+	if err := scanner.Scan(&msg.ID, &msg.RemoteID, &msg.Date, &msg.Size, &msg.Body, &msg.BodyStructure, &msg.Envelope, &msg.Deleted, &msg.ModSeq, &msg.ThreadID, &msg.Preview); err != nil {
 		return nil, err
 	}
 
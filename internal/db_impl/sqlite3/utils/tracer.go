@@ -93,6 +93,12 @@ func (r ReadTracer) GetMailboxRecentCount(ctx context.Context, mboxID imap.Inter
 	return r.RD.GetMailboxRecentCount(ctx, mboxID)
 }
 
+func (r ReadTracer) GetMailboxUnseenCount(ctx context.Context, mboxID imap.InternalMailboxID) (int, error) {
+	r.Entry.Tracef("GetMailboxUnseenCount")
+
+	return r.RD.GetMailboxUnseenCount(ctx, mboxID)
+}
+
 func (r ReadTracer) GetMailboxMessageCount(ctx context.Context, mboxID imap.InternalMailboxID) (int, error) {
 	r.Entry.Tracef("GetMailboxMessageCount")
 
@@ -105,6 +111,12 @@ func (r ReadTracer) GetMailboxMessageCountWithRemoteID(ctx context.Context, mbox
 	return r.RD.GetMailboxMessageCountWithRemoteID(ctx, mboxID)
 }
 
+func (r ReadTracer) GetMailboxSize(ctx context.Context, mboxID imap.InternalMailboxID) (int64, error) {
+	r.Entry.Tracef("GetMailboxSize")
+
+	return r.RD.GetMailboxSize(ctx, mboxID)
+}
+
 func (r ReadTracer) GetMailboxFlags(ctx context.Context, mboxID imap.InternalMailboxID) (imap.FlagSet, error) {
 	r.Entry.Tracef("GetMailboxFlags")
 
@@ -129,12 +141,36 @@ func (r ReadTracer) GetMailboxUID(ctx context.Context, mboxID imap.InternalMailb
 	return r.RD.GetMailboxUID(ctx, mboxID)
 }
 
+func (r ReadTracer) GetMailboxHighestModSeq(ctx context.Context, mboxID imap.InternalMailboxID) (imap.ModSeq, error) {
+	r.Entry.Tracef("GetMailboxHighestModSeq")
+
+	return r.RD.GetMailboxHighestModSeq(ctx, mboxID)
+}
+
+func (r ReadTracer) GetMailboxExpungedUIDsSince(ctx context.Context, mboxID imap.InternalMailboxID, modSeq imap.ModSeq) ([]imap.UID, error) {
+	r.Entry.Tracef("GetMailboxExpungedUIDsSince")
+
+	return r.RD.GetMailboxExpungedUIDsSince(ctx, mboxID, modSeq)
+}
+
+func (r ReadTracer) GetMailboxPrunedExpungedModSeq(ctx context.Context, mboxID imap.InternalMailboxID) (imap.ModSeq, error) {
+	r.Entry.Tracef("GetMailboxPrunedExpungedModSeq")
+
+	return r.RD.GetMailboxPrunedExpungedModSeq(ctx, mboxID)
+}
+
 func (r ReadTracer) GetMailboxMessageCountAndUID(ctx context.Context, mboxID imap.InternalMailboxID) (int, imap.UID, error) {
 	r.Entry.Tracef("GetMailboxMessageCountAndUID")
 
 	return r.RD.GetMailboxMessageCountAndUID(ctx, mboxID)
 }
 
+func (r ReadTracer) GetMailboxMessageIDWithUID(ctx context.Context, mboxID imap.InternalMailboxID, uid imap.UID) (db.MessageIDPair, error) {
+	r.Entry.Tracef("GetMailboxMessageIDWithUID")
+
+	return r.RD.GetMailboxMessageIDWithUID(ctx, mboxID, uid)
+}
+
 func (r ReadTracer) GetMailboxMessageForNewSnapshot(ctx context.Context, mboxID imap.InternalMailboxID) ([]db.SnapshotMessageResult, error) {
 	r.Entry.Tracef("GetMailboxMessagesForNewSnapshot")
 
@@ -183,6 +219,18 @@ func (r ReadTracer) GetTotalMessageCount(ctx context.Context) (int, error) {
 	return r.RD.GetTotalMessageCount(ctx)
 }
 
+func (r ReadTracer) GetTotalActiveMessageCount(ctx context.Context) (int, error) {
+	r.Entry.Tracef("GetTotalActiveMessageCount")
+
+	return r.RD.GetTotalActiveMessageCount(ctx)
+}
+
+func (r ReadTracer) GetTotalMessageSize(ctx context.Context) (int64, error) {
+	r.Entry.Tracef("GetTotalMessageSize")
+
+	return r.RD.GetTotalMessageSize(ctx)
+}
+
 func (r ReadTracer) GetMessageRemoteID(ctx context.Context, id imap.InternalMessageID) (imap.MessageID, error) {
 	r.Entry.Tracef("GetMessageRemoteID")
 
@@ -201,6 +249,24 @@ func (r ReadTracer) GetMessageDateAndSize(ctx context.Context, id imap.InternalM
 	return r.RD.GetMessageDateAndSize(ctx, id)
 }
 
+func (r ReadTracer) GetMessagesModSeq(ctx context.Context, mboxID imap.InternalMailboxID, ids []imap.InternalMessageID) (map[imap.InternalMessageID]imap.ModSeq, error) {
+	r.Entry.Tracef("GetMessagesModSeq")
+
+	return r.RD.GetMessagesModSeq(ctx, mboxID, ids)
+}
+
+func (r ReadTracer) GetMessagesThreadID(ctx context.Context, ids []imap.InternalMessageID) (map[imap.InternalMessageID]string, error) {
+	r.Entry.Tracef("GetMessagesThreadID")
+
+	return r.RD.GetMessagesThreadID(ctx, ids)
+}
+
+func (r ReadTracer) GetMessagesSaveDate(ctx context.Context, mboxID imap.InternalMailboxID, ids []imap.InternalMessageID) (map[imap.InternalMessageID]time.Time, error) {
+	r.Entry.Tracef("GetMessagesSaveDate")
+
+	return r.RD.GetMessagesSaveDate(ctx, mboxID, ids)
+}
+
 func (r ReadTracer) GetMessageMailboxIDs(ctx context.Context, id imap.InternalMessageID) ([]imap.InternalMailboxID, error) {
 	r.Entry.Tracef("GetMessageMailboxIDs")
 
@@ -249,6 +315,18 @@ func (r ReadTracer) GetConnectorSettings(ctx context.Context) (string, bool, err
 	return r.RD.GetConnectorSettings(ctx)
 }
 
+func (r ReadTracer) GetMetadata(ctx context.Context, mboxID imap.InternalMailboxID) ([]imap.MetadataEntry, error) {
+	r.Entry.Tracef("GetMetadata")
+
+	return r.RD.GetMetadata(ctx, mboxID)
+}
+
+func (r ReadTracer) GetMailboxAccessKey(ctx context.Context, mboxID imap.InternalMailboxID) ([]byte, error) {
+	r.Entry.Tracef("GetMailboxAccessKey")
+
+	return r.RD.GetMailboxAccessKey(ctx, mboxID)
+}
+
 func (r ReadTracer) GetAllMailboxesNameAndRemoteID(ctx context.Context) ([]db.MailboxNameAndRemoteID, error) {
 	r.Entry.Tracef("GetAllMailboxesNameAndRemoteID")
 
@@ -425,6 +503,12 @@ func (w WriteTracer) UpdateRemoteMessageID(ctx context.Context, internalID imap.
 	return w.TX.UpdateRemoteMessageID(ctx, internalID, remoteID)
 }
 
+func (w WriteTracer) SetMessagePreview(ctx context.Context, id imap.InternalMessageID, preview string) error {
+	w.Entry.Tracef("SetMessagePreview")
+
+	return w.TX.SetMessagePreview(ctx, id, preview)
+}
+
 func (w WriteTracer) AddFlagToMessages(ctx context.Context, ids []imap.InternalMessageID, flag string) error {
 	w.Entry.Tracef("AddFlagsToMessage")
 
@@ -461,6 +545,24 @@ func (w WriteTracer) StoreConnectorSettings(ctx context.Context, settings string
 	return w.TX.StoreConnectorSettings(ctx, settings)
 }
 
+func (w WriteTracer) SetMetadata(ctx context.Context, mboxID imap.InternalMailboxID, entries []imap.MetadataEntry) error {
+	w.Entry.Tracef("SetMetadata")
+
+	return w.TX.SetMetadata(ctx, mboxID, entries)
+}
+
+func (w WriteTracer) SetMailboxAccessKey(ctx context.Context, mboxID imap.InternalMailboxID, key []byte) error {
+	w.Entry.Tracef("SetMailboxAccessKey")
+
+	return w.TX.SetMailboxAccessKey(ctx, mboxID, key)
+}
+
+func (w WriteTracer) DeleteMailboxAccessKeys(ctx context.Context, mboxIDs ...imap.InternalMailboxID) error {
+	w.Entry.Tracef("DeleteMailboxAccessKeys")
+
+	return w.TX.DeleteMailboxAccessKeys(ctx, mboxIDs...)
+}
+
 func (w WriteTracer) AddFlagsToAllMailboxes(ctx context.Context, flags ...string) error {
 	w.Entry.Tracef("AddFlagsToAllMailboxes")
 
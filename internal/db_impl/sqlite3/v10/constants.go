@@ -0,0 +1,3 @@
+package v10
+
+const MessageToMailboxFieldSaveDate = "save_date"
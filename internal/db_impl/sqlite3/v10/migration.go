@@ -0,0 +1,26 @@
+package v10
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ProtonMail/gluon/imap"
+	"github.com/ProtonMail/gluon/internal/db_impl/sqlite3/utils"
+	v1 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v1"
+)
+
+type Migration struct{}
+
+func (m Migration) Run(ctx context.Context, tx utils.QueryWrapper, _ imap.UIDValidityGenerator) error {
+	// Add the date messages were saved in their mailboxes; it is NULL for messages which were already there.
+	query := fmt.Sprintf("ALTER TABLE %v ADD COLUMN `%v` datetime",
+		v1.MessageToMailboxTableName,
+		MessageToMailboxFieldSaveDate,
+	)
+
+	if _, err := utils.ExecQuery(ctx, tx, query); err != nil {
+		return fmt.Errorf("failed to add save date to message to mailbox table: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,3 @@
+package v11
+
+const MessageToMailboxFieldModSeq = "modseq"
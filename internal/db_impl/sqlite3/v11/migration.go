@@ -0,0 +1,26 @@
+package v11
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ProtonMail/gluon/imap"
+	"github.com/ProtonMail/gluon/internal/db_impl/sqlite3/utils"
+	v1 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v1"
+)
+
+type Migration struct{}
+
+func (m Migration) Run(ctx context.Context, tx utils.QueryWrapper, _ imap.UIDValidityGenerator) error {
+	// Add the mod sequence of changes which only affect the message in one mailbox, such as its \Deleted flag.
+	query := fmt.Sprintf("ALTER TABLE %v ADD COLUMN `%v` INTEGER NOT NULL DEFAULT 0",
+		v1.MessageToMailboxTableName,
+		MessageToMailboxFieldModSeq,
+	)
+
+	if _, err := utils.ExecQuery(ctx, tx, query); err != nil {
+		return fmt.Errorf("failed to add mod sequence to message to mailbox table: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,5 @@
+package v12
+
+const PrunedExpungedUIDsTableName = "pruned_expunged_uids"
+const PrunedExpungedUIDsFieldMailboxID = "mailbox_id"
+const PrunedExpungedUIDsFieldModSeq = "modseq"
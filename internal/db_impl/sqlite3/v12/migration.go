@@ -0,0 +1,31 @@
+package v12
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ProtonMail/gluon/imap"
+	"github.com/ProtonMail/gluon/internal/db_impl/sqlite3/utils"
+	v1 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v1"
+)
+
+type Migration struct{}
+
+func (m Migration) Run(ctx context.Context, tx utils.QueryWrapper, _ imap.UIDValidityGenerator) error {
+	// Create the table of the highest mod sequence of the expunged UIDs pruned from each mailbox.
+	query := fmt.Sprintf("CREATE TABLE `%[1]v` (`%[2]v` integer NOT NULL PRIMARY KEY, `%[3]v` integer NOT NULL, "+
+		"CONSTRAINT `pruned_expunged_uids_mailbox_id` FOREIGN KEY (`%[2]v`) REFERENCES `%[4]v` (`%[5]v`) ON DELETE CASCADE"+
+		")",
+		PrunedExpungedUIDsTableName,
+		PrunedExpungedUIDsFieldMailboxID,
+		PrunedExpungedUIDsFieldModSeq,
+		v1.MailboxesTableName,
+		v1.MailboxesFieldID,
+	)
+
+	if _, err := utils.ExecQuery(ctx, tx, query); err != nil {
+		return fmt.Errorf("failed to create pruned expunged uids table: %w", err)
+	}
+
+	return nil
+}
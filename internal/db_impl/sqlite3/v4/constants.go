@@ -0,0 +1,10 @@
+package v4
+
+const ModSeqTableName = "mod_seq"
+const ModSeqFieldID = "id"
+const ModSeqFieldValue = "value"
+const ModSeqDefaultID = 0
+
+const MessagesFieldModSeq = "modseq"
+
+const MailboxesFieldHighestModSeq = "highest_modseq"
@@ -0,0 +1,63 @@
+package v4
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ProtonMail/gluon/imap"
+	"github.com/ProtonMail/gluon/internal/db_impl/sqlite3/utils"
+	v1 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v1"
+)
+
+type Migration struct{}
+
+func (m Migration) Run(ctx context.Context, tx utils.QueryWrapper, _ imap.UIDValidityGenerator) error {
+	// Create mod sequence counter table.
+	{
+		query := fmt.Sprintf("CREATE TABLE `%v` (`%v` INTEGER NOT NULL PRIMARY KEY, `%v` INTEGER NOT NULL)",
+			ModSeqTableName,
+			ModSeqFieldID,
+			ModSeqFieldValue,
+		)
+
+		if _, err := utils.ExecQuery(ctx, tx, query); err != nil {
+			return fmt.Errorf("failed to create mod sequence table: %w", err)
+		}
+
+		query = fmt.Sprintf("INSERT INTO %v (`%v`, `%v`) VALUES (?, 1)",
+			ModSeqTableName,
+			ModSeqFieldID,
+			ModSeqFieldValue,
+		)
+
+		if _, err := utils.ExecQuery(ctx, tx, query, ModSeqDefaultID); err != nil {
+			return fmt.Errorf("failed to create default mod sequence entry: %w", err)
+		}
+	}
+
+	// Add mod sequence to messages.
+	{
+		query := fmt.Sprintf("ALTER TABLE %v ADD COLUMN `%v` INTEGER NOT NULL DEFAULT 1",
+			v1.MessagesTableName,
+			MessagesFieldModSeq,
+		)
+
+		if _, err := utils.ExecQuery(ctx, tx, query); err != nil {
+			return fmt.Errorf("failed to add mod sequence to messages table: %w", err)
+		}
+	}
+
+	// Add highest mod sequence to mailboxes.
+	{
+		query := fmt.Sprintf("ALTER TABLE %v ADD COLUMN `%v` INTEGER NOT NULL DEFAULT 1",
+			v1.MailboxesTableName,
+			MailboxesFieldHighestModSeq,
+		)
+
+		if _, err := utils.ExecQuery(ctx, tx, query); err != nil {
+			return fmt.Errorf("failed to add highest mod sequence to mailboxes table: %w", err)
+		}
+	}
+
+	return nil
+}
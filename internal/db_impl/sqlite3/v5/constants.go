@@ -0,0 +1,6 @@
+package v5
+
+const ExpungedUIDsTableName = "expunged_uids"
+const ExpungedUIDsFieldMailboxID = "mailbox_id"
+const ExpungedUIDsFieldUID = "uid"
+const ExpungedUIDsFieldModSeq = "modseq"
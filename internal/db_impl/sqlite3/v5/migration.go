@@ -0,0 +1,48 @@
+package v5
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ProtonMail/gluon/imap"
+	"github.com/ProtonMail/gluon/internal/db_impl/sqlite3/utils"
+	v1 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v1"
+)
+
+type Migration struct{}
+
+func (m Migration) Run(ctx context.Context, tx utils.QueryWrapper, _ imap.UIDValidityGenerator) error {
+	// Create expunged UIDs table.
+	{
+		query := fmt.Sprintf("CREATE TABLE `%[1]v` (`%[2]v` integer NOT NULL, `%[3]v` integer NOT NULL, `%[4]v` integer NOT NULL, "+
+			"CONSTRAINT `expunged_uids_mailbox_id` FOREIGN KEY (`%[2]v`) REFERENCES `%[5]v` (`%[6]v`) ON DELETE CASCADE, "+
+			"PRIMARY KEY (%[2]v, %[3]v)"+
+			")",
+			ExpungedUIDsTableName,
+			ExpungedUIDsFieldMailboxID,
+			ExpungedUIDsFieldUID,
+			ExpungedUIDsFieldModSeq,
+			v1.MailboxesTableName,
+			v1.MailboxesFieldID,
+		)
+
+		if _, err := utils.ExecQuery(ctx, tx, query); err != nil {
+			return fmt.Errorf("failed to create expunged uids table: %w", err)
+		}
+	}
+
+	// Create index on mod sequence to speed up VANISHED (EARLIER) lookups.
+	{
+		query := fmt.Sprintf("CREATE INDEX `expunged_uids_modseq` ON `%v` (`%v`, `%v`)",
+			ExpungedUIDsTableName,
+			ExpungedUIDsFieldMailboxID,
+			ExpungedUIDsFieldModSeq,
+		)
+
+		if _, err := utils.ExecQuery(ctx, tx, query); err != nil {
+			return fmt.Errorf("failed to create expunged uids index: %w", err)
+		}
+	}
+
+	return nil
+}
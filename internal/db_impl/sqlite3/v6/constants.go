@@ -0,0 +1,6 @@
+package v6
+
+const MetadataTableName = "metadata"
+const MetadataFieldMailboxID = "mailbox_id"
+const MetadataFieldName = "name"
+const MetadataFieldValue = "value"
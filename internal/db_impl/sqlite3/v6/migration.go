@@ -0,0 +1,47 @@
+package v6
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ProtonMail/gluon/imap"
+	"github.com/ProtonMail/gluon/internal/db_impl/sqlite3/utils"
+	v1 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v1"
+)
+
+type Migration struct{}
+
+func (m Migration) Run(ctx context.Context, tx utils.QueryWrapper, _ imap.UIDValidityGenerator) error {
+	// Create metadata table. Server entries have no mailbox ID.
+	{
+		query := fmt.Sprintf("CREATE TABLE `%[1]v` (`%[2]v` integer NULL, `%[3]v` text NOT NULL, `%[4]v` blob NOT NULL, "+
+			"CONSTRAINT `metadata_mailbox_id` FOREIGN KEY (`%[2]v`) REFERENCES `%[5]v` (`%[6]v`) ON DELETE CASCADE"+
+			")",
+			MetadataTableName,
+			MetadataFieldMailboxID,
+			MetadataFieldName,
+			MetadataFieldValue,
+			v1.MailboxesTableName,
+			v1.MailboxesFieldID,
+		)
+
+		if _, err := utils.ExecQuery(ctx, tx, query); err != nil {
+			return fmt.Errorf("failed to create metadata table: %w", err)
+		}
+	}
+
+	// Create index on mailbox ID and entry name to speed up lookups.
+	{
+		query := fmt.Sprintf("CREATE INDEX `metadata_mailbox_id_name` ON `%v` (`%v`, `%v`)",
+			MetadataTableName,
+			MetadataFieldMailboxID,
+			MetadataFieldName,
+		)
+
+		if _, err := utils.ExecQuery(ctx, tx, query); err != nil {
+			return fmt.Errorf("failed to create metadata index: %w", err)
+		}
+	}
+
+	return nil
+}
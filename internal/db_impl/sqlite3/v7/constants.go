@@ -0,0 +1,5 @@
+package v7
+
+const AccessKeysTableName = "access_keys"
+const AccessKeysFieldMailboxID = "mailbox_id"
+const AccessKeysFieldValue = "value"
@@ -0,0 +1,3 @@
+package v8
+
+const MessagesFieldThreadID = "thread_id"
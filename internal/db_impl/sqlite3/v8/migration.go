@@ -0,0 +1,26 @@
+package v8
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ProtonMail/gluon/imap"
+	"github.com/ProtonMail/gluon/internal/db_impl/sqlite3/utils"
+	v1 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v1"
+)
+
+type Migration struct{}
+
+func (m Migration) Run(ctx context.Context, tx utils.QueryWrapper, _ imap.UIDValidityGenerator) error {
+	// Add the connector's thread ID to messages; it is empty for existing messages.
+	query := fmt.Sprintf("ALTER TABLE %v ADD COLUMN `%v` TEXT NOT NULL DEFAULT ''",
+		v1.MessagesTableName,
+		MessagesFieldThreadID,
+	)
+
+	if _, err := utils.ExecQuery(ctx, tx, query); err != nil {
+		return fmt.Errorf("failed to add thread ID to messages table: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,3 @@
+package v9
+
+const MessagesFieldPreview = "preview"
@@ -0,0 +1,26 @@
+package v9
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ProtonMail/gluon/imap"
+	"github.com/ProtonMail/gluon/internal/db_impl/sqlite3/utils"
+	v1 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v1"
+)
+
+type Migration struct{}
+
+func (m Migration) Run(ctx context.Context, tx utils.QueryWrapper, _ imap.UIDValidityGenerator) error {
+	// Add the message preview; it is NULL for existing messages, whose preview is computed on demand.
+	query := fmt.Sprintf("ALTER TABLE %v ADD COLUMN `%v` TEXT",
+		v1.MessagesTableName,
+		MessagesFieldPreview,
+	)
+
+	if _, err := utils.ExecQuery(ctx, tx, query); err != nil {
+		return fmt.Errorf("failed to add preview to messages table: %w", err)
+	}
+
+	return nil
+}
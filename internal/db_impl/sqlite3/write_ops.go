@@ -5,15 +5,28 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/ProtonMail/gluon/db"
 	"github.com/ProtonMail/gluon/imap"
 	"github.com/ProtonMail/gluon/internal/db_impl/sqlite3/utils"
 	v1 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v1"
+	v10 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v10"
+	v11 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v11"
+	v12 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v12"
 	v2 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v2"
+	v4 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v4"
+	v5 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v5"
+	v6 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v6"
+	v7 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v7"
+	v8 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v8"
+	v9 "github.com/ProtonMail/gluon/internal/db_impl/sqlite3/v9"
 	"github.com/bradenaw/juniper/xslices"
 )
 
+// maxExpungedUIDs is the number of expunged UIDs kept per mailbox for VANISHED (EARLIER) responses (RFC7162).
+var maxExpungedUIDs = 10000
+
 type writeOps struct {
 	readOps
 	qw utils.QueryWrapper
@@ -26,12 +39,19 @@ func (w writeOps) CreateMailbox(
 	flags, permFlags, attrs imap.FlagSet,
 	uidValidity imap.UID,
 ) (*db.Mailbox, error) {
-	createMBoxQuery := fmt.Sprintf("INSERT INTO %v (`%v`, `%v`, `%v`, `%v`) VALUES (?,?,?,?) RETURNING `%v`",
+	// Start from the current mod sequence so that a re-created mailbox never reports an older value.
+	modSeq, err := w.getModSeq(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	createMBoxQuery := fmt.Sprintf("INSERT INTO %v (`%v`, `%v`, `%v`, `%v`, `%v`) VALUES (?,?,?,?,?) RETURNING `%v`",
 		v1.MailboxesTableName,
 		v1.MailboxesFieldRemoteID,
 		v1.MailboxesFieldName,
 		v1.MailboxesFieldUIDValidity,
 		v1.MailboxesFieldSubscribed,
+		v4.MailboxesFieldHighestModSeq,
 		v1.MailboxesFieldID,
 	)
 
@@ -40,6 +60,7 @@ func (w writeOps) CreateMailbox(
 		name,
 		uidValidity,
 		true,
+		modSeq,
 	)
 	if err != nil {
 		return nil, err
@@ -89,11 +110,12 @@ func (w writeOps) CreateMailbox(
 	}
 
 	return &db.Mailbox{
-		ID:          internalID,
-		RemoteID:    mboxID,
-		Name:        name,
-		UIDValidity: uidValidity,
-		Subscribed:  true,
+		ID:            internalID,
+		RemoteID:      mboxID,
+		Name:          name,
+		UIDValidity:   uidValidity,
+		Subscribed:    true,
+		HighestModSeq: modSeq,
 	}, nil
 }
 
@@ -183,7 +205,7 @@ func (w writeOps) AddMessagesToMailbox(
 		return nil, nil
 	}
 
-	for _, chunk := range xslices.Chunk(messageIDs, db.ChunkLimit/2) {
+	for _, chunk := range xslices.Chunk(messageIDs, db.ChunkLimit/3) {
 		// Insert into Mailbox table.
 		{
 			query := fmt.Sprintf("INSERT INTO %v (`%v`, `%v`) VALUES %v",
@@ -206,17 +228,20 @@ func (w writeOps) AddMessagesToMailbox(
 
 		// Insert into Message To Mailbox table.
 		{
-			query := fmt.Sprintf("INSERT INTO %v (`%v`, `%v`) VALUES %v",
+			query := fmt.Sprintf("INSERT INTO %v (`%v`, `%v`, `%v`) VALUES %v",
 				v1.MessageToMailboxTableName,
 				v1.MessageToMailboxFieldMessageID,
 				v1.MessageToMailboxFieldMailboxID,
-				strings.Join(xslices.Repeat("(?,?)", len(chunk)), ","),
+				v10.MessageToMailboxFieldSaveDate,
+				strings.Join(xslices.Repeat("(?,?,?)", len(chunk)), ","),
 			)
 
-			args := make([]any, 0, 2*len(chunk))
+			args := make([]any, 0, 3*len(chunk))
+
+			saveDate := time.Now()
 
 			for _, id := range chunk {
-				args = append(args, id.InternalID, mboxID)
+				args = append(args, id.InternalID, mboxID, saveDate)
 			}
 
 			if _, err := utils.ExecQuery(ctx, w.qw, query, args...); err != nil {
@@ -225,13 +250,50 @@ func (w writeOps) AddMessagesToMailbox(
 		}
 	}
 
-	return w.GetMailboxMessageUIDsWithFlagsAfterAddOrUIDBump(ctx, mboxID, xslices.Map(messageIDs, func(t db.MessageIDPair) imap.InternalMessageID {
+	internalIDs := xslices.Map(messageIDs, func(t db.MessageIDPair) imap.InternalMessageID {
 		return t.InternalID
-	}))
+	})
+
+	if err := w.bumpMessagesModSeq(ctx, internalIDs); err != nil {
+		return nil, err
+	}
+
+	return w.GetMailboxMessageUIDsWithFlagsAfterAddOrUIDBump(ctx, mboxID, internalIDs)
 }
 
 func (w writeOps) RemoveMessagesFromMailbox(ctx context.Context, mboxID imap.InternalMailboxID, messageIDs []imap.InternalMessageID) error {
+	if len(messageIDs) == 0 {
+		return nil
+	}
+
+	modSeq, err := w.nextModSeq(ctx)
+	if err != nil {
+		return err
+	}
+
 	for _, chunk := range xslices.Chunk(messageIDs, db.ChunkLimit) {
+		// Record the expunged UIDs.
+		{
+			query := fmt.Sprintf("INSERT OR REPLACE INTO %v (`%v`, `%v`, `%v`) SELECT ?, `%v`, ? FROM %v WHERE `%v` IN (%v)",
+				v5.ExpungedUIDsTableName,
+				v5.ExpungedUIDsFieldMailboxID,
+				v5.ExpungedUIDsFieldUID,
+				v5.ExpungedUIDsFieldModSeq,
+				v1.MailboxMessagesFieldUID,
+				v1.MailboxMessageTableName(mboxID),
+				v1.MailboxMessagesFieldMessageID,
+				utils.GenSQLIn(len(chunk)),
+			)
+
+			args := make([]any, 0, len(chunk)+2)
+			args = append(args, mboxID, modSeq)
+			args = append(args, utils.MapSliceToAny(chunk)...)
+
+			if _, err := utils.ExecQuery(ctx, w.qw, query, args...); err != nil {
+				return err
+			}
+		}
+
 		// Delete from mailbox table.
 		{
 			query := fmt.Sprintf("DELETE FROM %v WHERE `%v` IN (%v)",
@@ -240,7 +302,7 @@ func (w writeOps) RemoveMessagesFromMailbox(ctx context.Context, mboxID imap.Int
 				utils.GenSQLIn(len(chunk)),
 			)
 
-			if _, err := utils.ExecQuery(ctx, w.qw, query, utils.MapSliceToAny(messageIDs)...); err != nil {
+			if _, err := utils.ExecQuery(ctx, w.qw, query, utils.MapSliceToAny(chunk)...); err != nil {
 				return err
 			}
 		}
@@ -254,13 +316,17 @@ func (w writeOps) RemoveMessagesFromMailbox(ctx context.Context, mboxID imap.Int
 				v1.MessageToMailboxFieldMailboxID,
 			)
 
-			if _, err := utils.ExecQuery(ctx, w.qw, query, append(utils.MapSliceToAny(messageIDs), mboxID)...); err != nil {
+			if _, err := utils.ExecQuery(ctx, w.qw, query, append(utils.MapSliceToAny(chunk), mboxID)...); err != nil {
 				return err
 			}
 		}
 	}
 
-	return nil
+	if err := w.pruneExpungedUIDs(ctx, mboxID); err != nil {
+		return err
+	}
+
+	return w.setMailboxHighestModSeq(ctx, mboxID, modSeq)
 }
 
 func (w writeOps) ClearRecentFlagInMailboxOnMessage(ctx context.Context, mboxID imap.InternalMailboxID, messageID imap.InternalMessageID) error {
@@ -311,7 +377,8 @@ func (w writeOps) SetMailboxMessagesDeletedFlag(ctx context.Context, mboxID imap
 		}
 	}
 
-	return nil
+	// The deleted flag is specific to the mailbox, so the messages don't change in their other mailboxes.
+	return w.bumpMailboxMessagesModSeq(ctx, mboxID, messageIDs)
 }
 
 func (w writeOps) SetMailboxSubscribed(ctx context.Context, mboxID imap.InternalMailboxID, subscribed bool) error {
@@ -343,12 +410,24 @@ func (w writeOps) SetMailboxUIDValidity(ctx context.Context, mboxID imap.Interna
 		v1.MailboxesFieldID,
 	)
 
-	return utils.ExecQueryAndCheckUpdatedNotZero(ctx, w.qw, query, uidValidity, mboxID)
+	if err := utils.ExecQueryAndCheckUpdatedNotZero(ctx, w.qw, query, uidValidity, mboxID); err != nil {
+		return err
+	}
+
+	// UIDs recorded for the previous UID validity are meaningless now.
+	expungedQuery := fmt.Sprintf("DELETE FROM %v WHERE `%v` = ?",
+		v5.ExpungedUIDsTableName,
+		v5.ExpungedUIDsFieldMailboxID,
+	)
+
+	_, err := utils.ExecQuery(ctx, w.qw, expungedQuery, mboxID)
+
+	return err
 }
 
 func (w writeOps) CreateMessages(ctx context.Context, reqs ...*db.CreateMessageReq) error {
 	for _, chunk := range xslices.Chunk(reqs, db.ChunkLimit) {
-		createMessageQuery := fmt.Sprintf("INSERT INTO %v (`%v`, `%v`, `%v`, `%v`, `%v`, `%v`, `%v`) VALUES %v",
+		createMessageQuery := fmt.Sprintf("INSERT INTO %v (`%v`, `%v`, `%v`, `%v`, `%v`, `%v`, `%v`, `%v`, `%v`) VALUES %v",
 			v1.MessagesTableName,
 			v1.MessagesFieldID,
 			v1.MessagesFieldRemoteID,
@@ -357,10 +436,12 @@ func (w writeOps) CreateMessages(ctx context.Context, reqs ...*db.CreateMessageR
 			v1.MessagesFieldBody,
 			v1.MessagesFieldBodyStructure,
 			v1.MessagesFieldEnvelope,
-			strings.Join(xslices.Repeat("(?,?,?,?,?,?,?)", len(chunk)), ","),
+			v8.MessagesFieldThreadID,
+			v9.MessagesFieldPreview,
+			strings.Join(xslices.Repeat("(?,?,?,?,?,?,?,?,?)", len(chunk)), ","),
 		)
 
-		args := make([]any, 0, len(chunk)*6)
+		args := make([]any, 0, len(chunk)*9)
 		flagArgs := make([]any, 0, len(chunk)*2)
 
 		for _, req := range chunk {
@@ -371,7 +452,9 @@ func (w writeOps) CreateMessages(ctx context.Context, reqs ...*db.CreateMessageR
 				req.LiteralSize,
 				req.Body,
 				req.Structure,
-				req.Envelope)
+				req.Envelope,
+				req.Message.ThreadID,
+				req.Preview)
 
 			for _, f := range req.Message.Flags.ToSliceUnsorted() {
 				flagArgs = append(flagArgs, req.InternalID, f)
@@ -400,7 +483,7 @@ func (w writeOps) CreateMessages(ctx context.Context, reqs ...*db.CreateMessageR
 }
 
 func (w writeOps) CreateMessageAndAddToMailbox(ctx context.Context, mbox imap.InternalMailboxID, req *db.CreateMessageReq) (imap.UID, imap.FlagSet, error) {
-	createMessageQuery := fmt.Sprintf("INSERT INTO %v (`%v`, `%v`, `%v`, `%v`, `%v`, `%v`, `%v`) VALUES (?,?,?,?,?,?,?)",
+	createMessageQuery := fmt.Sprintf("INSERT INTO %v (`%v`, `%v`, `%v`, `%v`, `%v`, `%v`, `%v`, `%v`, `%v`) VALUES (?,?,?,?,?,?,?,?,?)",
 		v1.MessagesTableName,
 		v1.MessagesFieldID,
 		v1.MessagesFieldRemoteID,
@@ -409,6 +492,8 @@ func (w writeOps) CreateMessageAndAddToMailbox(ctx context.Context, mbox imap.In
 		v1.MessagesFieldBody,
 		v1.MessagesFieldBodyStructure,
 		v1.MessagesFieldEnvelope,
+		v8.MessagesFieldThreadID,
+		v9.MessagesFieldPreview,
 	)
 
 	if _, err := utils.ExecQuery(ctx, w.qw,
@@ -420,6 +505,8 @@ func (w writeOps) CreateMessageAndAddToMailbox(ctx context.Context, mbox imap.In
 		req.Body,
 		req.Structure,
 		req.Envelope,
+		req.Message.ThreadID,
+		req.Preview,
 	); err != nil {
 		return 0, imap.FlagSet{}, err
 	}
@@ -443,13 +530,14 @@ func (w writeOps) CreateMessageAndAddToMailbox(ctx context.Context, mbox imap.In
 	}
 
 	{
-		query := fmt.Sprintf("INSERT INTO %v (`%v`, `%v`) VALUES (?,?)",
+		query := fmt.Sprintf("INSERT INTO %v (`%v`, `%v`, `%v`) VALUES (?,?,?)",
 			v1.MessageToMailboxTableName,
 			v1.MessageToMailboxFieldMessageID,
 			v1.MessageToMailboxFieldMailboxID,
+			v10.MessageToMailboxFieldSaveDate,
 		)
 
-		if _, err := utils.ExecQuery(ctx, w.qw, query, req.InternalID, mbox); err != nil {
+		if _, err := utils.ExecQuery(ctx, w.qw, query, req.InternalID, mbox, time.Now()); err != nil {
 			return 0, imap.FlagSet{}, err
 		}
 	}
@@ -466,6 +554,10 @@ func (w writeOps) CreateMessageAndAddToMailbox(ctx context.Context, mbox imap.In
 		return 0, imap.FlagSet{}, err
 	}
 
+	if err := w.bumpMessagesModSeq(ctx, []imap.InternalMessageID{req.InternalID}); err != nil {
+		return 0, imap.FlagSet{}, err
+	}
+
 	flags := req.Message.Flags.Add(imap.FlagRecent)
 
 	return mboxUID, flags, nil
@@ -536,6 +628,18 @@ func (w writeOps) UpdateRemoteMessageID(ctx context.Context, internalID imap.Int
 	return utils.ExecQueryAndCheckUpdatedNotZero(ctx, w.qw, query, remoteID, internalID)
 }
 
+func (w writeOps) SetMessagePreview(ctx context.Context, id imap.InternalMessageID, preview string) error {
+	query := fmt.Sprintf("UPDATE %v SET `%v` = ? WHERE `%v` = ?",
+		v1.MessagesTableName,
+		v9.MessagesFieldPreview,
+		v1.MessagesFieldID,
+	)
+
+	_, err := utils.ExecQuery(ctx, w.qw, query, preview, id)
+
+	return err
+}
+
 func (w writeOps) AddFlagToMessages(ctx context.Context, ids []imap.InternalMessageID, flag string) error {
 	for _, chunk := range xslices.Chunk(ids, db.ChunkLimit) {
 		query := fmt.Sprintf("INSERT OR IGNORE INTO %v (`%v`, `%v`) VALUES %v",
@@ -556,7 +660,7 @@ func (w writeOps) AddFlagToMessages(ctx context.Context, ids []imap.InternalMess
 		}
 	}
 
-	return nil
+	return w.bumpMessagesModSeq(ctx, ids)
 }
 
 func (w writeOps) RemoveFlagFromMessages(ctx context.Context, ids []imap.InternalMessageID, flag string) error {
@@ -573,7 +677,7 @@ func (w writeOps) RemoveFlagFromMessages(ctx context.Context, ids []imap.Interna
 		}
 	}
 
-	return nil
+	return w.bumpMessagesModSeq(ctx, ids)
 }
 
 func (w writeOps) SetFlagsOnMessages(ctx context.Context, ids []imap.InternalMessageID, flags imap.FlagSet) error {
@@ -619,7 +723,7 @@ func (w writeOps) SetFlagsOnMessages(ctx context.Context, ids []imap.InternalMes
 		}
 	}
 
-	return nil
+	return w.bumpMessagesModSeq(ctx, ids)
 }
 
 func (w writeOps) AddDeletedSubscription(ctx context.Context, mboxName string, mboxID imap.MailboxID) error {
@@ -709,3 +813,216 @@ func (w writeOps) AddPermFlagsToAllMailboxes(ctx context.Context, flags ...strin
 
 	return err
 }
+
+func (w writeOps) SetMetadata(ctx context.Context, mboxID imap.InternalMailboxID, entries []imap.MetadataEntry) error {
+	deleteQuery := fmt.Sprintf("DELETE FROM %v WHERE `%v` IS ? AND `%v` = ?",
+		v6.MetadataTableName,
+		v6.MetadataFieldMailboxID,
+		v6.MetadataFieldName,
+	)
+
+	insertQuery := fmt.Sprintf("INSERT INTO %v (`%v`, `%v`, `%v`) VALUES (?, ?, ?)",
+		v6.MetadataTableName,
+		v6.MetadataFieldMailboxID,
+		v6.MetadataFieldName,
+		v6.MetadataFieldValue,
+	)
+
+	for _, entry := range entries {
+		if _, err := utils.ExecQuery(ctx, w.qw, deleteQuery, metadataMailboxID(mboxID), entry.Name); err != nil {
+			return err
+		}
+
+		if entry.Value == nil {
+			continue
+		}
+
+		if _, err := utils.ExecQuery(ctx, w.qw, insertQuery, metadataMailboxID(mboxID), entry.Name, entry.Value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w writeOps) SetMailboxAccessKey(ctx context.Context, mboxID imap.InternalMailboxID, key []byte) error {
+	query := fmt.Sprintf("INSERT OR REPLACE INTO %v (`%v`, `%v`) VALUES (?, ?)",
+		v7.AccessKeysTableName,
+		v7.AccessKeysFieldMailboxID,
+		v7.AccessKeysFieldValue,
+	)
+
+	_, err := utils.ExecQuery(ctx, w.qw, query, mboxID, key)
+
+	return err
+}
+
+func (w writeOps) DeleteMailboxAccessKeys(ctx context.Context, mboxIDs ...imap.InternalMailboxID) error {
+	if len(mboxIDs) == 0 {
+		_, err := utils.ExecQuery(ctx, w.qw, fmt.Sprintf("DELETE FROM %v", v7.AccessKeysTableName))
+
+		return err
+	}
+
+	query := fmt.Sprintf("DELETE FROM %v WHERE `%v` IN (%v)",
+		v7.AccessKeysTableName,
+		v7.AccessKeysFieldMailboxID,
+		utils.GenSQLIn(len(mboxIDs)),
+	)
+
+	_, err := utils.ExecQuery(ctx, w.qw, query, utils.MapSliceToAny(mboxIDs)...)
+
+	return err
+}
+
+// getModSeq returns the current value of the mod sequence counter.
+func (w writeOps) getModSeq(ctx context.Context) (imap.ModSeq, error) {
+	query := fmt.Sprintf("SELECT `%v` FROM %v WHERE `%v` = ?",
+		v4.ModSeqFieldValue,
+		v4.ModSeqTableName,
+		v4.ModSeqFieldID,
+	)
+
+	return utils.MapQueryRow[imap.ModSeq](ctx, w.qw, query, v4.ModSeqDefaultID)
+}
+
+// nextModSeq increments the mod sequence counter and returns the new value.
+func (w writeOps) nextModSeq(ctx context.Context) (imap.ModSeq, error) {
+	query := fmt.Sprintf("UPDATE %[1]v SET `%[2]v` = `%[2]v` + 1 WHERE `%[3]v` = ? RETURNING `%[2]v`",
+		v4.ModSeqTableName,
+		v4.ModSeqFieldValue,
+		v4.ModSeqFieldID,
+	)
+
+	return utils.MapQueryRow[imap.ModSeq](ctx, w.qw, query, v4.ModSeqDefaultID)
+}
+
+// bumpMessagesModSeq assigns a new mod sequence to the given messages and to every mailbox which contains them.
+func (w writeOps) bumpMessagesModSeq(ctx context.Context, ids []imap.InternalMessageID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	modSeq, err := w.nextModSeq(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, chunk := range xslices.Chunk(ids, db.ChunkLimit) {
+		messageQuery := fmt.Sprintf("UPDATE %v SET `%v` = ? WHERE `%v` IN (%v)",
+			v1.MessagesTableName,
+			v4.MessagesFieldModSeq,
+			v1.MessagesFieldID,
+			utils.GenSQLIn(len(chunk)),
+		)
+
+		args := make([]any, 0, len(chunk)+1)
+		args = append(args, modSeq)
+		args = append(args, utils.MapSliceToAny(chunk)...)
+
+		if _, err := utils.ExecQuery(ctx, w.qw, messageQuery, args...); err != nil {
+			return err
+		}
+
+		mailboxQuery := fmt.Sprintf("UPDATE %v SET `%v` = ? WHERE `%v` IN (SELECT `%v` FROM %v WHERE `%v` IN (%v))",
+			v1.MailboxesTableName,
+			v4.MailboxesFieldHighestModSeq,
+			v1.MailboxesFieldID,
+			v1.MessageToMailboxFieldMailboxID,
+			v1.MessageToMailboxTableName,
+			v1.MessageToMailboxFieldMessageID,
+			utils.GenSQLIn(len(chunk)),
+		)
+
+		if _, err := utils.ExecQuery(ctx, w.qw, mailboxQuery, args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// pruneExpungedUIDs forgets the oldest expunged UIDs of the mailbox beyond the last maxExpungedUIDs ones. The highest
+// mod sequence it forgot is recorded, so that VANISHED (EARLIER) responses since an older one know they are incomplete.
+func (w writeOps) pruneExpungedUIDs(ctx context.Context, mboxID imap.InternalMailboxID) error {
+	query := fmt.Sprintf("SELECT `%v` FROM %v WHERE `%v` = ? ORDER BY `%v` DESC LIMIT 1 OFFSET ?",
+		v5.ExpungedUIDsFieldModSeq,
+		v5.ExpungedUIDsTableName,
+		v5.ExpungedUIDsFieldMailboxID,
+		v5.ExpungedUIDsFieldModSeq,
+	)
+
+	modSeq, err := utils.MapQueryRow[imap.ModSeq](ctx, w.qw, query, mboxID, maxExpungedUIDs)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil
+		}
+
+		return err
+	}
+
+	deleteQuery := fmt.Sprintf("DELETE FROM %v WHERE `%v` = ? AND `%v` <= ?",
+		v5.ExpungedUIDsTableName,
+		v5.ExpungedUIDsFieldMailboxID,
+		v5.ExpungedUIDsFieldModSeq,
+	)
+
+	if _, err := utils.ExecQuery(ctx, w.qw, deleteQuery, mboxID, modSeq); err != nil {
+		return err
+	}
+
+	updateQuery := fmt.Sprintf("INSERT OR REPLACE INTO %v (`%v`, `%v`) VALUES (?, ?)",
+		v12.PrunedExpungedUIDsTableName,
+		v12.PrunedExpungedUIDsFieldMailboxID,
+		v12.PrunedExpungedUIDsFieldModSeq,
+	)
+
+	_, err = utils.ExecQuery(ctx, w.qw, updateQuery, mboxID, modSeq)
+
+	return err
+}
+
+// bumpMailboxMessagesModSeq assigns a new mod sequence to the given messages in the given mailbox only.
+func (w writeOps) bumpMailboxMessagesModSeq(ctx context.Context, mboxID imap.InternalMailboxID, ids []imap.InternalMessageID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	modSeq, err := w.nextModSeq(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, chunk := range xslices.Chunk(ids, db.ChunkLimit-2) {
+		query := fmt.Sprintf("UPDATE %v SET `%v` = ? WHERE `%v` = ? AND `%v` IN (%v)",
+			v1.MessageToMailboxTableName,
+			v11.MessageToMailboxFieldModSeq,
+			v1.MessageToMailboxFieldMailboxID,
+			v1.MessageToMailboxFieldMessageID,
+			utils.GenSQLIn(len(chunk)),
+		)
+
+		args := make([]any, 0, len(chunk)+2)
+		args = append(args, modSeq, mboxID)
+		args = append(args, utils.MapSliceToAny(chunk)...)
+
+		if _, err := utils.ExecQuery(ctx, w.qw, query, args...); err != nil {
+			return err
+		}
+	}
+
+	return w.setMailboxHighestModSeq(ctx, mboxID, modSeq)
+}
+
+// setMailboxHighestModSeq updates the highest mod sequence of the given mailbox.
+func (w writeOps) setMailboxHighestModSeq(ctx context.Context, mboxID imap.InternalMailboxID, modSeq imap.ModSeq) error {
+	query := fmt.Sprintf("UPDATE %v SET `%v` = ? WHERE `%v` = ?",
+		v1.MailboxesTableName,
+		v4.MailboxesFieldHighestModSeq,
+		v1.MailboxesFieldID,
+	)
+
+	_, err := utils.ExecQuery(ctx, w.qw, query, modSeq, mboxID)
+
+	return err
+}
@@ -0,0 +1,66 @@
+package sqlite3
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ProtonMail/gluon/db"
+	"github.com/ProtonMail/gluon/imap"
+	"github.com/bradenaw/juniper/xslices"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoveMessagesFromMailbox_PrunesExpungedUIDs(t *testing.T) {
+	defer func(limit int) { maxExpungedUIDs = limit }(maxExpungedUIDs)
+
+	maxExpungedUIDs = 2
+
+	client, _, err := NewClient(t.TempDir(), "foo", false, false)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, client.Close())
+	}()
+
+	ctx := context.Background()
+
+	require.NoError(t, client.Init(ctx, &imap.IncrementalUIDValidityGenerator{}))
+
+	require.NoError(t, client.Write(ctx, func(ctx context.Context, tx db.Transaction) error {
+		mbox, err := tx.CreateMailbox(ctx, "mbox", "Mailbox", imap.NewFlagSet(), imap.NewFlagSet(), imap.NewFlagSet(), 1)
+		require.NoError(t, err)
+
+		ids := xslices.Map([]imap.MessageID{"msg1", "msg2", "msg3", "msg4"}, func(remoteID imap.MessageID) db.MessageIDPair {
+			return db.MessageIDPair{InternalID: imap.NewInternalMessageID(), RemoteID: remoteID}
+		})
+
+		require.NoError(t, tx.CreateMessages(ctx, xslices.Map(ids, func(id db.MessageIDPair) *db.CreateMessageReq {
+			return &db.CreateMessageReq{
+				Message:    imap.Message{ID: id.RemoteID, Flags: imap.NewFlagSet()},
+				InternalID: id.InternalID,
+			}
+		})...))
+
+		_, err = tx.AddMessagesToMailbox(ctx, mbox.ID, ids)
+		require.NoError(t, err)
+
+		modSeq, err := tx.GetMailboxHighestModSeq(ctx, mbox.ID)
+		require.NoError(t, err)
+
+		// Each message is expunged with its own mod sequence.
+		for _, id := range ids {
+			require.NoError(t, tx.RemoveMessagesFromMailbox(ctx, mbox.ID, []imap.InternalMessageID{id.InternalID}))
+		}
+
+		// Only the last expunged UIDs are kept.
+		uids, err := tx.GetMailboxExpungedUIDsSince(ctx, mbox.ID, modSeq)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []imap.UID{3, 4}, uids)
+
+		pruned, err := tx.GetMailboxPrunedExpungedModSeq(ctx, mbox.ID)
+		require.NoError(t, err)
+		require.Equal(t, modSeq+2, pruned)
+
+		return nil
+	}))
+}
@@ -1,8 +1,11 @@
 package response
 
+import "fmt"
+
 type bad struct {
-	tag string
-	err error
+	tag   string
+	err   error
+	items []Item
 }
 
 func Bad(withTag ...string) *bad {
@@ -19,6 +22,11 @@ func Bad(withTag ...string) *bad {
 	}
 }
 
+func (r *bad) WithItems(items ...Item) *bad {
+	r.items = append(r.items, items...)
+	return r
+}
+
 func (r *bad) WithError(err error) *bad {
 	r.err = err
 	return r
@@ -31,6 +39,16 @@ func (r *bad) Send(s Session) error {
 func (r *bad) String() string {
 	parts := []string{r.tag, "BAD"}
 
+	if len(r.items) > 0 {
+		var items []string
+
+		for _, item := range r.items {
+			items = append(items, item.String())
+		}
+
+		parts = append(parts, fmt.Sprintf("[%v]", join(items)))
+	}
+
 	if r.err != nil {
 		parts = append(parts, r.err.Error())
 	}
@@ -18,3 +18,7 @@ func TestBadTagged(t *testing.T) {
 func TestBadError(t *testing.T) {
 	assert.Equal(t, "tag BAD erroooooor", Bad("tag").WithError(errors.New("erroooooor")).String())
 }
+
+func TestBadUIDRequired(t *testing.T) {
+	assert.Equal(t, "tag BAD [UIDREQUIRED] message sequence numbers are not allowed", Bad("tag").WithItems(ItemUIDRequired()).WithError(errors.New("message sequence numbers are not allowed")).String())
+}
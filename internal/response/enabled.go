@@ -0,0 +1,38 @@
+package response
+
+import (
+	"fmt"
+
+	"github.com/ProtonMail/gluon/imap"
+)
+
+type enabled struct {
+	caps []imap.Capability
+}
+
+func Enabled() *enabled {
+	return &enabled{}
+}
+
+func (r *enabled) WithCapabilities(caps ...imap.Capability) *enabled {
+	r.caps = append(r.caps, caps...)
+	return r
+}
+
+func (r *enabled) Send(s Session) error {
+	return s.WriteResponse(r.String())
+}
+
+func (r *enabled) String() string {
+	if len(r.caps) == 0 {
+		return "* ENABLED"
+	}
+
+	var caps []string
+
+	for _, capability := range r.caps {
+		caps = append(caps, string(capability))
+	}
+
+	return fmt.Sprintf("* ENABLED %v", join(caps))
+}
@@ -0,0 +1,16 @@
+package response
+
+import (
+	"testing"
+
+	"github.com/ProtonMail/gluon/imap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnabled(t *testing.T) {
+	assert.Equal(t, "* ENABLED QRESYNC CONDSTORE", Enabled().WithCapabilities(imap.QRESYNC, imap.CONDSTORE).String())
+}
+
+func TestEnabledNone(t *testing.T) {
+	assert.Equal(t, "* ENABLED", Enabled().String())
+}
@@ -0,0 +1,122 @@
+package response
+
+import (
+	"fmt"
+
+	"github.com/ProtonMail/gluon/imap"
+)
+
+type esearch struct {
+	tag      string
+	uid      bool
+	min, max *uint32
+	count    *int
+	all      imap.SeqSet
+	modSeq   imap.ModSeq
+
+	partial    string
+	partialAll imap.SeqSet
+}
+
+// ESearch returns the extended search response (RFC4731) for the command with the given tag.
+func ESearch(tag string) *esearch {
+	return &esearch{
+		tag: tag,
+	}
+}
+
+// WithUID marks the returned values as UIDs rather than sequence numbers.
+func (r *esearch) WithUID() *esearch {
+	r.uid = true
+	return r
+}
+
+// WithMin sets the lowest message number or UID matching the search.
+func (r *esearch) WithMin(min uint32) *esearch {
+	r.min = &min
+	return r
+}
+
+// WithMax sets the highest message number or UID matching the search.
+func (r *esearch) WithMax(max uint32) *esearch {
+	r.max = &max
+	return r
+}
+
+// WithCount sets the number of messages matching the search.
+func (r *esearch) WithCount(count int) *esearch {
+	r.count = &count
+	return r
+}
+
+// WithAll sets all message numbers or UIDs matching the search; they are returned as a compact sequence set.
+func (r *esearch) WithAll(ids ...uint32) *esearch {
+	r.all = newIDSeqSet(ids)
+	return r
+}
+
+// WithPartial sets the message numbers or UIDs of the given range of the messages matching the search (RFC9394).
+func (r *esearch) WithPartial(partial string, ids ...uint32) *esearch {
+	r.partial = partial
+	r.partialAll = newIDSeqSet(ids)
+
+	return r
+}
+
+// WithModSeq sets the highest mod-sequence of the returned messages (RFC7162).
+func (r *esearch) WithModSeq(modSeq imap.ModSeq) *esearch {
+	r.modSeq = modSeq
+	return r
+}
+
+func (r *esearch) Send(s Session) error {
+	return s.WriteResponse(r.String())
+}
+
+func (r *esearch) String() string {
+	parts := []string{"*", "ESEARCH", fmt.Sprintf(`(TAG "%v")`, r.tag)}
+
+	if r.uid {
+		parts = append(parts, "UID")
+	}
+
+	if r.min != nil {
+		parts = append(parts, fmt.Sprintf("MIN %v", *r.min))
+	}
+
+	if r.max != nil {
+		parts = append(parts, fmt.Sprintf("MAX %v", *r.max))
+	}
+
+	if r.count != nil {
+		parts = append(parts, fmt.Sprintf("COUNT %v", *r.count))
+	}
+
+	if len(r.all) > 0 {
+		parts = append(parts, fmt.Sprintf("ALL %v", r.all))
+	}
+
+	if r.partial != "" {
+		if len(r.partialAll) > 0 {
+			parts = append(parts, fmt.Sprintf("PARTIAL (%v %v)", r.partial, r.partialAll))
+		} else {
+			parts = append(parts, fmt.Sprintf("PARTIAL (%v NIL)", r.partial))
+		}
+	}
+
+	if r.modSeq != 0 {
+		parts = append(parts, fmt.Sprintf("MODSEQ %v", r.modSeq))
+	}
+
+	return join(parts)
+}
+
+func newIDSeqSet(ids []uint32) imap.SeqSet {
+	seqs := make([]imap.SeqID, 0, len(ids))
+
+	for _, id := range ids {
+		seqs = append(seqs, imap.SeqID(id))
+	}
+
+	return imap.NewSeqSet(seqs)
+}
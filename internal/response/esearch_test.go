@@ -0,0 +1,75 @@
+package response
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestESearch(t *testing.T) {
+	assert.Equal(
+		t,
+		`* ESEARCH (TAG "A282") MIN 2 COUNT 3`,
+		ESearch("A282").WithMin(2).WithCount(3).String(),
+	)
+}
+
+func TestESearchUID(t *testing.T) {
+	assert.Equal(
+		t,
+		`* ESEARCH (TAG "A285") UID MIN 7 MAX 3800`,
+		ESearch("A285").WithUID().WithMin(7).WithMax(3800).String(),
+	)
+}
+
+func TestESearchAll(t *testing.T) {
+	assert.Equal(
+		t,
+		`* ESEARCH (TAG "A283") ALL 2,10:11`,
+		ESearch("A283").WithAll(11, 2, 10).String(),
+	)
+}
+
+func TestESearchEmpty(t *testing.T) {
+	assert.Equal(
+		t,
+		`* ESEARCH (TAG "A284")`,
+		ESearch("A284").WithAll().String(),
+	)
+}
+
+func TestESearchWithModSeq(t *testing.T) {
+	assert.Equal(
+		t,
+		`* ESEARCH (TAG "a") ALL 1:3,5 MODSEQ 1236`,
+		ESearch("a").WithAll(1, 2, 3, 5).WithModSeq(1236).String(),
+	)
+}
+
+func TestESearchPartial(t *testing.T) {
+	assert.Equal(
+		t,
+		`* ESEARCH (TAG "A01") UID COUNT 1200 PARTIAL (-1:-100 200:250,252:300)`,
+		ESearch("A01").WithUID().WithCount(1200).WithPartial("-1:-100", seqRange(200, 250, 252, 300)...).String(),
+	)
+}
+
+func TestESearchPartialEmpty(t *testing.T) {
+	assert.Equal(
+		t,
+		`* ESEARCH (TAG "A02") UID PARTIAL (23500:24000 NIL)`,
+		ESearch("A02").WithUID().WithPartial("23500:24000").String(),
+	)
+}
+
+func seqRange(bounds ...uint32) []uint32 {
+	var ids []uint32
+
+	for i := 0; i < len(bounds); i += 2 {
+		for id := bounds[i]; id <= bounds[i+1]; id++ {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
@@ -9,6 +9,9 @@ import (
 type fetch struct {
 	seq   imap.SeqID
 	items []Item
+
+	// uid is set for UIDFETCH responses, which identify the message by UID rather than by sequence number.
+	uid imap.UID
 }
 
 func Fetch(seq imap.SeqID) *fetch {
@@ -17,12 +20,23 @@ func Fetch(seq imap.SeqID) *fetch {
 	}
 }
 
+// UIDFetch returns the UIDFETCH response (RFC9586) which replaces FETCH once UIDONLY is enabled.
+func UIDFetch(uid imap.UID) *fetch {
+	return &fetch{
+		uid: uid,
+	}
+}
+
 func (r *fetch) WithItems(items ...Item) *fetch {
 	r.items = append(r.items, items...)
 	return r
 }
 
 func (r *fetch) Send(s Session) error {
+	if s.IsIMAP4rev2() {
+		return s.WriteResponse(r.withoutRecent().String())
+	}
+
 	return s.WriteResponse(r.String())
 }
 
@@ -33,12 +47,33 @@ func (r *fetch) String() string {
 		items = append(items, item.String())
 	}
 
+	if r.uid != 0 {
+		return fmt.Sprintf(`* %v UIDFETCH (%v)`, r.uid, join(items))
+	}
+
 	return fmt.Sprintf(`* %v FETCH (%v)`, r.seq, join(items))
 }
 
+// withoutRecent returns a copy of the response whose flags don't include the \Recent flag, which was removed in
+// IMAP4rev2 (RFC9051).
+func (r *fetch) withoutRecent() *fetch {
+	res := &fetch{seq: r.seq, uid: r.uid}
+
+	for _, item := range r.items {
+		if flags, ok := item.(*itemFlags); ok {
+			item = ItemFlags(flags.flags.Remove(imap.FlagRecent))
+		}
+
+		res.WithItems(item)
+	}
+
+	return res
+}
+
 func (r *fetch) canSkip(other Response) bool {
+	// UIDFETCH responses are not affected by the sequence numbers of new messages.
 	otherExists, isExists := other.(*exists)
-	if isExists && r.seq < otherExists.count {
+	if isExists && (r.uid != 0 || r.seq < otherExists.count) {
 		return true
 	}
 
@@ -48,7 +83,7 @@ func (r *fetch) canSkip(other Response) bool {
 	}
 
 	otherFetch, isFetch := other.(*fetch)
-	if isFetch && otherFetch.seq != r.seq {
+	if isFetch && (otherFetch.seq != r.seq || otherFetch.uid != r.uid) {
 		return true
 	}
 
@@ -57,7 +92,7 @@ func (r *fetch) canSkip(other Response) bool {
 
 func (r *fetch) mergeWith(other Response) Response {
 	otherFetch, ok := other.(*fetch)
-	if !ok || otherFetch.seq != r.seq {
+	if !ok || otherFetch.seq != r.seq || otherFetch.uid != r.uid {
 		return nil
 	}
 
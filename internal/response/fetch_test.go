@@ -2,6 +2,7 @@ package response
 
 import (
 	"testing"
+	"time"
 
 	"github.com/ProtonMail/gluon/imap"
 	"github.com/stretchr/testify/assert"
@@ -16,3 +17,77 @@ func TestFetch(t *testing.T) {
 			String(),
 	)
 }
+
+func TestFetchModSeq(t *testing.T) {
+	assert.Equal(
+		t,
+		`* 4 FETCH (UID 8 MODSEQ (12121231000))`,
+		Fetch(4).
+			WithItems(ItemUID(8), ItemModSeq(12121231000)).
+			String(),
+	)
+}
+
+func TestFetchObjectID(t *testing.T) {
+	assert.Equal(
+		t,
+		`* 1 FETCH (EMAILID (M6d99ac3275bb4e) THREADID (T64b478a75b7ea9))`,
+		Fetch(1).
+			WithItems(ItemEmailID("M6d99ac3275bb4e"), ItemThreadID("T64b478a75b7ea9")).
+			String(),
+	)
+
+	assert.Equal(
+		t,
+		`* 2 FETCH (EMAILID (a_2Bb_3D) THREADID NIL)`,
+		Fetch(2).
+			WithItems(ItemEmailID("a+b="), ItemThreadID("")).
+			String(),
+	)
+}
+
+func TestFetchPreview(t *testing.T) {
+	preview, empty, utf8 := "Hello world", "", "Grüße"
+
+	assert.Equal(
+		t,
+		`* 1 FETCH (PREVIEW "Hello world")`,
+		Fetch(1).WithItems(ItemPreview(&preview)).String(),
+	)
+
+	assert.Equal(
+		t,
+		`* 2 FETCH (PREVIEW "")`,
+		Fetch(2).WithItems(ItemPreview(&empty)).String(),
+	)
+
+	assert.Equal(
+		t,
+		"* 3 FETCH (PREVIEW {7}\r\nGrüße)",
+		Fetch(3).WithItems(ItemPreview(&utf8)).String(),
+	)
+
+	assert.Equal(
+		t,
+		`* 4 FETCH (PREVIEW NIL)`,
+		Fetch(4).WithItems(ItemPreview(nil)).String(),
+	)
+}
+
+func TestFetchSaveDate(t *testing.T) {
+	assert.Equal(
+		t,
+		`* 1 FETCH (SAVEDATE "03-Feb-2023 10:20:30 +0000" SAVEDATE NIL)`,
+		Fetch(1).
+			WithItems(ItemSaveDate(time.Date(2023, time.February, 3, 10, 20, 30, 0, time.UTC)), ItemSaveDate(time.Time{})).
+			String(),
+	)
+}
+
+func TestUIDFetch(t *testing.T) {
+	assert.Equal(
+		t,
+		`* 42 UIDFETCH (FLAGS (\Seen) MODSEQ (12))`,
+		UIDFetch(42).WithItems(ItemFlags(imap.NewFlagSet(imap.FlagSeen)), ItemModSeq(12)).String(),
+	)
+}
@@ -1,6 +1,9 @@
 package response
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+)
 
 func join(items []string, withDel ...string) string {
 	var del string
@@ -13,3 +16,23 @@ func join(items []string, withDel ...string) string {
 
 	return strings.Join(items, del)
 }
+
+// formatNString returns the value as NIL if it is nil, as a quoted string if possible, or as a literal otherwise.
+func formatNString(value []byte) string {
+	if value == nil {
+		return "NIL"
+	}
+
+	for _, b := range value {
+		if b < 0x20 || b > 0x7e || b == '"' || b == '\\' {
+			return fmt.Sprintf("{%v}\r\n%s", len(value), value)
+		}
+	}
+
+	return `"` + string(value) + `"`
+}
+
+// formatString returns the value as a quoted string if possible, or as a literal otherwise.
+func formatString(value string) string {
+	return formatNString([]byte(value))
+}
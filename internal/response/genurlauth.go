@@ -0,0 +1,24 @@
+package response
+
+import (
+	"fmt"
+
+	"github.com/bradenaw/juniper/xslices"
+)
+
+type genURLAuth struct {
+	urls []string
+}
+
+// GenURLAuth returns the GENURLAUTH response (RFC4467) listing the authorized URLs, in the order they were requested.
+func GenURLAuth(urls ...string) *genURLAuth {
+	return &genURLAuth{urls: urls}
+}
+
+func (r *genURLAuth) Send(s Session) error {
+	return s.WriteResponse(r.String())
+}
+
+func (r *genURLAuth) String() string {
+	return fmt.Sprintf(`* GENURLAUTH %v`, join(xslices.Map(r.urls, formatString)))
+}
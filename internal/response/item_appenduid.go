@@ -7,16 +7,18 @@ import (
 )
 
 type itemAppendUID struct {
-	uidValidity, messageUID imap.UID
+	uidValidity imap.UID
+	messageUIDs imap.SeqSet
 }
 
-func ItemAppendUID(uidValidity, messageUID imap.UID) *itemAppendUID {
+// ItemAppendUID reports the UIDs of the appended messages; there are several of them with MULTIAPPEND (RFC3502).
+func ItemAppendUID(uidValidity imap.UID, messageUIDs ...imap.UID) *itemAppendUID {
 	return &itemAppendUID{
 		uidValidity: uidValidity,
-		messageUID:  messageUID,
+		messageUIDs: imap.NewSeqSetFromUID(messageUIDs),
 	}
 }
 
 func (c *itemAppendUID) String() string {
-	return fmt.Sprintf("APPENDUID %v %v", c.uidValidity, c.messageUID)
+	return fmt.Sprintf("APPENDUID %v %v", c.uidValidity, c.messageUIDs)
 }
@@ -0,0 +1,16 @@
+package response
+
+import "fmt"
+
+type itemBadEvent struct {
+	events []string
+}
+
+// ItemBadEvent returns the BADEVENT response code (RFC5465) listing the events the server supports.
+func ItemBadEvent(events ...string) *itemBadEvent {
+	return &itemBadEvent{events: events}
+}
+
+func (c *itemBadEvent) String() string {
+	return fmt.Sprintf("BADEVENT (%v)", join(c.events))
+}
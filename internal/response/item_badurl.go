@@ -0,0 +1,16 @@
+package response
+
+import "fmt"
+
+type itemBadURL struct {
+	url string
+}
+
+// ItemBadURL returns the BADURL response code (RFC4469) reporting a URL of a CATENATE command which can't be resolved.
+func ItemBadURL(url string) *itemBadURL {
+	return &itemBadURL{url: url}
+}
+
+func (c *itemBadURL) String() string {
+	return fmt.Sprintf("BADURL %v", c.url)
+}
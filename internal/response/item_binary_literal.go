@@ -0,0 +1,52 @@
+package response
+
+import (
+	"bytes"
+	"fmt"
+)
+
+type itemBinaryLiteral struct {
+	section string
+	literal []byte
+	partial int
+}
+
+// ItemBinaryLiteral returns the BINARY fetch item (RFC3516) holding the decoded content of the given section.
+func ItemBinaryLiteral(section string, literal []byte) *itemBinaryLiteral {
+	return &itemBinaryLiteral{
+		section: section,
+		literal: literal,
+		partial: -1,
+	}
+}
+
+func (r *itemBinaryLiteral) WithPartial(begin, count int) *itemBinaryLiteral {
+	r.partial = begin
+
+	if literalLen := len(r.literal); begin >= literalLen {
+		r.literal = nil
+	} else if begin+count > literalLen {
+		r.literal = r.literal[begin:]
+	} else {
+		r.literal = r.literal[begin : begin+count]
+	}
+
+	return r
+}
+
+func (r *itemBinaryLiteral) String() string {
+	var partial string
+
+	if r.partial >= 0 {
+		partial = fmt.Sprintf("<%v>", r.partial)
+	}
+
+	// Content with NUL bytes can only be sent as a literal8.
+	var literal8 string
+
+	if bytes.IndexByte(r.literal, 0) >= 0 {
+		literal8 = "~"
+	}
+
+	return fmt.Sprintf("BINARY[%v]%v %v{%v}\r\n%s", r.section, partial, literal8, len(r.literal), r.literal)
+}
@@ -0,0 +1,31 @@
+package response
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestItemBinaryLiteral(t *testing.T) {
+	assert.Equal(
+		t,
+		"BINARY[1] {4}\r\nbody",
+		ItemBinaryLiteral("1", []byte("body")).String(),
+	)
+}
+
+func TestItemBinaryLiteralPartial(t *testing.T) {
+	assert.Equal(
+		t,
+		"BINARY[1.2]<1> {2}\r\nod",
+		ItemBinaryLiteral("1.2", []byte("body")).WithPartial(1, 2).String(),
+	)
+}
+
+func TestItemBinaryLiteral8(t *testing.T) {
+	assert.Equal(
+		t,
+		"BINARY[1] ~{3}\r\na\x00b",
+		ItemBinaryLiteral("1", []byte("a\x00b")).String(),
+	)
+}
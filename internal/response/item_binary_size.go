@@ -0,0 +1,20 @@
+package response
+
+import "fmt"
+
+type itemBinarySize struct {
+	section string
+	size    int
+}
+
+// ItemBinarySize returns the BINARY.SIZE fetch item (RFC3516) holding the decoded size of the given section.
+func ItemBinarySize(section string, size int) *itemBinarySize {
+	return &itemBinarySize{
+		section: section,
+		size:    size,
+	}
+}
+
+func (s *itemBinarySize) String() string {
+	return fmt.Sprintf("BINARY.SIZE[%v] %v", s.section, s.size)
+}
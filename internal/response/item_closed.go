@@ -0,0 +1,12 @@
+package response
+
+type itemClosed struct{}
+
+// ItemClosed returns the CLOSED response code sent when a previously selected mailbox is closed (RFC7162).
+func ItemClosed() *itemClosed {
+	return &itemClosed{}
+}
+
+func (c *itemClosed) String() string {
+	return "CLOSED"
+}
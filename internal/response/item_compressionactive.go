@@ -0,0 +1,12 @@
+package response
+
+type itemCompressionActive struct{}
+
+// ItemCompressionActive returns the COMPRESSIONACTIVE response code sent when compression is already active (RFC4978).
+func ItemCompressionActive() *itemCompressionActive {
+	return &itemCompressionActive{}
+}
+
+func (c *itemCompressionActive) String() string {
+	return "COMPRESSIONACTIVE"
+}
@@ -0,0 +1,20 @@
+package response
+
+import (
+	"fmt"
+
+	"github.com/ProtonMail/gluon/imap"
+)
+
+type itemEmailID struct {
+	messageID imap.MessageID
+}
+
+// ItemEmailID returns the EMAILID fetch item (RFC8474) holding the object ID of a message's remote ID.
+func ItemEmailID(messageID imap.MessageID) *itemEmailID {
+	return &itemEmailID{messageID: messageID}
+}
+
+func (c *itemEmailID) String() string {
+	return fmt.Sprintf("EMAILID (%v)", c.messageID.ObjectID())
+}
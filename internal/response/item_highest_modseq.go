@@ -0,0 +1,19 @@
+package response
+
+import (
+	"fmt"
+
+	"github.com/ProtonMail/gluon/imap"
+)
+
+type itemHighestModSeq struct {
+	modSeq imap.ModSeq
+}
+
+func ItemHighestModSeq(modSeq imap.ModSeq) *itemHighestModSeq {
+	return &itemHighestModSeq{modSeq: modSeq}
+}
+
+func (c *itemHighestModSeq) String() string {
+	return fmt.Sprintf("HIGHESTMODSEQ %v", c.modSeq)
+}
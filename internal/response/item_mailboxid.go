@@ -0,0 +1,21 @@
+package response
+
+import (
+	"fmt"
+
+	"github.com/ProtonMail/gluon/imap"
+)
+
+type itemMailboxID struct {
+	mboxID imap.MailboxID
+}
+
+// ItemMailboxID returns the MAILBOXID item (RFC8474) holding the object ID of a mailbox. It is used both as a status
+// item and as a response code.
+func ItemMailboxID(mboxID imap.MailboxID) *itemMailboxID {
+	return &itemMailboxID{mboxID: mboxID}
+}
+
+func (c *itemMailboxID) String() string {
+	return fmt.Sprintf("MAILBOXID (%v)", c.mboxID.ObjectID())
+}
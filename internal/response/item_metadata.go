@@ -0,0 +1,41 @@
+package response
+
+import "fmt"
+
+type itemMetadataLongEntries struct {
+	size int
+}
+
+// ItemMetadataLongEntries returns the METADATA LONGENTRIES response code (RFC5464) reporting the size of the biggest
+// entry that was omitted from a GETMETADATA response because of the MAXSIZE option.
+func ItemMetadataLongEntries(size int) *itemMetadataLongEntries {
+	return &itemMetadataLongEntries{size: size}
+}
+
+func (c *itemMetadataLongEntries) String() string {
+	return fmt.Sprintf("METADATA LONGENTRIES %v", c.size)
+}
+
+type itemMetadataMaxSize struct {
+	size int
+}
+
+// ItemMetadataMaxSize returns the METADATA MAXSIZE response code (RFC5464) reporting the maximum size of an entry value.
+func ItemMetadataMaxSize(size int) *itemMetadataMaxSize {
+	return &itemMetadataMaxSize{size: size}
+}
+
+func (c *itemMetadataMaxSize) String() string {
+	return fmt.Sprintf("METADATA MAXSIZE %v", c.size)
+}
+
+type itemMetadataTooMany struct{}
+
+// ItemMetadataTooMany returns the METADATA TOOMANY response code (RFC5464) reported when too many entries are set.
+func ItemMetadataTooMany() *itemMetadataTooMany {
+	return &itemMetadataTooMany{}
+}
+
+func (c *itemMetadataTooMany) String() string {
+	return "METADATA TOOMANY"
+}
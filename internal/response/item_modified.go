@@ -0,0 +1,21 @@
+package response
+
+import (
+	"fmt"
+
+	"github.com/ProtonMail/gluon/imap"
+)
+
+type itemModified struct {
+	set imap.SeqSet
+}
+
+// ItemModified returns the MODIFIED response code listing the messages that failed the UNCHANGEDSINCE test.
+// The values are either sequence numbers or UIDs depending on the command that was issued.
+func ItemModified(set []imap.SeqID) *itemModified {
+	return &itemModified{set: imap.NewSeqSet(set)}
+}
+
+func (c *itemModified) String() string {
+	return fmt.Sprintf("MODIFIED %v", c.set)
+}
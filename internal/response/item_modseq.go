@@ -0,0 +1,32 @@
+package response
+
+import (
+	"fmt"
+
+	"github.com/ProtonMail/gluon/imap"
+)
+
+type itemModSeq struct {
+	modSeq imap.ModSeq
+}
+
+func ItemModSeq(modSeq imap.ModSeq) *itemModSeq {
+	return &itemModSeq{modSeq: modSeq}
+}
+
+func (c *itemModSeq) String() string {
+	return fmt.Sprintf("MODSEQ (%v)", c.modSeq)
+}
+
+func (c *itemModSeq) mergeWith(other Item) Item {
+	otherModSeq, ok := other.(*itemModSeq)
+	if !ok {
+		return nil
+	}
+
+	if otherModSeq.modSeq > c.modSeq {
+		return ItemModSeq(otherModSeq.modSeq)
+	}
+
+	return ItemModSeq(c.modSeq)
+}
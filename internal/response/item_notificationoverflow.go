@@ -0,0 +1,13 @@
+package response
+
+type itemNotificationOverflow struct{}
+
+// ItemNotificationOverflow returns the NOTIFICATIONOVERFLOW response code (RFC5465) sent when notifications are
+// turned off because too many of them are pending.
+func ItemNotificationOverflow() *itemNotificationOverflow {
+	return &itemNotificationOverflow{}
+}
+
+func (c *itemNotificationOverflow) String() string {
+	return "NOTIFICATIONOVERFLOW"
+}
@@ -0,0 +1,12 @@
+package response
+
+type itemOverQuota struct{}
+
+// ItemOverQuota returns the OVERQUOTA response code (RFC9208) reported when an operation would exceed the quota.
+func ItemOverQuota() *itemOverQuota {
+	return &itemOverQuota{}
+}
+
+func (c *itemOverQuota) String() string {
+	return "OVERQUOTA"
+}
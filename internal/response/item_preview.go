@@ -0,0 +1,20 @@
+package response
+
+import "fmt"
+
+type itemPreview struct {
+	preview *string
+}
+
+// ItemPreview returns the PREVIEW fetch item (RFC8970). It is NIL if the preview is nil, i.e. not available.
+func ItemPreview(preview *string) *itemPreview {
+	return &itemPreview{preview: preview}
+}
+
+func (c *itemPreview) String() string {
+	if c.preview == nil {
+		return "PREVIEW NIL"
+	}
+
+	return fmt.Sprintf("PREVIEW %v", formatNString([]byte(*c.preview)))
+}
@@ -0,0 +1,24 @@
+package response
+
+import (
+	"fmt"
+	"time"
+)
+
+type itemSaveDate struct {
+	date time.Time
+}
+
+// ItemSaveDate returns the SAVEDATE fetch item (RFC8514) holding the date the message was saved in the mailbox. It is
+// NIL if the date is zero, i.e. not known.
+func ItemSaveDate(date time.Time) *itemSaveDate {
+	return &itemSaveDate{date: date}
+}
+
+func (c *itemSaveDate) String() string {
+	if c.date.IsZero() {
+		return "SAVEDATE NIL"
+	}
+
+	return fmt.Sprintf("SAVEDATE \"%v\"", c.date.UTC().Format(internalDateFormat))
+}
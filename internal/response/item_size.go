@@ -0,0 +1,16 @@
+package response
+
+import "fmt"
+
+type itemSize struct {
+	size int64
+}
+
+// ItemSize returns the SIZE status item (RFC8438) holding the total size of the messages in a mailbox.
+func ItemSize(size int64) *itemSize {
+	return &itemSize{size: size}
+}
+
+func (s *itemSize) String() string {
+	return fmt.Sprintf("SIZE %v", s.size)
+}
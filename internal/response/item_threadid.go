@@ -0,0 +1,25 @@
+package response
+
+import (
+	"fmt"
+
+	"github.com/ProtonMail/gluon/imap"
+)
+
+type itemThreadID struct {
+	threadID string
+}
+
+// ItemThreadID returns the THREADID fetch item (RFC8474) holding the object ID of a message's thread. It is NIL if
+// the thread ID is empty.
+func ItemThreadID(threadID string) *itemThreadID {
+	return &itemThreadID{threadID: threadID}
+}
+
+func (c *itemThreadID) String() string {
+	if c.threadID == "" {
+		return "THREADID NIL"
+	}
+
+	return fmt.Sprintf("THREADID (%v)", imap.ObjectID(c.threadID))
+}
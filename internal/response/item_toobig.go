@@ -0,0 +1,12 @@
+package response
+
+type itemTooBig struct{}
+
+// ItemTooBig returns the TOOBIG response code (RFC4469) reported when a literal is too big to be accepted.
+func ItemTooBig() *itemTooBig {
+	return &itemTooBig{}
+}
+
+func (c *itemTooBig) String() string {
+	return "TOOBIG"
+}
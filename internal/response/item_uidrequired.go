@@ -0,0 +1,13 @@
+package response
+
+type itemUIDRequired struct{}
+
+// ItemUIDRequired returns the UIDREQUIRED response code (RFC9586), sent when a command uses message sequence numbers
+// while UIDONLY is enabled.
+func ItemUIDRequired() *itemUIDRequired {
+	return &itemUIDRequired{}
+}
+
+func (c *itemUIDRequired) String() string {
+	return "UIDREQUIRED"
+}
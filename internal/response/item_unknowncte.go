@@ -0,0 +1,12 @@
+package response
+
+type itemUnknownCTE struct{}
+
+// ItemUnknownCTE returns the UNKNOWN-CTE response code (RFC3516) reported when a part can't be decoded.
+func ItemUnknownCTE() *itemUnknownCTE {
+	return &itemUnknownCTE{}
+}
+
+func (c *itemUnknownCTE) String() string {
+	return "UNKNOWN-CTE"
+}
@@ -5,11 +5,14 @@ import (
 	"strconv"
 
 	"github.com/ProtonMail/gluon/imap"
+	"github.com/bradenaw/juniper/xslices"
 )
 
 type list struct {
 	name, del string
 	att       imap.FlagSet
+	childInfo []string
+	oldName   string
 }
 
 func List() *list {
@@ -31,6 +34,18 @@ func (r *list) WithAttributes(att imap.FlagSet) *list {
 	return r
 }
 
+// WithChildInfo adds the CHILDINFO extended data item (RFC5258) listing the selection options matched by inferiors.
+func (r *list) WithChildInfo(opts ...string) *list {
+	r.childInfo = append(r.childInfo, opts...)
+	return r
+}
+
+// WithOldName adds the OLDNAME extended data item (RFC5465) giving the previous name of a renamed mailbox.
+func (r *list) WithOldName(name string) *list {
+	r.oldName = name
+	return r
+}
+
 func (r *list) Send(s Session) error {
 	return s.WriteResponse(r.String())
 }
@@ -42,5 +57,21 @@ func (r *list) String() string {
 		del = strconv.Quote(r.del)
 	}
 
-	return fmt.Sprintf(`* LIST (%v) %v %v`, join(r.att.ToSlice()), del, strconv.Quote(r.name))
+	res := fmt.Sprintf(`* LIST (%v) %v %v`, join(r.att.ToSlice()), del, strconv.Quote(r.name))
+
+	var ext []string
+
+	if len(r.childInfo) > 0 {
+		ext = append(ext, fmt.Sprintf(`"CHILDINFO" (%v)`, join(xslices.Map(r.childInfo, strconv.Quote))))
+	}
+
+	if r.oldName != "" {
+		ext = append(ext, fmt.Sprintf(`"OLDNAME" (%v)`, strconv.Quote(r.oldName)))
+	}
+
+	if len(ext) > 0 {
+		res += fmt.Sprintf(` (%v)`, join(ext))
+	}
+
+	return res
 }
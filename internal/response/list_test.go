@@ -22,3 +22,19 @@ func TestListNilDelimiter(t *testing.T) {
 		List().WithAttributes(imap.NewFlagSet(`\Noselect`)).WithName(`Mail`).String(),
 	)
 }
+
+func TestListChildInfo(t *testing.T) {
+	assert.Equal(
+		t,
+		`* LIST () "/" "Foo" ("CHILDINFO" ("SUBSCRIBED"))`,
+		List().WithDelimiter("/").WithName(`Foo`).WithChildInfo("SUBSCRIBED").String(),
+	)
+}
+
+func TestListOldName(t *testing.T) {
+	assert.Equal(
+		t,
+		`* LIST () "/" "Bar" ("OLDNAME" ("Foo"))`,
+		List().WithDelimiter("/").WithName(`Bar`).WithOldName(`Foo`).String(),
+	)
+}
@@ -68,6 +68,19 @@ func TestMergeResponses(t *testing.T) {
 				Recent().WithCount(3),
 			},
 		},
+		"consecutive vanished": {
+			given: []Response{
+				Vanished(1),
+				Vanished(2),
+				Exists().WithCount(1),
+				Vanished(3),
+			},
+			want: []Response{
+				Vanished(1, 2),
+				Exists().WithCount(1),
+				Vanished(3),
+			},
+		},
 		"combining exists and recent": {
 			given: []Response{
 				Exists().WithCount(1),
@@ -0,0 +1,49 @@
+package response
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+)
+
+type metadata struct {
+	name    string
+	entries []metadataEntry
+}
+
+type metadataEntry struct {
+	name  string
+	value []byte
+}
+
+// Metadata returns the METADATA response (RFC5464) listing the entries of the given mailbox, or of the server if the
+// name is empty. Entries with a nil value are reported as NIL.
+func Metadata(name string) *metadata {
+	return &metadata{
+		name: name,
+	}
+}
+
+func (r *metadata) WithEntry(name string, value []byte) *metadata {
+	r.entries = append(r.entries, metadataEntry{name: name, value: value})
+	return r
+}
+
+func (r *metadata) Send(s Session) error {
+	return s.WriteResponse(r.String())
+}
+
+func (r *metadata) String() string {
+	var entries []string
+
+	for _, entry := range r.entries {
+		// Values with NUL bytes can only be sent as a literal8.
+		if bytes.IndexByte(entry.value, 0) >= 0 {
+			entries = append(entries, entry.name, fmt.Sprintf("~{%v}\r\n%s", len(entry.value), entry.value))
+		} else {
+			entries = append(entries, entry.name, formatNString(entry.value))
+		}
+	}
+
+	return fmt.Sprintf(`* METADATA %v (%v)`, strconv.Quote(r.name), join(entries))
+}
@@ -0,0 +1,39 @@
+package response
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetadata(t *testing.T) {
+	assert.Equal(
+		t,
+		`* METADATA "INBOX" (/private/comment "My comment" /shared/comment NIL)`,
+		Metadata("INBOX").WithEntry("/private/comment", []byte("My comment")).WithEntry("/shared/comment", nil).String(),
+	)
+}
+
+func TestMetadataServer(t *testing.T) {
+	assert.Equal(
+		t,
+		`* METADATA "" (/shared/admin "")`,
+		Metadata("").WithEntry("/shared/admin", []byte{}).String(),
+	)
+}
+
+func TestMetadataLiteral(t *testing.T) {
+	assert.Equal(
+		t,
+		"* METADATA \"INBOX\" (/private/comment {11}\r\nline\r\n\"two\")",
+		Metadata("INBOX").WithEntry("/private/comment", []byte("line\r\n\"two\"")).String(),
+	)
+}
+
+func TestMetadataLiteral8(t *testing.T) {
+	assert.Equal(
+		t,
+		"* METADATA \"INBOX\" (/private/blob ~{3}\r\na\x00b)",
+		Metadata("INBOX").WithEntry("/private/blob", []byte("a\x00b")).String(),
+	)
+}
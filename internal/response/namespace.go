@@ -0,0 +1,64 @@
+package response
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type namespace struct {
+	personal, otherUsers, shared []string
+	del                          string
+}
+
+func Namespace() *namespace {
+	return &namespace{}
+}
+
+func (r *namespace) WithPersonal(prefixes ...string) *namespace {
+	r.personal = append(r.personal, prefixes...)
+	return r
+}
+
+func (r *namespace) WithOtherUsers(prefixes ...string) *namespace {
+	r.otherUsers = append(r.otherUsers, prefixes...)
+	return r
+}
+
+func (r *namespace) WithShared(prefixes ...string) *namespace {
+	r.shared = append(r.shared, prefixes...)
+	return r
+}
+
+func (r *namespace) WithDelimiter(del string) *namespace {
+	r.del = del
+	return r
+}
+
+func (r *namespace) Send(s Session) error {
+	return s.WriteResponse(r.String())
+}
+
+func (r *namespace) String() string {
+	return fmt.Sprintf(`* NAMESPACE %v %v %v`, r.format(r.personal), r.format(r.otherUsers), r.format(r.shared))
+}
+
+func (r *namespace) format(prefixes []string) string {
+	if len(prefixes) == 0 {
+		return "NIL"
+	}
+
+	del := "NIL"
+
+	if r.del != "" {
+		del = strconv.Quote(r.del)
+	}
+
+	var res []string
+
+	for _, prefix := range prefixes {
+		res = append(res, fmt.Sprintf(`(%v %v)`, strconv.Quote(prefix), del))
+	}
+
+	return fmt.Sprintf(`(%v)`, strings.Join(res, ""))
+}
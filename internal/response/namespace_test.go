@@ -0,0 +1,36 @@
+package response
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamespace(t *testing.T) {
+	assert.Equal(
+		t,
+		`* NAMESPACE (("" "/")) NIL NIL`,
+		Namespace().WithPersonal("").WithDelimiter("/").String(),
+	)
+}
+
+func TestNamespaceMultiple(t *testing.T) {
+	assert.Equal(
+		t,
+		`* NAMESPACE (("" ".")("Folders." ".")) (("Other Users." ".")) (("Shared." "."))`,
+		Namespace().
+			WithPersonal("", "Folders.").
+			WithOtherUsers("Other Users.").
+			WithShared("Shared.").
+			WithDelimiter(".").
+			String(),
+	)
+}
+
+func TestNamespaceNilDelimiter(t *testing.T) {
+	assert.Equal(
+		t,
+		`* NAMESPACE (("" NIL)) NIL NIL`,
+		Namespace().WithPersonal("").String(),
+	)
+}
@@ -22,3 +22,11 @@ func TestNoError(t *testing.T) {
 func TestNoTryCreate(t *testing.T) {
 	assert.Equal(t, "tag NO [TRYCREATE] erroooooor", No("tag").WithItems(ItemTryCreate()).WithError(errors.New("erroooooor")).String())
 }
+
+func TestNoUseAttr(t *testing.T) {
+	assert.Equal(t, "tag NO [USEATTR] erroooooor", No("tag").WithItems(ItemUseAttr()).WithError(errors.New("erroooooor")).String())
+}
+
+func TestNoBadURL(t *testing.T) {
+	assert.Equal(t, `tag NO [BADURL /INBOX/;uid=1] erroooooor`, No("tag").WithItems(ItemBadURL("/INBOX/;uid=1")).WithError(errors.New("erroooooor")).String())
+}
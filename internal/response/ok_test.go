@@ -34,3 +34,19 @@ func TestOkUIDValidity(t *testing.T) {
 func TestOkReadOnly(t *testing.T) {
 	assert.Equal(t, `* OK [READ-ONLY]`, Ok().WithItems(ItemReadOnly()).String())
 }
+
+func TestOkHighestModSeq(t *testing.T) {
+	assert.Equal(t, `* OK [HIGHESTMODSEQ 715194045007]`, Ok().WithItems(ItemHighestModSeq(715194045007)).String())
+}
+
+func TestOkModified(t *testing.T) {
+	assert.Equal(t, `tag OK [MODIFIED 7,9] Conditional STORE failed`, Ok("tag").WithItems(ItemModified([]imap.SeqID{9, 7})).WithMessage("Conditional STORE failed").String())
+}
+
+func TestOkClosed(t *testing.T) {
+	assert.Equal(t, `* OK [CLOSED] Previous mailbox closed`, Ok().WithItems(ItemClosed()).WithMessage("Previous mailbox closed").String())
+}
+
+func TestOkMailboxID(t *testing.T) {
+	assert.Equal(t, `tag OK [MAILBOXID (F2212ea87-6097-4256-9d51-71338625)] CREATE`, Ok("tag").WithItems(ItemMailboxID("F2212ea87-6097-4256-9d51-71338625")).WithMessage("CREATE").String())
+}
@@ -0,0 +1,69 @@
+package response
+
+import (
+	"fmt"
+	"strconv"
+)
+
+type quota struct {
+	root      string
+	resources []quotaResource
+}
+
+type quotaResource struct {
+	name         string
+	usage, limit int64
+}
+
+// Quota returns the QUOTA response (RFC9208) listing the usage and limits of the resources of the given quota root.
+func Quota(root string) *quota {
+	return &quota{
+		root: root,
+	}
+}
+
+func (r *quota) WithResource(name string, usage, limit int64) *quota {
+	r.resources = append(r.resources, quotaResource{name: name, usage: usage, limit: limit})
+	return r
+}
+
+func (r *quota) Send(s Session) error {
+	return s.WriteResponse(r.String())
+}
+
+func (r *quota) String() string {
+	var resources []string
+
+	for _, resource := range r.resources {
+		resources = append(resources, fmt.Sprintf("%v %v %v", resource.name, resource.usage, resource.limit))
+	}
+
+	return fmt.Sprintf(`* QUOTA %v (%v)`, strconv.Quote(r.root), join(resources))
+}
+
+type quotaRoot struct {
+	name  string
+	roots []string
+}
+
+// QuotaRoot returns the QUOTAROOT response (RFC9208) listing the quota roots of the given mailbox.
+func QuotaRoot(name string, roots ...string) *quotaRoot {
+	return &quotaRoot{
+		name:  name,
+		roots: roots,
+	}
+}
+
+func (r *quotaRoot) Send(s Session) error {
+	return s.WriteResponse(r.String())
+}
+
+func (r *quotaRoot) String() string {
+	parts := []string{"*", "QUOTAROOT", strconv.Quote(r.name)}
+
+	for _, root := range r.roots {
+		parts = append(parts, strconv.Quote(root))
+	}
+
+	return join(parts)
+}
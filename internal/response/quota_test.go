@@ -0,0 +1,39 @@
+package response
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuota(t *testing.T) {
+	assert.Equal(
+		t,
+		`* QUOTA "" (STORAGE 10 512 MESSAGE 3 1000)`,
+		Quota("").WithResource("STORAGE", 10, 512).WithResource("MESSAGE", 3, 1000).String(),
+	)
+}
+
+func TestQuotaNoResources(t *testing.T) {
+	assert.Equal(
+		t,
+		`* QUOTA "" ()`,
+		Quota("").String(),
+	)
+}
+
+func TestQuotaRoot(t *testing.T) {
+	assert.Equal(
+		t,
+		`* QUOTAROOT "INBOX" ""`,
+		QuotaRoot("INBOX", "").String(),
+	)
+}
+
+func TestQuotaRootNoRoots(t *testing.T) {
+	assert.Equal(
+		t,
+		`* QUOTAROOT "INBOX"`,
+		QuotaRoot("INBOX").String(),
+	)
+}
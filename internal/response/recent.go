@@ -17,7 +17,12 @@ func (r *recent) WithCount(n uint32) *recent {
 	return r
 }
 
+// Send writes the response unless the client switched to IMAP4rev2 (RFC9051), which has no RECENT response.
 func (r *recent) Send(s Session) error {
+	if s.IsIMAP4rev2() {
+		return nil
+	}
+
 	return s.WriteResponse(r.String())
 }
 
@@ -1,13 +1,16 @@
 package response
 
 import (
+	"fmt"
 	"strconv"
 
+	"github.com/ProtonMail/gluon/imap"
 	"golang.org/x/exp/slices"
 )
 
 type search struct {
-	seqs []uint32
+	seqs   []uint32
+	modSeq imap.ModSeq
 }
 
 func Search(seqs ...uint32) *search {
@@ -18,6 +21,12 @@ func Search(seqs ...uint32) *search {
 	}
 }
 
+// WithModSeq sets the highest mod-sequence of the returned messages (RFC7162).
+func (r *search) WithModSeq(modSeq imap.ModSeq) *search {
+	r.modSeq = modSeq
+	return r
+}
+
 func (r *search) Send(s Session) error {
 	return s.WriteResponse(r.String())
 }
@@ -33,6 +42,10 @@ func (r *search) String() string {
 		}
 
 		parts = append(parts, join(seqs))
+
+		if r.modSeq != 0 {
+			parts = append(parts, fmt.Sprintf("(MODSEQ %v)", r.modSeq))
+		}
 	}
 
 	return join(parts)
@@ -21,3 +21,19 @@ func TestSearchEmpty(t *testing.T) {
 		Search().String(),
 	)
 }
+
+func TestSearchWithModSeq(t *testing.T) {
+	assert.Equal(
+		t,
+		`* SEARCH 2 5 6 7 11 12 18 19 20 23 (MODSEQ 917162500)`,
+		Search(2, 5, 6, 7, 11, 12, 18, 19, 20, 23).WithModSeq(917162500).String(),
+	)
+}
+
+func TestSearchEmptyWithModSeq(t *testing.T) {
+	assert.Equal(
+		t,
+		`* SEARCH`,
+		Search().WithModSeq(917162500).String(),
+	)
+}
@@ -0,0 +1,50 @@
+package response
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ProtonMail/gluon/imap"
+)
+
+type sort struct {
+	ids    []uint32
+	modSeq imap.ModSeq
+}
+
+// Sort returns a SORT response (RFC5256). Unlike SEARCH, the order of the given IDs is kept.
+func Sort(ids ...uint32) *sort {
+	return &sort{
+		ids: ids,
+	}
+}
+
+// WithModSeq sets the highest mod-sequence of the returned messages (RFC7162).
+func (r *sort) WithModSeq(modSeq imap.ModSeq) *sort {
+	r.modSeq = modSeq
+	return r
+}
+
+func (r *sort) Send(s Session) error {
+	return s.WriteResponse(r.String())
+}
+
+func (r *sort) String() string {
+	parts := []string{"*", "SORT"}
+
+	if len(r.ids) > 0 {
+		var ids []string
+
+		for _, id := range r.ids {
+			ids = append(ids, strconv.Itoa(int(id)))
+		}
+
+		parts = append(parts, join(ids))
+
+		if r.modSeq != 0 {
+			parts = append(parts, fmt.Sprintf("(MODSEQ %v)", r.modSeq))
+		}
+	}
+
+	return join(parts)
+}
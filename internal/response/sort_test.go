@@ -0,0 +1,31 @@
+package response
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSort(t *testing.T) {
+	assert.Equal(
+		t,
+		`* SORT 5 3 4 1 2`,
+		Sort(5, 3, 4, 1, 2).String(),
+	)
+}
+
+func TestSortEmpty(t *testing.T) {
+	assert.Equal(
+		t,
+		`* SORT`,
+		Sort().String(),
+	)
+}
+
+func TestSortWithModSeq(t *testing.T) {
+	assert.Equal(
+		t,
+		`* SORT 3 1 2 (MODSEQ 917162500)`,
+		Sort(3, 1, 2).WithModSeq(917162500).String(),
+	)
+}
@@ -17,3 +17,26 @@ func TestStatus(t *testing.T) {
 			String(),
 	)
 }
+
+func TestStatusHighestModSeq(t *testing.T) {
+	assert.Equal(
+		t,
+		`* STATUS "blurdybloop" (MESSAGES 231 HIGHESTMODSEQ 7011231777)`,
+		Status().
+			WithMailbox(`blurdybloop`).
+			WithItems(ItemMessages(231)).
+			WithItems(ItemHighestModSeq(7011231777)).
+			String(),
+	)
+}
+
+func TestStatusMailboxID(t *testing.T) {
+	assert.Equal(
+		t,
+		`* STATUS "foo" (MAILBOXID (F2212ea87-6097-4256-9d51-71338625))`,
+		Status().
+			WithMailbox(`foo`).
+			WithItems(ItemMailboxID("F2212ea87-6097-4256-9d51-71338625")).
+			String(),
+	)
+}
@@ -0,0 +1,69 @@
+package response
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/ProtonMail/gluon/imap"
+)
+
+type thread struct {
+	threads []imap.Thread
+}
+
+// Thread returns a THREAD response listing the given threads (RFC5256).
+func Thread(threads ...imap.Thread) *thread {
+	return &thread{
+		threads: threads,
+	}
+}
+
+func (r *thread) Send(s Session) error {
+	return s.WriteResponse(r.String())
+}
+
+func (r *thread) String() string {
+	var b strings.Builder
+
+	b.WriteString("* THREAD")
+
+	if len(r.threads) > 0 {
+		b.WriteByte(' ')
+	}
+
+	for _, thread := range r.threads {
+		writeThreadList(&b, thread)
+	}
+
+	return b.String()
+}
+
+// writeThreadList writes the given thread as a thread-list.
+func writeThreadList(b *strings.Builder, thread imap.Thread) {
+	// thread-list     = "(" (thread-members / thread-nested) ")"
+	b.WriteByte('(')
+	writeThreadMembers(b, thread)
+	b.WriteByte(')')
+}
+
+// writeThreadMembers writes the given thread and its descendants without the enclosing parentheses.
+func writeThreadMembers(b *strings.Builder, thread imap.Thread) {
+	// thread-members  = nz-number *(SP nz-number) [SP thread-nested]
+	// thread-nested   = 2*thread-list
+	if thread.ID != 0 {
+		b.WriteString(strconv.FormatUint(uint64(thread.ID), 10))
+
+		if len(thread.Children) > 0 {
+			b.WriteByte(' ')
+		}
+	}
+
+	if len(thread.Children) == 1 {
+		writeThreadMembers(b, thread.Children[0])
+		return
+	}
+
+	for _, child := range thread.Children {
+		writeThreadList(b, child)
+	}
+}
@@ -0,0 +1,42 @@
+package response
+
+import (
+	"testing"
+
+	"github.com/ProtonMail/gluon/imap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThread(t *testing.T) {
+	assert.Equal(
+		t,
+		`* THREAD (2)(3 6 (4 23)(44 7 96))`,
+		Thread(
+			imap.Thread{ID: 2},
+			imap.Thread{ID: 3, Children: []imap.Thread{
+				{ID: 6, Children: []imap.Thread{
+					{ID: 4, Children: []imap.Thread{{ID: 23}}},
+					{ID: 44, Children: []imap.Thread{{ID: 7, Children: []imap.Thread{{ID: 96}}}}},
+				}},
+			}},
+		).String(),
+	)
+}
+
+func TestThreadWithPlaceholder(t *testing.T) {
+	assert.Equal(
+		t,
+		`* THREAD ((3)(5))`,
+		Thread(
+			imap.Thread{Children: []imap.Thread{{ID: 3}, {ID: 5}}},
+		).String(),
+	)
+}
+
+func TestThreadEmpty(t *testing.T) {
+	assert.Equal(
+		t,
+		`* THREAD`,
+		Thread().String(),
+	)
+}
@@ -8,6 +8,9 @@ type Response interface {
 
 type Session interface {
 	WriteResponse(string) error
+
+	// IsIMAP4rev2 returns whether the client switched to IMAP4rev2 (RFC9051), which drops the \Recent flag.
+	IsIMAP4rev2() bool
 }
 
 type mergeableResponse interface {
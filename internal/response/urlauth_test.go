@@ -0,0 +1,37 @@
+package response
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenURLAuth(t *testing.T) {
+	assert.Equal(
+		t,
+		`* GENURLAUTH "imap://joe@example.com/INBOX/;uid=1;urlauth=anonymous:internal:0123" "imap://joe@example.com/INBOX/;uid=2;urlauth=authuser:internal:4567"`,
+		GenURLAuth(
+			"imap://joe@example.com/INBOX/;uid=1;urlauth=anonymous:internal:0123",
+			"imap://joe@example.com/INBOX/;uid=2;urlauth=authuser:internal:4567",
+		).String(),
+	)
+}
+
+func TestURLFetch(t *testing.T) {
+	assert.Equal(
+		t,
+		"* URLFETCH \"imap://joe@example.com/INBOX/;uid=1\" {5}\r\nHello \"imap://joe@example.com/INBOX/;uid=2\" NIL",
+		URLFetch().
+			WithURL("imap://joe@example.com/INBOX/;uid=1", []byte("Hello")).
+			WithURL("imap://joe@example.com/INBOX/;uid=2", nil).
+			String(),
+	)
+}
+
+func TestURLFetchLiteralURL(t *testing.T) {
+	assert.Equal(
+		t,
+		"* URLFETCH {39}\r\nimap://joe@example.com/Entw\xc3\xbcrfe/;uid=1 NIL",
+		URLFetch().WithURL("imap://joe@example.com/Entw\u00fcrfe/;uid=1", nil).String(),
+	)
+}
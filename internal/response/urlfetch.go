@@ -0,0 +1,41 @@
+package response
+
+import "fmt"
+
+type urlFetch struct {
+	entries []urlFetchEntry
+}
+
+type urlFetchEntry struct {
+	url  string
+	data []byte
+}
+
+// URLFetch returns the URLFETCH response (RFC4467) holding the content the URLs point at. URLs with nil content,
+// which couldn't be resolved, are reported as NIL.
+func URLFetch() *urlFetch {
+	return &urlFetch{}
+}
+
+func (r *urlFetch) WithURL(url string, data []byte) *urlFetch {
+	r.entries = append(r.entries, urlFetchEntry{url: url, data: data})
+	return r
+}
+
+func (r *urlFetch) Send(s Session) error {
+	return s.WriteResponse(r.String())
+}
+
+func (r *urlFetch) String() string {
+	var items []string
+
+	for _, entry := range r.entries {
+		if entry.data == nil {
+			items = append(items, formatString(entry.url), "NIL")
+		} else {
+			items = append(items, formatString(entry.url), fmt.Sprintf("{%v}\r\n%s", len(entry.data), entry.data))
+		}
+	}
+
+	return fmt.Sprintf(`* URLFETCH %v`, join(items))
+}
@@ -0,0 +1,68 @@
+package response
+
+import (
+	"fmt"
+
+	"github.com/ProtonMail/gluon/imap"
+)
+
+// vanished is the VANISHED response defined in RFC7162 which replaces EXPUNGE once QRESYNC is enabled.
+type vanished struct {
+	uids    []imap.UID
+	set     imap.SeqSet
+	earlier bool
+}
+
+func Vanished(uids ...imap.UID) *vanished {
+	return &vanished{
+		uids: uids,
+	}
+}
+
+// VanishedSet returns the VANISHED response reporting the UIDs of the given set, which may span many UIDs.
+func VanishedSet(set imap.SeqSet) *vanished {
+	return &vanished{
+		set: set,
+	}
+}
+
+// WithEarlier marks the response as reporting messages which were expunged before the current command.
+func (r *vanished) WithEarlier() *vanished {
+	r.earlier = true
+
+	return r
+}
+
+func (r *vanished) Send(s Session) error {
+	return s.WriteResponse(r.String())
+}
+
+func (r *vanished) String() string {
+	set := r.set
+	if set == nil {
+		set = imap.NewSeqSetFromUID(r.uids)
+	}
+
+	if r.earlier {
+		return fmt.Sprintf("* VANISHED (EARLIER) %v", set)
+	}
+
+	return fmt.Sprintf("* VANISHED %v", set)
+}
+
+func (r *vanished) canSkip(Response) bool {
+	return false
+}
+
+func (r *vanished) mergeWith(other Response) Response {
+	otherVanished, ok := other.(*vanished)
+	if !ok || otherVanished.earlier != r.earlier || otherVanished.set != nil || r.set != nil {
+		return nil
+	}
+
+	uids := make([]imap.UID, 0, len(otherVanished.uids)+len(r.uids))
+	uids = append(uids, otherVanished.uids...)
+	uids = append(uids, r.uids...)
+
+	return &vanished{uids: uids, earlier: r.earlier}
+}
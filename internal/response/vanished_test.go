@@ -0,0 +1,23 @@
+package response
+
+import (
+	"testing"
+
+	"github.com/ProtonMail/gluon/imap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVanished(t *testing.T) {
+	assert.Equal(t, `* VANISHED 3,5:7`, Vanished(5, 3, 6, 7).String())
+}
+
+func TestVanishedEarlier(t *testing.T) {
+	assert.Equal(t, `* VANISHED (EARLIER) 41,43:45`, Vanished(41, 43, 44, 45).WithEarlier().String())
+}
+
+func TestVanishedSet(t *testing.T) {
+	assert.Equal(t, `* VANISHED (EARLIER) 1:41,43:999999`, VanishedSet(imap.SeqSet{
+		{Begin: 1, End: 41},
+		{Begin: 43, End: 999999},
+	}).WithEarlier().String())
+}
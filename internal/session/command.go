@@ -17,6 +17,15 @@ import (
 type commandResult struct {
 	command command.Command
 	err     error
+
+	// resumeCh, if set, is closed once the command reader may continue reading from the connection.
+	resumeCh chan struct{}
+}
+
+func (r commandResult) resume() {
+	if r.resumeCh != nil {
+		close(r.resumeCh)
+	}
 }
 
 func (s *Session) startCommandReader(ctx context.Context) <-chan commandResult {
@@ -35,6 +44,7 @@ func (s *Session) startCommandReader(ctx context.Context) <-chan commandResult {
 
 		options := []command.Option{
 			command.WithLiteralContinuationCallback(func(message string) error { return response.Continuation().Send(s, message) }),
+			command.WithMaxNonSyncLiteralSize(s.maxNonSyncLiteralSize),
 		}
 		if s.disableIMAPAuthenticate {
 			options = append(options, command.WithDisableIMAPAuthenticate())
@@ -85,6 +95,27 @@ func (s *Session) startCommandReader(ctx context.Context) <-chan commandResult {
 				} else {
 					continue
 				}
+
+			case *command.Compress:
+				// Compression changes the connection streams, so the next command may only be read once it has been
+				// handled by the session.
+				resumeCh := make(chan struct{})
+
+				select {
+				case cmdCh <- commandResult{command: cmd, resumeCh: resumeCh}:
+					// ...
+
+				case <-ctx.Done():
+					return
+				}
+
+				select {
+				case <-resumeCh:
+					continue
+
+				case <-ctx.Done():
+					return
+				}
 			}
 
 			select {
@@ -0,0 +1,63 @@
+package session
+
+import (
+	"compress/flate"
+	"io"
+	"net"
+	"sync"
+)
+
+// compressConn wraps a connection with raw DEFLATE streams as defined in RFC4978.
+type compressConn struct {
+	net.Conn
+
+	reader io.ReadCloser
+
+	writer     *flate.Writer
+	writerLock sync.Mutex
+}
+
+func newCompressConn(conn net.Conn) (*compressConn, error) {
+	writer, err := flate.NewWriter(conn, flate.DefaultCompression)
+	if err != nil {
+		return nil, err
+	}
+
+	return &compressConn{
+		Conn:   conn,
+		reader: flate.NewReader(conn),
+		writer: writer,
+	}, nil
+}
+
+func (c *compressConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
+
+// Write compresses the given bytes and flushes them immediately so that the client receives every response in full.
+func (c *compressConn) Write(b []byte) (int, error) {
+	c.writerLock.Lock()
+	defer c.writerLock.Unlock()
+
+	n, err := c.writer.Write(b)
+	if err != nil {
+		return n, err
+	}
+
+	if err := c.writer.Flush(); err != nil {
+		return n, err
+	}
+
+	return n, nil
+}
+
+// Close ends the compressed output stream before closing the connection so that the client receives it in full.
+func (c *compressConn) Close() error {
+	c.writerLock.Lock()
+	_ = c.writer.Close()
+	c.writerLock.Unlock()
+
+	_ = c.reader.Close()
+
+	return c.Conn.Close()
+}
@@ -19,7 +19,27 @@ var (
 	ErrNotAuthenticated     = errors.New("session is not authenticated")
 	ErrAlreadyAuthenticated = errors.New("session is already authenticated")
 
+	ErrCompressionUnavailable = errors.New("compression is unavailable")
+	ErrCompressionActive      = errors.New("compression is already active")
+
 	ErrNotImplemented = errors.New("not implemented")
+
+	ErrExtensionNotEnabled = errors.New("extension is not enabled")
+	ErrVanishedNotUID      = errors.New("VANISHED is only allowed in UID FETCH")
+	ErrUIDRequired         = errors.New("message sequence numbers are not allowed once UIDONLY is enabled")
+
+	ErrNoSuchQuotaRoot = errors.New("no such quota root")
+
+	ErrNotifyUnsupportedEvent  = errors.New("unsupported event")
+	ErrNotifyUnsupportedFilter = errors.New("unsupported filter")
+	ErrNotifyMessageEvents     = errors.New("MessageNew and MessageExpunge must be requested together")
+	ErrNotifyFlagChange        = errors.New("FlagChange requires MessageNew and MessageExpunge")
+
+	ErrURLAuthUnsupportedMechanism = errors.New("unsupported URLAUTH mechanism")
+	ErrURLAuthInvalidRump          = errors.New("URL must be an absolute URLAUTH rump URL")
+	ErrURLAuthOtherUser            = errors.New("URL belongs to another user")
+	ErrURLAuthAccessDenied         = errors.New("URL access denied")
+	ErrURLAuthUnsupportedAccess    = errors.New("unsupported URLAUTH access identifier")
 )
 
 func shouldReportIMAPCommandError(err error) bool {
@@ -32,12 +52,25 @@ func shouldReportIMAPCommandError(err error) bool {
 		return false
 	case errors.Is(err, connector.ErrOperationNotAllowed):
 		return false
+	case errors.Is(err, connector.ErrUnsupportedSpecialUse):
+		return false
+	case errors.Is(err, ErrNoSuchQuotaRoot):
+		return false
+	case errors.Is(err, ErrNotifyUnsupportedEvent) || errors.Is(err, ErrNotifyUnsupportedFilter) ||
+		errors.Is(err, ErrNotifyMessageEvents) || errors.Is(err, ErrNotifyFlagChange):
+		return false
+	case errors.Is(err, ErrURLAuthUnsupportedMechanism) || errors.Is(err, ErrURLAuthInvalidRump) ||
+		errors.Is(err, ErrURLAuthOtherUser) || errors.Is(err, ErrURLAuthAccessDenied) ||
+		errors.Is(err, ErrURLAuthUnsupportedAccess):
+		return false
 	case errors.Is(err, context.Canceled):
 		return false
 	case errors.As(err, &netErr):
 		return false
 	case errors.Is(err, rfc822.ErrNoSuchPart):
 		return false
+	case errors.Is(err, rfc822.ErrUnknownEncoding):
+		return false
 	case errors.Is(err, state.ErrKnownRecoveredMessage):
 		return false
 	}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/ProtonMail/gluon/imap"
 	"github.com/ProtonMail/gluon/imap/command"
 	"github.com/ProtonMail/gluon/internal/response"
 	"github.com/ProtonMail/gluon/internal/state"
 	"github.com/ProtonMail/gluon/logging"
+	"golang.org/x/exp/slices"
 )
 
 func (s *Session) handleOther(
@@ -69,7 +71,17 @@ func (s *Session) handleCommand(
 		*command.List,
 		*command.LSub,
 		*command.Status,
-		*command.Append:
+		*command.Append,
+		*command.Enable,
+		*command.Namespace,
+		*command.GetQuota,
+		*command.GetQuotaRoot,
+		*command.GetMetadata,
+		*command.SetMetadata,
+		*command.Notify,
+		*command.ResetKey,
+		*command.GenURLAuth,
+		*command.URLFetch:
 		return s.handleAuthenticatedCommand(ctx, tag, cmd, ch)
 	case
 		*command.Check,
@@ -78,10 +90,13 @@ func (s *Session) handleCommand(
 		*command.UIDExpunge,
 		*command.Unselect,
 		*command.Search,
+		*command.Sort,
+		*command.Thread,
 		*command.Fetch,
 		*command.Store,
 		*command.Copy,
 		*command.Move,
+		*command.Replace,
 		*command.UID:
 		return s.handleSelectedCommand(ctx, tag, cmd, ch)
 
@@ -150,6 +165,10 @@ func (s *Session) handleAuthenticatedCommand(
 		return ErrNotAuthenticated
 	}
 
+	if err := s.checkExtensionsEnabled(tag, cmd); err != nil {
+		return err
+	}
+
 	switch cmd := cmd.(type) {
 	case *command.Select:
 		// 6.3.1. SELECT Command
@@ -193,8 +212,53 @@ func (s *Session) handleAuthenticatedCommand(
 
 	case *command.Append:
 		// 6.3.11. APPEND Command
+		if len(cmd.Additional) > 0 {
+			// RFC3502 MULTIAPPEND
+			return s.handleMultiAppend(ctx, tag, cmd, ch)
+		}
+
 		return s.handleAppend(ctx, tag, cmd, ch)
 
+	case *command.Enable:
+		// RFC 5161 ENABLE Command
+		return s.handleEnable(ctx, tag, cmd, ch)
+
+	case *command.Namespace:
+		// RFC 2342 NAMESPACE Command
+		return s.handleNamespace(ctx, tag, cmd, ch)
+
+	case *command.GetQuota:
+		// RFC 9208 GETQUOTA Command
+		return s.handleGetQuota(ctx, tag, cmd, ch)
+
+	case *command.GetQuotaRoot:
+		// RFC 9208 GETQUOTAROOT Command
+		return s.handleGetQuotaRoot(ctx, tag, cmd, ch)
+
+	case *command.GetMetadata:
+		// RFC 5464 GETMETADATA Command
+		return s.handleGetMetadata(ctx, tag, cmd, ch)
+
+	case *command.SetMetadata:
+		// RFC 5464 SETMETADATA Command
+		return s.handleSetMetadata(ctx, tag, cmd, ch)
+
+	case *command.Notify:
+		// RFC 5465 NOTIFY Command
+		return s.handleNotify(ctx, tag, cmd, ch)
+
+	case *command.ResetKey:
+		// RFC 4467 RESETKEY Command
+		return s.handleResetKey(ctx, tag, cmd, ch)
+
+	case *command.GenURLAuth:
+		// RFC 4467 GENURLAUTH Command
+		return s.handleGenURLAuth(ctx, tag, cmd, ch)
+
+	case *command.URLFetch:
+		// RFC 4467 URLFETCH Command
+		return s.handleURLFetch(ctx, tag, cmd, ch)
+
 	default:
 		return fmt.Errorf("bad command")
 	}
@@ -213,6 +277,14 @@ func (s *Session) handleSelectedCommand(
 		return ErrNotAuthenticated
 	}
 
+	if err := s.checkExtensionsEnabled(tag, cmd); err != nil {
+		return err
+	}
+
+	if err := s.checkUIDOnly(tag, cmd); err != nil {
+		return err
+	}
+
 	return s.state.Selected(ctx, func(mailbox *state.Mailbox) error {
 		okResponse, err := s.handleWithMailbox(ctx, tag, cmd, mailbox, ch)
 
@@ -268,6 +340,14 @@ func (s *Session) handleWithMailbox(
 		// 6.4.4. SEARCH Command
 		return s.handleSearch(ctx, tag, cmd, mailbox, ch)
 
+	case *command.Sort:
+		// RFC5256 SORT Extension
+		return s.handleSort(ctx, tag, cmd, mailbox, ch)
+
+	case *command.Thread:
+		// RFC5256 THREAD Extension
+		return s.handleThread(ctx, tag, cmd, mailbox, ch)
+
 	case *command.Fetch:
 		// 6.4.5. FETCH Command
 		return s.handleFetch(ctx, tag, cmd, mailbox, ch)
@@ -288,7 +368,106 @@ func (s *Session) handleWithMailbox(
 		// RFC6851 MOVE Command
 		return s.handleMove(ctx, tag, cmd, mailbox, ch)
 
+	case *command.Replace:
+		// RFC8508 REPLACE Command
+		return s.handleReplace(ctx, tag, cmd, mailbox, ch)
+
 	default:
 		return nil, fmt.Errorf("bad command")
 	}
 }
+
+// checkExtensionsEnabled returns an error if the command uses an extension which the client has not enabled yet.
+func (s *Session) checkExtensionsEnabled(tag string, cmd command.Payload) error {
+	if c, ok := getRequiredExtension(cmd); ok && !s.state.IsEnabled(c) {
+		return response.Bad(tag).WithError(fmt.Errorf("%w: %v", ErrExtensionNotEnabled, c))
+	}
+
+	return nil
+}
+
+// checkUIDOnly returns an error if the command uses message sequence numbers while UIDONLY is enabled (RFC9586).
+func (s *Session) checkUIDOnly(tag string, cmd command.Payload) error {
+	if s.state.IsEnabled(imap.UIDONLY) && usesSeqNumbers(cmd) {
+		return response.Bad(tag).WithItems(response.ItemUIDRequired()).WithError(ErrUIDRequired)
+	}
+
+	return nil
+}
+
+// usesSeqNumbers returns whether the command refers to messages by sequence number.
+func usesSeqNumbers(cmd command.Payload) bool {
+	switch cmd := cmd.(type) {
+	case *command.Fetch, *command.Store, *command.Copy, *command.Move, *command.Replace, *command.Search, *command.Sort, *command.Thread:
+		return true
+
+	case *command.UID:
+		switch cmd := cmd.Command.(type) {
+		case *command.Search:
+			return searchKeysUseSeqNumbers(cmd.Keys)
+
+		case *command.Sort:
+			return searchKeysUseSeqNumbers(cmd.Keys)
+
+		case *command.Thread:
+			return searchKeysUseSeqNumbers(cmd.Keys)
+
+		default:
+			return false
+		}
+
+	default:
+		return false
+	}
+}
+
+// searchKeysUseSeqNumbers returns whether any of the search keys is a sequence set other than "$" (RFC5182), which
+// refers to the last search result.
+func searchKeysUseSeqNumbers(keys []command.SearchKey) bool {
+	return slices.ContainsFunc(keys, func(key command.SearchKey) bool {
+		switch key := key.(type) {
+		case *command.SearchKeySeqSet:
+			return !command.IsSearchResSeqSet(key.SeqSet)
+
+		case *command.SearchKeyNot:
+			return searchKeysUseSeqNumbers([]command.SearchKey{key.Key})
+
+		case *command.SearchKeyOr:
+			return searchKeysUseSeqNumbers([]command.SearchKey{key.Key1, key.Key2})
+
+		case *command.SearchKeyList:
+			return searchKeysUseSeqNumbers(key.Keys)
+
+		default:
+			return false
+		}
+	})
+}
+
+// getRequiredExtension returns the extension which must be enabled before the command can be used, if any.
+func getRequiredExtension(cmd command.Payload) (imap.Capability, bool) {
+	switch cmd := cmd.(type) {
+	case *command.Select:
+		return imap.QRESYNC, cmd.QResync != nil
+
+	case *command.Examine:
+		return imap.QRESYNC, cmd.QResync != nil
+
+	case *command.Fetch:
+		return imap.QRESYNC, cmd.Vanished
+
+	case *command.Append:
+		return imap.UTF8ACCEPT, slices.ContainsFunc(cmd.Messages(), func(message command.AppendMessage) bool {
+			return message.UTF8
+		})
+
+	case *command.Replace:
+		return imap.UTF8ACCEPT, cmd.Message.UTF8
+
+	case *command.UID:
+		return getRequiredExtension(cmd.Command)
+
+	default:
+		return "", false
+	}
+}
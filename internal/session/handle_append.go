@@ -1,9 +1,11 @@
 package session
 
 import (
+	"bytes"
 	"context"
 	"errors"
 
+	"github.com/ProtonMail/gluon/connector"
 	"github.com/ProtonMail/gluon/imap/command"
 	"github.com/ProtonMail/gluon/internal/response"
 	"github.com/ProtonMail/gluon/internal/state"
@@ -21,6 +23,10 @@ func (s *Session) handleAppend(ctx context.Context, tag string, cmd *command.App
 		return err
 	}
 
+	if err := s.catenate(ctx, tag, cmd); err != nil {
+		return err
+	}
+
 	flags, err := validateStoreFlags(cmd.Flags)
 	if err != nil {
 		return response.Bad(tag).WithError(err)
@@ -48,6 +54,10 @@ func (s *Session) handleAppend(ctx context.Context, tag string, cmd *command.App
 				)
 			}
 
+			if errors.Is(err, state.ErrOverQuota) {
+				return response.No(tag).WithError(err).WithItems(response.ItemOverQuota())
+			}
+
 			return err
 		}
 
@@ -68,3 +78,128 @@ func (s *Session) handleAppend(ctx context.Context, tag string, cmd *command.App
 
 	return nil
 }
+
+// handleMultiAppend appends all the messages of the command at once (RFC3502); if any of them can't be appended,
+// none of them is.
+func (s *Session) handleMultiAppend(ctx context.Context, tag string, cmd *command.Append, ch chan response.Response) error {
+	profiling.Start(ctx, profiling.CmdTypeMultiAppend)
+	defer profiling.Stop(ctx, profiling.CmdTypeMultiAppend)
+
+	nameUTF8, err := s.decodeMailboxName(cmd.Mailbox)
+	if err != nil {
+		return err
+	}
+
+	if err := s.catenate(ctx, tag, cmd); err != nil {
+		return err
+	}
+
+	messages := cmd.Messages()
+	reqs := make([]connector.CreateMessageReq, 0, len(messages))
+
+	for _, message := range messages {
+		flags, err := validateStoreFlags(message.Flags)
+		if err != nil {
+			return response.Bad(tag).WithError(err)
+		}
+
+		reqs = append(reqs, connector.CreateMessageReq{
+			Literal: message.Literal,
+			Flags:   flags,
+			Date:    message.DateTime,
+		})
+	}
+
+	if err := s.state.AppendOnlyMailbox(ctx, nameUTF8, func(mailbox state.AppendOnlyMailbox, isSameMBox bool) error {
+		isDrafts, err := mailbox.IsDrafts(ctx)
+		if err != nil {
+			return err
+		}
+
+		if !isDrafts {
+			for _, req := range reqs {
+				if err := rfcvalidation.ValidateMessageHeaderFields(req.Literal); err != nil {
+					return response.Bad(tag).WithError(err)
+				}
+			}
+		}
+
+		messageUIDs, err := mailbox.AppendMany(ctx, reqs)
+		if err != nil {
+			if shouldReportIMAPCommandError(err) {
+				reporter.MessageWithContext(ctx,
+					"Failed to append messages to mailbox from state",
+					reporter.Context{"error": err, "mailbox": nameUTF8, "count": len(reqs)},
+				)
+			}
+
+			if errors.Is(err, state.ErrOverQuota) {
+				return response.No(tag).WithError(err).WithItems(response.ItemOverQuota())
+			}
+
+			return err
+		}
+
+		if isSameMBox {
+			if err := flush(ctx, mailbox, true, ch); err != nil {
+				return err
+			}
+		}
+
+		ch <- response.Ok(tag).WithItems(response.ItemAppendUID(mailbox.UIDValidity(), messageUIDs...)).WithMessage("APPEND")
+
+		return nil
+	}); errors.Is(err, state.ErrNoSuchMailbox) {
+		return response.No(tag).WithError(err).WithItems(response.ItemTryCreate())
+	} else if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// catenate builds the messages of the command which are made of several parts with CATENATE (RFC4469).
+func (s *Session) catenate(ctx context.Context, tag string, cmd *command.Append) error {
+	if len(cmd.Catenate) > 0 {
+		literal, err := s.catenateParts(ctx, tag, cmd.Catenate)
+		if err != nil {
+			return err
+		}
+
+		cmd.Literal = literal
+	}
+
+	for i := range cmd.Additional {
+		if len(cmd.Additional[i].Catenate) > 0 {
+			literal, err := s.catenateParts(ctx, tag, cmd.Additional[i].Catenate)
+			if err != nil {
+				return err
+			}
+
+			cmd.Additional[i].Literal = literal
+		}
+	}
+
+	return nil
+}
+
+// catenateParts builds a message from its text parts and the content the URL parts point at.
+func (s *Session) catenateParts(ctx context.Context, tag string, parts []command.CatenatePart) ([]byte, error) {
+	var b bytes.Buffer
+
+	for _, part := range parts {
+		if part.URL == "" {
+			b.Write(part.Text)
+			continue
+		}
+
+		data, err := s.fetchURL(ctx, part.URL, false)
+		if err != nil {
+			return nil, response.No(tag).WithError(err).WithItems(response.ItemBadURL(part.URL))
+		}
+
+		b.Write(data)
+	}
+
+	return b.Bytes(), nil
+}
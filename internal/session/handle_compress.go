@@ -0,0 +1,44 @@
+package session
+
+import (
+	"bufio"
+	"context"
+
+	"github.com/ProtonMail/gluon/imap/command"
+	"github.com/ProtonMail/gluon/internal/response"
+	"github.com/ProtonMail/gluon/profiling"
+)
+
+func (s *Session) handleCompress(ctx context.Context, tag string, _ *command.Compress) error {
+	profiling.Start(ctx, profiling.CmdTypeCompress)
+	defer profiling.Stop(ctx, profiling.CmdTypeCompress)
+
+	if s.state == nil {
+		return ErrNotAuthenticated
+	}
+
+	if !s.compressionEnabled {
+		return response.Bad(tag).WithError(ErrCompressionUnavailable)
+	}
+
+	if s.compressionActive {
+		return response.No(tag).WithError(ErrCompressionActive).WithItems(response.ItemCompressionActive())
+	}
+
+	conn, err := newCompressConn(s.conn)
+	if err != nil {
+		return err
+	}
+
+	if err := response.Ok(tag).WithMessage("DEFLATE active").Send(s); err != nil {
+		return err
+	}
+
+	s.conn = conn
+	s.compressionActive = true
+
+	s.inputCollector.Reset()
+	s.inputCollector.SetSource(bufio.NewReader(s.conn))
+
+	return nil
+}
@@ -2,8 +2,10 @@ package session
 
 import (
 	"context"
+	"errors"
 	"strings"
 
+	"github.com/ProtonMail/gluon/connector"
 	"github.com/ProtonMail/gluon/imap"
 	"github.com/ProtonMail/gluon/imap/command"
 	"github.com/ProtonMail/gluon/internal/response"
@@ -25,12 +27,15 @@ func (s *Session) handleCreate(ctx context.Context, tag string, cmd *command.Cre
 		return ErrCreateInbox
 	}
 
-	if err := s.state.Create(ctx, nameUTF8); err != nil {
+	mboxID, err := s.state.Create(ctx, nameUTF8, imap.NewFlagSetFromSlice(cmd.SpecialUse))
+	if errors.Is(err, connector.ErrUnsupportedSpecialUse) {
+		return response.No(tag).WithError(err).WithItems(response.ItemUseAttr())
+	} else if err != nil {
 		observability.AddMessageRelatedMetric(ctx, metrics.GenerateFailedToCreateMailbox())
 		return err
 	}
 
-	ch <- response.Ok(tag).WithMessage("CREATE")
+	ch <- response.Ok(tag).WithItems(response.ItemMailboxID(mboxID)).WithMessage("CREATE")
 
 	return nil
 }
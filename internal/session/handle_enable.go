@@ -0,0 +1,55 @@
+package session
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ProtonMail/gluon/imap"
+	"github.com/ProtonMail/gluon/imap/command"
+	"github.com/ProtonMail/gluon/internal/response"
+	"github.com/ProtonMail/gluon/profiling"
+	"golang.org/x/exp/slices"
+)
+
+func (s *Session) handleEnable(ctx context.Context, tag string, cmd *command.Enable, ch chan response.Response) error {
+	profiling.Start(ctx, profiling.CmdTypeEnable)
+	defer profiling.Stop(ctx, profiling.CmdTypeEnable)
+
+	// Only the extensions which were not enabled before are listed in the ENABLED response; unknown ones are ignored.
+	enabled := s.state.Enable(s.getEnableableCaps(cmd.Capabilities)...)
+
+	ch <- response.Enabled().WithCapabilities(enabled...)
+
+	// The responses following the ENABLED response use the IMAP4rev2 (RFC9051) syntax.
+	if slices.Contains(enabled, imap.IMAP4rev2) {
+		s.imap4rev2.Store(true)
+	}
+
+	// Mailbox names are exchanged in UTF-8 from now on (RFC6855).
+	if slices.Contains(enabled, imap.UTF8ACCEPT) {
+		s.utf8Accept.Store(true)
+	}
+
+	ch <- response.Ok(tag).WithMessage("ENABLE")
+
+	return nil
+}
+
+// getEnableableCaps returns the given capabilities which are advertised by the server and can be enabled by the client.
+func (s *Session) getEnableableCaps(names []string) []imap.Capability {
+	s.capsLock.Lock()
+	defer s.capsLock.Unlock()
+
+	var caps []imap.Capability
+
+	// Capability names are case-insensitive.
+	for _, name := range names {
+		if idx := slices.IndexFunc(s.caps, func(c imap.Capability) bool {
+			return strings.EqualFold(string(c), name)
+		}); idx >= 0 && imap.IsCapabilityEnableable(s.caps[idx]) {
+			caps = append(caps, s.caps[idx])
+		}
+	}
+
+	return caps
+}
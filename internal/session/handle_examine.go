@@ -19,7 +19,17 @@ func (s *Session) handleExamine(ctx context.Context, tag string, cmd *command.Ex
 		return err
 	}
 
+	if cmd.CondStore {
+		s.state.Enable(imap.CONDSTORE)
+	}
+
+	wasSelected := s.state.IsSelected()
+
 	if err := s.state.Examine(ctx, nameUTF8, func(mailbox *state.Mailbox) error {
+		if wasSelected && s.state.IsEnabled(imap.QRESYNC) {
+			ch <- response.Ok().WithItems(response.ItemClosed()).WithMessage("Previous mailbox closed")
+		}
+
 		flags, err := mailbox.Flags(ctx)
 		if err != nil {
 			return err
@@ -41,11 +51,29 @@ func (s *Session) handleExamine(ctx context.Context, tag string, cmd *command.Ex
 		ch <- response.Ok().WithItems(response.ItemPermanentFlags(permFlags))
 		ch <- response.Ok().WithItems(response.ItemUIDNext(uidNext))
 		ch <- response.Ok().WithItems(response.ItemUIDValidity(mailbox.UIDValidity()))
+		ch <- response.Ok().WithItems(response.ItemMailboxID(mailbox.MailboxID()))
 
-		if unseen, ok := mailbox.GetFirstMessageWithoutFlag(imap.FlagSeen); ok {
+		// The UNSEEN response code was removed in IMAP4rev2 (RFC9051); it holds a sequence number, which UIDONLY clients
+		// don't use (RFC9586).
+		if unseen, ok := mailbox.GetFirstMessageWithoutFlag(imap.FlagSeen); ok && !s.IsIMAP4rev2() && !s.state.IsEnabled(imap.UIDONLY) {
 			ch <- response.Ok().WithItems(response.ItemUnseen(uint32(unseen.Seq)))
 		}
 
+		if s.state.IsEnabled(imap.CONDSTORE) {
+			highestModSeq, err := mailbox.HighestModSeq(ctx)
+			if err != nil {
+				return err
+			}
+
+			ch <- response.Ok().WithItems(response.ItemHighestModSeq(highestModSeq))
+		}
+
+		if cmd.QResync != nil {
+			if err := resyncMailbox(ctx, mailbox, cmd.QResync, ch); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return err
@@ -4,12 +4,14 @@ import (
 	"context"
 	"errors"
 
+	"github.com/ProtonMail/gluon/imap"
 	"github.com/ProtonMail/gluon/imap/command"
 	"github.com/ProtonMail/gluon/internal/contexts"
 	"github.com/ProtonMail/gluon/internal/response"
 	"github.com/ProtonMail/gluon/internal/state"
 	"github.com/ProtonMail/gluon/profiling"
 	"github.com/ProtonMail/gluon/reporter"
+	"github.com/ProtonMail/gluon/rfc822"
 )
 
 func (s *Session) handleFetch(ctx context.Context, tag string, cmd *command.Fetch, mailbox *state.Mailbox, ch chan response.Response) (response.Response, error) {
@@ -21,8 +23,25 @@ func (s *Session) handleFetch(ctx context.Context, tag string, cmd *command.Fetc
 		defer profiling.Stop(ctx, profiling.CmdTypeFetch)
 	}
 
+	if cmd.Vanished {
+		if !contexts.IsUID(ctx) {
+			return response.Bad(tag).WithError(ErrVanishedNotUID), nil
+		}
+
+		uids, err := mailbox.Vanished(ctx, imap.ModSeq(cmd.ChangedSince), cmd.SeqSet)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(uids) > 0 {
+			ch <- response.VanishedSet(uids).WithEarlier()
+		}
+	}
+
 	if err := mailbox.Fetch(ctx, cmd, ch); errors.Is(err, state.ErrNoSuchMessage) {
 		return response.Bad(tag).WithError(err), nil
+	} else if errors.Is(err, rfc822.ErrUnknownEncoding) {
+		return response.No(tag).WithError(err).WithItems(response.ItemUnknownCTE()), nil
 	} else if err != nil {
 		if shouldReportIMAPCommandError(err) {
 			// there's no events like this in sentry so far.
@@ -80,6 +80,8 @@ func (s *Session) handleIdle(ctx context.Context, tag string, _ *command.Idle, c
 				return response.Ok(tag).WithMessage("IDLE").Send(s)
 
 			default:
+				cmd.resume()
+
 				return response.Bad(tag).Send(s)
 			}
 		}
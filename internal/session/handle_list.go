@@ -4,41 +4,136 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/ProtonMail/gluon/imap"
 	"github.com/ProtonMail/gluon/imap/command"
 	"github.com/ProtonMail/gluon/internal/response"
 	"github.com/ProtonMail/gluon/internal/state"
 	"github.com/ProtonMail/gluon/profiling"
-	"github.com/emersion/go-imap/utf7"
 )
 
 func (s *Session) handleList(ctx context.Context, tag string, cmd *command.List, ch chan response.Response) error {
 	profiling.Start(ctx, profiling.CmdTypeList)
 	defer profiling.Stop(ctx, profiling.CmdTypeList)
 
-	nameUTF8, err := s.decodeMailboxName(cmd.ListMailbox)
-	if err != nil {
+	var patterns []string
+
+	for _, pattern := range cmd.Patterns() {
+		nameUTF8, err := s.decodeMailboxName(pattern)
+		if err != nil {
+			return err
+		}
+
+		patterns = append(patterns, nameUTF8)
+	}
+
+	var matches map[string]state.Match
+
+	// All the mailboxes are local, so REMOTE (RFC5258) selects the same mailboxes as without it, and no mailbox gets
+	// the \Remote attribute.
+	if err := s.state.List(ctx, cmd.Mailbox, patterns, false, state.ListOptions{
+		Subscribed:     cmd.SelectSubscribed,
+		RecursiveMatch: cmd.SelectRecursiveMatch,
+	}, func(res map[string]state.Match) error {
+		matches = res
+		return nil
+	}); err != nil {
 		return err
 	}
 
-	return s.state.List(ctx, cmd.Mailbox, nameUTF8, false, func(matches map[string]state.Match) error {
-		for _, match := range matches {
-			nameUtf7, err := utf7.Encoding.NewEncoder().String(match.Name)
-			if err != nil {
-				return fmt.Errorf("failed to convert name to utf7")
+	for _, match := range matches {
+		if cmd.SelectSpecialUse && !hasSpecialUse(match.Atts) {
+			continue
+		}
+
+		nameUtf7, err := s.encodeMailboxName(match.Name)
+		if err != nil {
+			return fmt.Errorf("failed to encode mailbox name")
+		}
+
+		res := response.List().
+			WithName(nameUtf7).
+			WithDelimiter(match.Delimiter).
+			WithAttributes(getListAttributes(cmd, match, s.IsIMAP4rev2()))
+
+		// Mailboxes listed only because of their subscribed inferiors carry the reason they were listed.
+		if cmd.SelectRecursiveMatch && match.HasSubscribedChildren {
+			res = res.WithChildInfo("SUBSCRIBED")
+		}
+
+		select {
+		case ch <- res:
+
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		if len(cmd.ReturnStatus) > 0 && !match.Atts.Contains(imap.AttrNoSelect) {
+			if err := s.state.Mailbox(ctx, match.Name, func(mailbox *state.Mailbox) error {
+				items, err := s.getStatusItems(ctx, mailbox, cmd.ReturnStatus, ch)
+				if err != nil {
+					return err
+				}
+
+				ch <- response.Status().WithMailbox(nameUtf7).WithItems(items...)
+
+				return nil
+			}); err != nil {
+				return err
 			}
-			select {
-			case ch <- response.List().
-				WithName(nameUtf7).
-				WithDelimiter(match.Delimiter).
-				WithAttributes(match.Atts):
-
-			case <-ctx.Done():
-				return ctx.Err()
+		}
+	}
+
+	ch <- response.Ok(tag).WithMessage("LIST")
+
+	return nil
+}
+
+// getListAttributes returns the attributes of the matched mailbox, completed with those requested by the
+// LIST-EXTENDED (RFC5258) options. IMAP4rev2 (RFC9051) clients always get the \NonExistent and \Subscribed attributes
+// as they no longer rely on LSUB. Clients asking for other return options only get the special-use attributes
+// (RFC6154) if they ask for them too.
+func getListAttributes(cmd *command.List, match state.Match, imap4rev2 bool) imap.FlagSet {
+	atts := match.Atts.Clone()
+
+	if hasReturnOpts(cmd) && !cmd.ReturnSpecialUse && !cmd.SelectSpecialUse {
+		for _, att := range atts.ToSliceUnsorted() {
+			if imap.IsSpecialUseAttribute(att) {
+				atts = atts.Remove(att)
 			}
 		}
+	}
 
-		ch <- response.Ok(tag).WithMessage("LIST")
+	if (cmd.SelectSubscribed || imap4rev2) && match.NonExistent && match.Name != "" {
+		atts = atts.Remove(imap.AttrNoSelect).Add(imap.AttrNonExistent)
+	}
 
-		return nil
-	})
+	if (cmd.SelectSubscribed || cmd.ReturnSubscribed || imap4rev2) && match.Subscribed {
+		atts.AddToSelf(imap.AttrSubscribed)
+	}
+
+	if cmd.ReturnChildren {
+		if match.HasChildren {
+			atts.AddToSelf(imap.AttrHasChildren)
+		} else {
+			atts.AddToSelf(imap.AttrHasNoChildren)
+		}
+	}
+
+	return atts
+}
+
+// hasReturnOpts returns whether the command requests any return option other than SPECIAL-USE.
+func hasReturnOpts(cmd *command.List) bool {
+	return cmd.ReturnSubscribed || cmd.ReturnChildren || len(cmd.ReturnStatus) > 0
+}
+
+// hasSpecialUse returns whether the given mailbox attributes include a special-use attribute (RFC6154).
+func hasSpecialUse(atts imap.FlagSet) bool {
+	for _, att := range atts.ToSliceUnsorted() {
+		if imap.IsSpecialUseAttribute(att) {
+			return true
+		}
+	}
+
+	return false
 }
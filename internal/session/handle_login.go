@@ -37,6 +37,7 @@ func (s *Session) handleLogin(ctx context.Context, tag string, cmd *command.Logi
 	}
 
 	s.state = state
+	s.userName = cmd.UserID
 
 	ch <- response.Ok(tag).WithItems(response.ItemCapability(s.caps...)).WithMessage("Logged in")
 
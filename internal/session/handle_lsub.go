@@ -7,7 +7,6 @@ import (
 	"github.com/ProtonMail/gluon/internal/response"
 	"github.com/ProtonMail/gluon/internal/state"
 	"github.com/ProtonMail/gluon/profiling"
-	"github.com/emersion/go-imap/utf7"
 )
 
 func (s *Session) handleLsub(ctx context.Context, tag string, cmd *command.LSub, ch chan response.Response) error {
@@ -19,9 +18,9 @@ func (s *Session) handleLsub(ctx context.Context, tag string, cmd *command.LSub,
 		return err
 	}
 
-	return s.state.List(ctx, cmd.Mailbox, nameUTF8, true, func(matches map[string]state.Match) error {
+	return s.state.List(ctx, cmd.Mailbox, []string{nameUTF8}, true, state.ListOptions{}, func(matches map[string]state.Match) error {
 		for _, match := range matches {
-			nameUtf7, err := utf7.Encoding.NewEncoder().String(match.Name)
+			nameUtf7, err := s.encodeMailboxName(match.Name)
 			if err != nil {
 				panic(err)
 			}
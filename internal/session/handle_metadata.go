@@ -0,0 +1,129 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/ProtonMail/gluon/imap/command"
+	"github.com/ProtonMail/gluon/internal/response"
+	"github.com/ProtonMail/gluon/internal/state"
+	"github.com/ProtonMail/gluon/profiling"
+)
+
+func (s *Session) handleGetMetadata(ctx context.Context, tag string, cmd *command.GetMetadata, ch chan response.Response) error {
+	profiling.Start(ctx, profiling.CmdTypeGetMetadata)
+	defer profiling.Stop(ctx, profiling.CmdTypeGetMetadata)
+
+	nameUTF8, err := s.decodeMetadataMailboxName(cmd.Mailbox)
+	if err != nil {
+		return err
+	}
+
+	entries, err := s.state.GetMetadata(ctx, nameUTF8)
+	if err != nil {
+		return err
+	}
+
+	var (
+		res     = response.Metadata(cmd.Mailbox)
+		found   = make(map[string]struct{})
+		longest int
+	)
+
+	for _, entry := range entries {
+		if !matchMetadataEntry(entry.Name, cmd.Entries, cmd.Depth) {
+			continue
+		}
+
+		found[entry.Name] = struct{}{}
+
+		// Entries bigger than the client accepts are omitted, the biggest one is reported in the tagged response.
+		if cmd.MaxSize != nil && len(entry.Value) > *cmd.MaxSize {
+			if len(entry.Value) > longest {
+				longest = len(entry.Value)
+			}
+
+			continue
+		}
+
+		res.WithEntry(entry.Name, entry.Value)
+	}
+
+	// Requested entries which don't exist are reported with a NIL value.
+	for _, name := range cmd.Entries {
+		if _, ok := found[name]; !ok {
+			found[name] = struct{}{}
+			res.WithEntry(name, nil)
+		}
+	}
+
+	ch <- res
+
+	if longest > 0 {
+		ch <- response.Ok(tag).WithItems(response.ItemMetadataLongEntries(longest)).WithMessage("GETMETADATA")
+	} else {
+		ch <- response.Ok(tag).WithMessage("GETMETADATA")
+	}
+
+	return nil
+}
+
+func (s *Session) handleSetMetadata(ctx context.Context, tag string, cmd *command.SetMetadata, ch chan response.Response) error {
+	profiling.Start(ctx, profiling.CmdTypeSetMetadata)
+	defer profiling.Stop(ctx, profiling.CmdTypeSetMetadata)
+
+	nameUTF8, err := s.decodeMetadataMailboxName(cmd.Mailbox)
+	if err != nil {
+		return err
+	}
+
+	if err := s.state.SetMetadata(ctx, nameUTF8, cmd.Entries); errors.Is(err, state.ErrMetadataMaxSize) {
+		return response.No(tag).WithError(err).WithItems(response.ItemMetadataMaxSize(state.MetadataMaxSize))
+	} else if errors.Is(err, state.ErrMetadataTooMany) {
+		return response.No(tag).WithError(err).WithItems(response.ItemMetadataTooMany())
+	} else if err != nil {
+		return err
+	}
+
+	ch <- response.Ok(tag).WithMessage("SETMETADATA")
+
+	return nil
+}
+
+// decodeMetadataMailboxName decodes the name of the mailbox whose entries are accessed. The empty name designates the
+// server entries and is left as is.
+func (s *Session) decodeMetadataMailboxName(name string) (string, error) {
+	if name == "" {
+		return "", nil
+	}
+
+	return s.decodeMailboxName(name)
+}
+
+// matchMetadataEntry returns whether the entry with the given name was requested, either directly or, depending on
+// the depth, as a descendant of one of the requested entries.
+func matchMetadataEntry(name string, requested []string, depth command.MetadataDepth) bool {
+	for _, req := range requested {
+		if name == req {
+			return true
+		}
+
+		suffix, ok := strings.CutPrefix(name, req+"/")
+		if !ok {
+			continue
+		}
+
+		switch depth {
+		case command.MetadataDepthOne:
+			if !strings.Contains(suffix, "/") {
+				return true
+			}
+
+		case command.MetadataDepthInfinity:
+			return true
+		}
+	}
+
+	return false
+}
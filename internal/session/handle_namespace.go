@@ -0,0 +1,69 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ProtonMail/gluon/imap"
+	"github.com/ProtonMail/gluon/imap/command"
+	"github.com/ProtonMail/gluon/internal/response"
+	"github.com/ProtonMail/gluon/profiling"
+)
+
+func (s *Session) handleNamespace(ctx context.Context, tag string, _ *command.Namespace, ch chan response.Response) error {
+	profiling.Start(ctx, profiling.CmdTypeNamespace)
+	defer profiling.Stop(ctx, profiling.CmdTypeNamespace)
+
+	namespaces := s.state.GetNamespaces(ctx)
+
+	delimiter := s.backend.GetDelimiter()
+
+	personal, err := s.encodeNamespacePrefixes(namespaces.Personal, delimiter)
+	if err != nil {
+		return err
+	}
+
+	otherUsers, err := s.encodeNamespacePrefixes(namespaces.OtherUsers, delimiter)
+	if err != nil {
+		return err
+	}
+
+	shared, err := s.encodeNamespacePrefixes(namespaces.Shared, delimiter)
+	if err != nil {
+		return err
+	}
+
+	ch <- response.Namespace().
+		WithPersonal(personal...).
+		WithOtherUsers(otherUsers...).
+		WithShared(shared...).
+		WithDelimiter(delimiter)
+
+	ch <- response.Ok(tag).WithMessage("NAMESPACE")
+
+	return nil
+}
+
+// encodeNamespacePrefixes joins the namespace prefixes with the hierarchy delimiter and encodes them like mailbox names.
+// Non-empty prefixes end with the delimiter, as mailbox names in the namespace are built by appending to it.
+func (s *Session) encodeNamespacePrefixes(namespaces []imap.Namespace, delimiter string) ([]string, error) {
+	prefixes := make([]string, 0, len(namespaces))
+
+	for _, namespace := range namespaces {
+		var prefix string
+
+		if len(namespace.Prefix) > 0 {
+			prefix = strings.Join(namespace.Prefix, delimiter) + delimiter
+		}
+
+		encoded, err := s.encodeMailboxName(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode namespace prefix: %w", err)
+		}
+
+		prefixes = append(prefixes, encoded)
+	}
+
+	return prefixes, nil
+}
@@ -21,6 +21,13 @@ func (s *Session) handleNoop(ctx context.Context, tag string, _ *command.Noop, c
 		}
 	}
 
+	// Without a selected mailbox, only the pending notifications (RFC5465) are sent.
+	if (s.state != nil) && !s.state.IsSelected() {
+		for _, res := range s.state.PopNotifications() {
+			ch <- res
+		}
+	}
+
 	ch <- response.Ok(tag).WithMessage(okMessage(ctx))
 
 	return nil
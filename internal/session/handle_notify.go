@@ -0,0 +1,100 @@
+package session
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ProtonMail/gluon/imap/command"
+	"github.com/ProtonMail/gluon/internal/response"
+	"github.com/ProtonMail/gluon/internal/state"
+	"github.com/ProtonMail/gluon/profiling"
+	"github.com/bradenaw/juniper/xslices"
+	"golang.org/x/exp/slices"
+)
+
+// handleNotify sets the events the client wants to be notified about for mailboxes other than the selected one. The
+// selected mailbox keeps being reported with the usual untagged responses, so SELECTED and SELECTED-DELAYED groups
+// asking for fetch attributes or for no events at all are rejected.
+func (s *Session) handleNotify(ctx context.Context, tag string, cmd *command.Notify, ch chan response.Response) error {
+	profiling.Start(ctx, profiling.CmdTypeNotify)
+	defer profiling.Stop(ctx, profiling.CmdTypeNotify)
+
+	if len(cmd.EventGroups) == 0 {
+		s.state.SetNotify(nil)
+
+		ch <- response.Ok(tag).WithMessage("NOTIFY")
+
+		return nil
+	}
+
+	groups := make([]command.NotifyEventGroup, 0, len(cmd.EventGroups))
+
+	for _, group := range cmd.EventGroups {
+		if group.Filter == command.NotifyFilterSelected || group.Filter == command.NotifyFilterSelectedDelayed {
+			if len(group.FetchAttributes) > 0 {
+				return response.No(tag).WithError(
+					fmt.Errorf("%w: %v with MessageNew fetch attributes", ErrNotifyUnsupportedFilter, group.Filter),
+				)
+			}
+
+			if len(group.Events) == 0 {
+				return response.No(tag).WithError(fmt.Errorf("%w: %v NONE", ErrNotifyUnsupportedFilter, group.Filter))
+			}
+		}
+
+		for _, event := range group.Events {
+			if !slices.Contains(state.NotifyEvents, event) {
+				return response.No(tag).WithError(ErrNotifyUnsupportedEvent).WithItems(response.ItemBadEvent(
+					xslices.Map(state.NotifyEvents, func(event command.NotifyEvent) string { return string(event) })...,
+				))
+			}
+		}
+
+		// A client can't learn about new messages without also learning about expunged ones, and vice versa (RFC5465).
+		hasNew := slices.Contains(group.Events, command.NotifyEventMessageNew)
+		hasExpunge := slices.Contains(group.Events, command.NotifyEventMessageExpunge)
+
+		if hasNew != hasExpunge {
+			return response.Bad(tag).WithError(ErrNotifyMessageEvents)
+		}
+
+		if slices.Contains(group.Events, command.NotifyEventFlagChange) && !hasNew {
+			return response.Bad(tag).WithError(ErrNotifyFlagChange)
+		}
+
+		mailboxes := make([]string, 0, len(group.Mailboxes))
+
+		for _, mailbox := range group.Mailboxes {
+			nameUTF8, err := s.decodeMailboxName(mailbox)
+			if err != nil {
+				return err
+			}
+
+			mailboxes = append(mailboxes, nameUTF8)
+		}
+
+		group.Mailboxes = mailboxes
+
+		groups = append(groups, group)
+	}
+
+	s.state.SetNotify(&state.NotifyOptions{
+		EventGroups: groups,
+		EncodeName:  s.encodeMailboxName,
+	})
+
+	if cmd.Status {
+		res, err := s.state.NotifyStatus(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, res := range res {
+			ch <- res
+		}
+	}
+
+	ch <- response.Ok(tag).WithMessage("NOTIFY")
+
+	return nil
+}
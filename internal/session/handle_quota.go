@@ -0,0 +1,80 @@
+package session
+
+import (
+	"context"
+
+	"github.com/ProtonMail/gluon/imap"
+	"github.com/ProtonMail/gluon/imap/command"
+	"github.com/ProtonMail/gluon/internal/response"
+	"github.com/ProtonMail/gluon/internal/state"
+	"github.com/ProtonMail/gluon/profiling"
+)
+
+func (s *Session) handleGetQuota(ctx context.Context, tag string, cmd *command.GetQuota, ch chan response.Response) error {
+	profiling.Start(ctx, profiling.CmdTypeGetQuota)
+	defer profiling.Stop(ctx, profiling.CmdTypeGetQuota)
+
+	quota, ok, err := s.state.GetQuota(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !ok || cmd.Root != imap.QuotaRoot {
+		return ErrNoSuchQuotaRoot
+	}
+
+	ch <- newQuotaResponse(quota)
+
+	ch <- response.Ok(tag).WithMessage("GETQUOTA")
+
+	return nil
+}
+
+func (s *Session) handleGetQuotaRoot(ctx context.Context, tag string, cmd *command.GetQuotaRoot, ch chan response.Response) error {
+	profiling.Start(ctx, profiling.CmdTypeGetQuotaRoot)
+	defer profiling.Stop(ctx, profiling.CmdTypeGetQuotaRoot)
+
+	nameUTF8, err := s.decodeMailboxName(cmd.Mailbox)
+	if err != nil {
+		return err
+	}
+
+	if err := s.state.Mailbox(ctx, nameUTF8, func(*state.Mailbox) error {
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	quota, ok, err := s.state.GetQuota(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Mailboxes have no quota root if the connector doesn't report a quota.
+	if !ok {
+		ch <- response.QuotaRoot(cmd.Mailbox)
+	} else {
+		ch <- response.QuotaRoot(cmd.Mailbox, imap.QuotaRoot)
+		ch <- newQuotaResponse(quota)
+	}
+
+	ch <- response.Ok(tag).WithMessage("GETQUOTAROOT")
+
+	return nil
+}
+
+// newQuotaResponse returns the QUOTA response of the given quota. Only the limited resources are listed; the storage
+// is reported in units of 1024 bytes as required by RFC9208.
+func newQuotaResponse(quota imap.Quota) response.Response {
+	res := response.Quota(imap.QuotaRoot)
+
+	if quota.StorageLimit > 0 {
+		res.WithResource("STORAGE", (quota.StorageUsage+1023)/1024, quota.StorageLimit/1024)
+	}
+
+	if quota.MessageLimit > 0 {
+		res.WithResource("MESSAGE", quota.MessageUsage, quota.MessageLimit)
+	}
+
+	return res
+}
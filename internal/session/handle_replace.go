@@ -0,0 +1,82 @@
+package session
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ProtonMail/gluon/imap/command"
+	"github.com/ProtonMail/gluon/internal/contexts"
+	"github.com/ProtonMail/gluon/internal/response"
+	"github.com/ProtonMail/gluon/internal/state"
+	"github.com/ProtonMail/gluon/profiling"
+	"github.com/ProtonMail/gluon/reporter"
+	"github.com/ProtonMail/gluon/rfcvalidation"
+)
+
+func (s *Session) handleReplace(ctx context.Context, tag string, cmd *command.Replace, mailbox *state.Mailbox, ch chan response.Response) (response.Response, error) {
+	if contexts.IsUID(ctx) {
+		profiling.Start(ctx, profiling.CmdTypeUIDReplace)
+		defer profiling.Stop(ctx, profiling.CmdTypeUIDReplace)
+	} else {
+		profiling.Start(ctx, profiling.CmdTypeReplace)
+		defer profiling.Stop(ctx, profiling.CmdTypeReplace)
+	}
+
+	nameUTF8, err := s.decodeMailboxName(cmd.Mailbox)
+	if err != nil {
+		return nil, err
+	}
+
+	if mailbox.ReadOnly() {
+		return nil, ErrReadOnly
+	}
+
+	literal := cmd.Message.Literal
+
+	if len(cmd.Message.Catenate) > 0 {
+		if literal, err = s.catenateParts(ctx, tag, cmd.Message.Catenate); err != nil {
+			return nil, err
+		}
+	}
+
+	flags, err := validateStoreFlags(cmd.Message.Flags)
+	if err != nil {
+		return response.Bad(tag).WithError(err), nil
+	}
+
+	var invalidErr error
+
+	item, err := mailbox.Replace(ctx, cmd.SeqNum, nameUTF8, literal, flags, cmd.Message.DateTime, func(isDrafts bool) error {
+		if !isDrafts {
+			invalidErr = rfcvalidation.ValidateMessageHeaderFields(literal)
+		}
+
+		return invalidErr
+	})
+	if invalidErr != nil {
+		return response.Bad(tag).WithError(invalidErr), nil
+	} else if errors.Is(err, state.ErrNoSuchMessage) {
+		return response.No(tag).WithError(err), nil
+	} else if errors.Is(err, state.ErrNoSuchMailbox) {
+		return response.No(tag).WithError(err).WithItems(response.ItemTryCreate()), nil
+	} else if errors.Is(err, state.ErrOverQuota) {
+		return response.No(tag).WithError(err).WithItems(response.ItemOverQuota()), nil
+	} else if err != nil {
+		if shouldReportIMAPCommandError(err) {
+			reporter.MessageWithContext(ctx,
+				"Failed to replace message",
+				reporter.Context{"error": err, "mailbox": nameUTF8},
+			)
+		}
+
+		return nil, err
+	}
+
+	ch <- response.Ok().WithItems(item).WithMessage("Replacement message ready")
+
+	if err := flush(ctx, mailbox, true, ch); err != nil {
+		return nil, err
+	}
+
+	return response.Ok(tag).WithMessage(okMessage(ctx)), nil
+}
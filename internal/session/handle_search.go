@@ -3,11 +3,13 @@ package session
 import (
 	"context"
 
+	"github.com/ProtonMail/gluon/imap"
 	"github.com/ProtonMail/gluon/imap/command"
 	"github.com/ProtonMail/gluon/internal/contexts"
 	"github.com/ProtonMail/gluon/internal/response"
 	"github.com/ProtonMail/gluon/internal/state"
 	"github.com/ProtonMail/gluon/profiling"
+	"golang.org/x/exp/slices"
 	"golang.org/x/text/encoding"
 	"golang.org/x/text/encoding/ianaindex"
 )
@@ -21,29 +23,52 @@ func (s *Session) handleSearch(ctx context.Context, tag string, cmd *command.Sea
 		defer profiling.Stop(ctx, profiling.CmdTypeSearch)
 	}
 
-	var decoder *encoding.Decoder
-
-	if len(cmd.Charset) != 0 {
-		encoding, err := ianaindex.IANA.Encoding(cmd.Charset)
-		if err != nil {
-			return nil, response.No(tag).WithItems(response.ItemBadCharset())
-		}
+	decoder, err := getSearchDecoder(tag, cmd.Charset)
+	if err != nil {
+		return nil, err
+	}
 
-		decoder = encoding.NewDecoder()
-	} else {
-		decoder = encoding.Nop.NewDecoder()
+	// IMAP4rev2 (RFC9051) clients only get ESEARCH responses; a search without result options returns all messages.
+	if cmd.Return == nil && s.IsIMAP4rev2() {
+		cmd.Return = []command.SearchReturnOption{command.SearchReturnOptionAll}
 	}
 
-	seq, err := mailbox.Search(ctx, cmd.Keys, decoder)
+	save := slices.Contains(cmd.Return, command.SearchReturnOptionSave)
+
+	seq, modSeq, err := mailbox.Search(ctx, cmd.Keys, decoder)
 	if err != nil {
+		// A failed search leaves an empty saved result behind (RFC5182).
+		if save {
+			mailbox.SaveSearchResult(ctx, nil)
+		}
+
 		return nil, err
 	}
 
-	select {
-	case ch <- response.Search(seq...):
+	if save {
+		mailbox.SaveSearchResult(ctx, getSavedSearchResult(cmd, seq))
+	}
+
+	var res response.Response
 
-	case <-ctx.Done():
-		return nil, ctx.Err()
+	switch {
+	case cmd.Return == nil:
+		res = response.Search(seq...).WithModSeq(modSeq)
+
+	case len(cmd.Return) == 1 && save:
+		// If the result is only saved, no ESEARCH response is returned.
+
+	default:
+		res = newESearchResponse(ctx, tag, cmd, seq, modSeq)
+	}
+
+	if res != nil {
+		select {
+		case ch <- res:
+
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
 	}
 
 	var items []response.Item
@@ -56,3 +81,93 @@ func (s *Session) handleSearch(ctx context.Context, tag string, cmd *command.Sea
 		WithItems(items...).
 		WithMessage(okMessage(ctx)), nil
 }
+
+// newESearchResponse builds the ESEARCH response (RFC4731) holding the requested result options.
+func newESearchResponse(ctx context.Context, tag string, cmd *command.Search, seq []uint32, modSeq imap.ModSeq) response.Response {
+	res := response.ESearch(tag)
+
+	if contexts.IsUID(ctx) {
+		res.WithUID()
+	}
+
+	for _, option := range cmd.Return {
+		switch option {
+		case command.SearchReturnOptionMin:
+			if len(seq) > 0 {
+				res.WithMin(seq[0])
+			}
+
+		case command.SearchReturnOptionMax:
+			if len(seq) > 0 {
+				res.WithMax(seq[len(seq)-1])
+			}
+
+		case command.SearchReturnOptionCount:
+			res.WithCount(len(seq))
+
+		case command.SearchReturnOptionAll:
+			res.WithAll(seq...)
+
+		case command.SearchReturnOptionPartial:
+			res.WithPartial(cmd.Partial.String(), getPartialResult(cmd.Partial, seq)...)
+		}
+	}
+
+	if len(seq) > 0 {
+		res.WithModSeq(modSeq)
+	}
+
+	return res
+}
+
+// getSavedSearchResult returns the part of the search result which is saved by the SAVE result option (RFC5182).
+// If only PARTIAL (RFC9394), MIN and/or MAX are requested alongside SAVE, only the returned messages are saved.
+func getSavedSearchResult(cmd *command.Search, seq []uint32) []uint32 {
+	options := cmd.Return
+
+	if len(seq) == 0 || slices.Contains(options, command.SearchReturnOptionAll) || slices.Contains(options, command.SearchReturnOptionCount) {
+		return seq
+	}
+
+	if cmd.Partial != nil {
+		return getPartialResult(cmd.Partial, seq)
+	}
+
+	wantMin := slices.Contains(options, command.SearchReturnOptionMin)
+	wantMax := slices.Contains(options, command.SearchReturnOptionMax)
+
+	switch {
+	case wantMin && wantMax && len(seq) > 1:
+		return []uint32{seq[0], seq[len(seq)-1]}
+
+	case wantMin:
+		return seq[:1]
+
+	case wantMax:
+		return seq[len(seq)-1:]
+
+	default:
+		return seq
+	}
+}
+
+// getPartialResult returns the given range of the search result (RFC9394).
+func getPartialResult(partial *command.PartialRange, seq []uint32) []uint32 {
+	begin, end := partial.Bounds(len(seq))
+
+	return seq[begin:end]
+}
+
+// getSearchDecoder returns the decoder of the charset used by the search keys.
+func getSearchDecoder(tag string, charset string) (*encoding.Decoder, error) {
+	if len(charset) == 0 {
+		return encoding.Nop.NewDecoder(), nil
+	}
+
+	encoding, err := ianaindex.IANA.Encoding(charset)
+	if err != nil {
+		return nil, response.No(tag).WithItems(response.ItemBadCharset())
+	}
+
+	return encoding.NewDecoder(), nil
+}
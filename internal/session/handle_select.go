@@ -6,6 +6,7 @@ import (
 	"github.com/ProtonMail/gluon/events"
 	"github.com/ProtonMail/gluon/imap"
 	"github.com/ProtonMail/gluon/imap/command"
+	"github.com/ProtonMail/gluon/internal/contexts"
 	"github.com/ProtonMail/gluon/internal/response"
 	"github.com/ProtonMail/gluon/internal/state"
 	"github.com/ProtonMail/gluon/profiling"
@@ -20,7 +21,17 @@ func (s *Session) handleSelect(ctx context.Context, tag string, cmd *command.Sel
 		return err
 	}
 
+	if cmd.CondStore {
+		s.state.Enable(imap.CONDSTORE)
+	}
+
+	wasSelected := s.state.IsSelected()
+
 	if err := s.state.Select(ctx, nameUTF8, func(mailbox *state.Mailbox) error {
+		if wasSelected && s.state.IsEnabled(imap.QRESYNC) {
+			ch <- response.Ok().WithItems(response.ItemClosed()).WithMessage("Previous mailbox closed")
+		}
+
 		flags, err := mailbox.Flags(ctx)
 		if err != nil {
 			return err
@@ -42,11 +53,29 @@ func (s *Session) handleSelect(ctx context.Context, tag string, cmd *command.Sel
 		ch <- response.Ok().WithItems(response.ItemPermanentFlags(permFlags)).WithMessage("Flags permitted")
 		ch <- response.Ok().WithItems(response.ItemUIDNext(uidNext)).WithMessage("Predicted next UID")
 		ch <- response.Ok().WithItems(response.ItemUIDValidity(mailbox.UIDValidity())).WithMessage("UIDs valid")
+		ch <- response.Ok().WithItems(response.ItemMailboxID(mailbox.MailboxID())).WithMessage("Mailbox ID")
 
-		if unseen, ok := mailbox.GetFirstMessageWithoutFlag(imap.FlagSeen); ok {
+		// The UNSEEN response code was removed in IMAP4rev2 (RFC9051); it holds a sequence number, which UIDONLY clients
+		// don't use (RFC9586).
+		if unseen, ok := mailbox.GetFirstMessageWithoutFlag(imap.FlagSeen); ok && !s.IsIMAP4rev2() && !s.state.IsEnabled(imap.UIDONLY) {
 			ch <- response.Ok().WithItems(response.ItemUnseen(uint32(unseen.Seq))).WithMessage("Unseen messages")
 		}
 
+		if s.state.IsEnabled(imap.CONDSTORE) {
+			highestModSeq, err := mailbox.HighestModSeq(ctx)
+			if err != nil {
+				return err
+			}
+
+			ch <- response.Ok().WithItems(response.ItemHighestModSeq(highestModSeq)).WithMessage("Highest")
+		}
+
+		if cmd.QResync != nil {
+			if err := resyncMailbox(ctx, mailbox, cmd.QResync, ch); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return err
@@ -61,3 +90,35 @@ func (s *Session) handleSelect(ctx context.Context, tag string, cmd *command.Sel
 
 	return nil
 }
+
+// resyncMailbox sends the changes the client missed since the mod sequence given in the QRESYNC select parameter
+// (RFC7162). Nothing is sent if the client's UID validity is outdated.
+func resyncMailbox(ctx context.Context, mailbox *state.Mailbox, params *command.QResync, ch chan response.Response) error {
+	if imap.UID(params.UIDValidity) != mailbox.UIDValidity() {
+		return nil
+	}
+
+	uids, err := mailbox.Vanished(ctx, imap.ModSeq(params.ModSeq), params.KnownUIDs)
+	if err != nil {
+		return err
+	}
+
+	if len(uids) > 0 {
+		ch <- response.VanishedSet(uids).WithEarlier()
+	}
+
+	if mailbox.Count() == 0 {
+		return nil
+	}
+
+	seqSet := params.KnownUIDs
+	if seqSet == nil {
+		seqSet = []command.SeqRange{{Begin: 1, End: command.SeqNumValueAsterisk}}
+	}
+
+	return mailbox.Fetch(contexts.AsUID(ctx), &command.Fetch{
+		SeqSet:       seqSet,
+		Attributes:   []command.FetchAttribute{&command.FetchAttributeFlags{}},
+		ChangedSince: params.ModSeq,
+	}, ch)
+}
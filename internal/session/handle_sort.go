@@ -0,0 +1,48 @@
+package session
+
+import (
+	"context"
+
+	"github.com/ProtonMail/gluon/imap/command"
+	"github.com/ProtonMail/gluon/internal/contexts"
+	"github.com/ProtonMail/gluon/internal/response"
+	"github.com/ProtonMail/gluon/internal/state"
+	"github.com/ProtonMail/gluon/profiling"
+)
+
+func (s *Session) handleSort(ctx context.Context, tag string, cmd *command.Sort, mailbox *state.Mailbox, ch chan response.Response) (response.Response, error) {
+	if contexts.IsUID(ctx) {
+		profiling.Start(ctx, profiling.CmdTypeUIDSort)
+		defer profiling.Stop(ctx, profiling.CmdTypeUIDSort)
+	} else {
+		profiling.Start(ctx, profiling.CmdTypeSort)
+		defer profiling.Stop(ctx, profiling.CmdTypeSort)
+	}
+
+	decoder, err := getSearchDecoder(tag, cmd.Charset)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, modSeq, err := mailbox.Sort(ctx, cmd.Criteria, cmd.Keys, decoder)
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case ch <- response.Sort(ids...).WithModSeq(modSeq):
+
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	var items []response.Item
+
+	if mailbox.ExpungeIssued() {
+		items = append(items, response.ItemExpungeIssued())
+	}
+
+	return response.Ok(tag).
+		WithItems(items...).
+		WithMessage(okMessage(ctx)), nil
+}
@@ -20,46 +20,83 @@ func (s *Session) handleStatus(ctx context.Context, tag string, cmd *command.Sta
 	}
 
 	if err := s.state.Mailbox(ctx, nameUTF8, func(mailbox *state.Mailbox) error {
-		if mailbox.Selected() {
-			if err := flush(ctx, mailbox, true, ch); err != nil {
-				return err
-			}
+		items, err := s.getStatusItems(ctx, mailbox, cmd.Attributes, ch)
+		if err != nil {
+			return err
 		}
 
-		var items []response.Item
+		ch <- response.Status().WithMailbox(cmd.Mailbox).WithItems(items...)
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	ch <- response.Ok(tag).WithMessage("STATUS")
 
-		for _, att := range cmd.Attributes {
-			switch att {
-			case command.StatusAttributeMessages:
-				items = append(items, response.ItemMessages(mailbox.Count()))
+	return nil
+}
 
-			case command.StatusAttributeRecent:
-				items = append(items, response.ItemRecent(mailbox.GetMessagesWithFlagCount(imap.FlagRecent)))
+// getStatusItems returns the requested status attributes of the given mailbox. Pending updates of the selected
+// mailbox are flushed first so the counts are up-to-date.
+func (s *Session) getStatusItems(
+	ctx context.Context,
+	mailbox *state.Mailbox,
+	attributes []command.StatusAttribute,
+	ch chan response.Response,
+) ([]response.Item, error) {
+	if mailbox.Selected() {
+		if err := flush(ctx, mailbox, true, ch); err != nil {
+			return nil, err
+		}
+	}
 
-			case command.StatusAttributeUIDNext:
-				uidNext, err := mailbox.UIDNext(ctx)
-				if err != nil {
-					return err
-				}
+	var items []response.Item
 
-				items = append(items, response.ItemUIDNext(uidNext))
+	for _, att := range attributes {
+		switch att {
+		case command.StatusAttributeMessages:
+			items = append(items, response.ItemMessages(mailbox.Count()))
 
-			case command.StatusAttributeUIDValidity:
-				items = append(items, response.ItemUIDValidity(mailbox.UIDValidity()))
+		case command.StatusAttributeRecent:
+			items = append(items, response.ItemRecent(mailbox.GetMessagesWithFlagCount(imap.FlagRecent)))
 
-			case command.StatusAttributeUnseen:
-				items = append(items, response.ItemUnseen(uint32(mailbox.GetMessagesWithoutFlagCount(imap.FlagSeen))))
+		case command.StatusAttributeUIDNext:
+			uidNext, err := mailbox.UIDNext(ctx)
+			if err != nil {
+				return nil, err
 			}
-		}
 
-		ch <- response.Status().WithMailbox(cmd.Mailbox).WithItems(items...)
+			items = append(items, response.ItemUIDNext(uidNext))
 
-		return nil
-	}); err != nil {
-		return err
-	}
+		case command.StatusAttributeUIDValidity:
+			items = append(items, response.ItemUIDValidity(mailbox.UIDValidity()))
 
-	ch <- response.Ok(tag).WithMessage("STATUS")
+		case command.StatusAttributeUnseen:
+			items = append(items, response.ItemUnseen(uint32(mailbox.GetMessagesWithoutFlagCount(imap.FlagSeen))))
 
-	return nil
+		case command.StatusAttributeHighestModSeq:
+			s.state.Enable(imap.CONDSTORE)
+
+			highestModSeq, err := mailbox.HighestModSeq(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			items = append(items, response.ItemHighestModSeq(highestModSeq))
+
+		case command.StatusAttributeSize:
+			size, err := mailbox.Size(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			items = append(items, response.ItemSize(size))
+
+		case command.StatusAttributeMailboxID:
+			items = append(items, response.ItemMailboxID(mailbox.MailboxID()))
+		}
+	}
+
+	return items, nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/ProtonMail/gluon/imap"
 	"github.com/ProtonMail/gluon/imap/command"
 	"github.com/ProtonMail/gluon/internal/contexts"
 	"github.com/ProtonMail/gluon/internal/response"
@@ -35,7 +36,15 @@ func (s *Session) handleStore(ctx context.Context, tag string, cmd *command.Stor
 		return response.Bad(tag).WithError(err), nil
 	}
 
-	if err := mailbox.Store(ctx, cmd.SeqSet, cmd.Action, flags); errors.Is(err, state.ErrNoSuchMessage) {
+	var unchangedSince *imap.ModSeq
+
+	if cmd.UnchangedSince != nil {
+		modSeq := imap.ModSeq(*cmd.UnchangedSince)
+		unchangedSince = &modSeq
+	}
+
+	modified, err := mailbox.Store(ctx, cmd.SeqSet, cmd.Action, flags, unchangedSince)
+	if errors.Is(err, state.ErrNoSuchMessage) {
 		return response.Bad(tag).WithError(err), nil
 	} else if err != nil {
 		// A result of either a failed request (API unreachable), or the message does not exist on remote.
@@ -54,6 +63,12 @@ func (s *Session) handleStore(ctx context.Context, tag string, cmd *command.Stor
 		items = append(items, response.ItemExpungeIssued())
 	}
 
+	if len(modified) != 0 {
+		return response.Ok(tag).
+			WithItems(append(items, response.ItemModified(modified))...).
+			WithMessage("Conditional STORE failed"), nil
+	}
+
 	return response.Ok(tag).
 		WithItems(items...).
 		WithMessage(okMessage(ctx)), nil
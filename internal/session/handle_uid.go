@@ -23,9 +23,18 @@ func (s *Session) handleUID(ctx context.Context, tag string, cmd *command.UID, m
 	case *command.Search:
 		return s.handleSearch(contexts.AsUID(ctx), tag, cmd, mailbox, ch)
 
+	case *command.Sort:
+		return s.handleSort(contexts.AsUID(ctx), tag, cmd, mailbox, ch)
+
+	case *command.Thread:
+		return s.handleThread(contexts.AsUID(ctx), tag, cmd, mailbox, ch)
+
 	case *command.Store:
 		return s.handleStore(contexts.AsUID(ctx), tag, cmd, mailbox, ch)
 
+	case *command.Replace:
+		return s.handleReplace(contexts.AsUID(ctx), tag, cmd, mailbox, ch)
+
 	default:
 		panic("bad command")
 	}
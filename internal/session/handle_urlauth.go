@@ -0,0 +1,191 @@
+package session
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/ProtonMail/gluon/imap/command"
+	"github.com/ProtonMail/gluon/internal/response"
+	"github.com/ProtonMail/gluon/internal/state"
+	"github.com/ProtonMail/gluon/profiling"
+	"golang.org/x/exp/slices"
+)
+
+func (s *Session) handleResetKey(ctx context.Context, tag string, cmd *command.ResetKey, ch chan response.Response) error {
+	profiling.Start(ctx, profiling.CmdTypeResetKey)
+	defer profiling.Stop(ctx, profiling.CmdTypeResetKey)
+
+	if slices.ContainsFunc(cmd.Mechanisms, isUnsupportedURLAuthMechanism) {
+		return response.No(tag).WithError(ErrURLAuthUnsupportedMechanism)
+	}
+
+	var name string
+
+	if cmd.Mailbox != "" {
+		nameUTF8, err := s.decodeMailboxName(cmd.Mailbox)
+		if err != nil {
+			return err
+		}
+
+		name = nameUTF8
+	}
+
+	if err := s.state.ResetURLAuthKeys(ctx, name); err != nil {
+		return err
+	}
+
+	ch <- response.Ok(tag).WithMessage("RESETKEY")
+
+	return nil
+}
+
+func (s *Session) handleGenURLAuth(ctx context.Context, tag string, cmd *command.GenURLAuth, ch chan response.Response) error {
+	profiling.Start(ctx, profiling.CmdTypeGenURLAuth)
+	defer profiling.Stop(ctx, profiling.CmdTypeGenURLAuth)
+
+	urls := make([]string, 0, len(cmd.URLs))
+
+	for _, req := range cmd.URLs {
+		if isUnsupportedURLAuthMechanism(req.Mechanism) {
+			return response.No(tag).WithError(ErrURLAuthUnsupportedMechanism)
+		}
+
+		u, err := command.ParseURL(req.URL)
+		if err != nil {
+			return response.Bad(tag).WithError(err)
+		}
+
+		if !u.IsAbsolute() || u.Access == "" || u.Token != "" {
+			return response.Bad(tag).WithError(ErrURLAuthInvalidRump)
+		}
+
+		// Only the user's own messages can be shared.
+		if !strings.EqualFold(u.User, s.userName) {
+			return response.No(tag).WithError(ErrURLAuthOtherUser)
+		}
+
+		if !isSupportedURLAuthAccess(u.Access) {
+			return response.No(tag).WithError(ErrURLAuthUnsupportedAccess)
+		}
+
+		authURL, err := s.state.GenerateURLAuth(ctx, u)
+		if err != nil {
+			return err
+		}
+
+		urls = append(urls, authURL)
+	}
+
+	ch <- response.GenURLAuth(urls...)
+
+	ch <- response.Ok(tag).WithMessage("GENURLAUTH")
+
+	return nil
+}
+
+func (s *Session) handleURLFetch(ctx context.Context, tag string, cmd *command.URLFetch, ch chan response.Response) error {
+	profiling.Start(ctx, profiling.CmdTypeURLFetch)
+	defer profiling.Stop(ctx, profiling.CmdTypeURLFetch)
+
+	res := response.URLFetch()
+
+	for _, rawURL := range cmd.URLs {
+		data, err := s.fetchURL(ctx, rawURL, true)
+		if err != nil {
+			// URLs which can't be resolved are reported as NIL.
+			s.log.WithError(err).Debug("Failed to fetch URL")
+		}
+
+		res = res.WithURL(rawURL, data)
+	}
+
+	ch <- res
+
+	ch <- response.Ok(tag).WithMessage("URLFETCH")
+
+	return nil
+}
+
+// fetchURL returns the content the IMAP URL points at. URLs carrying a URLAUTH authorization (RFC4467) are only
+// resolved if the authorization is valid and grants access to the session's user; it is required if requireAuth is
+// set, and to access the messages of other users.
+func (s *Session) fetchURL(ctx context.Context, rawURL string, requireAuth bool) ([]byte, error) {
+	u, err := command.ParseURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	otherUser := u.User != "" && !strings.EqualFold(u.User, s.userName)
+
+	if otherUser && u.Access == "" {
+		return nil, ErrURLAuthOtherUser
+	}
+
+	if u.Access != "" || requireAuth {
+		if !u.IsAbsolute() || u.Token == "" {
+			return nil, state.ErrURLAuthInvalid
+		}
+
+		if !s.isURLAuthAccessAllowed(u.Access) {
+			return nil, ErrURLAuthAccessDenied
+		}
+	}
+
+	var data []byte
+
+	if otherUser {
+		data, err = s.backend.FetchURL(ctx, u)
+	} else {
+		data, err = s.state.FetchURL(ctx, u)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Distinguish empty content from content which couldn't be resolved.
+	if data == nil {
+		data = []byte{}
+	}
+
+	return data, nil
+}
+
+// isURLAuthAccessAllowed returns whether the URLAUTH access identifier grants access to the session's user.
+func (s *Session) isURLAuthAccessAllowed(access string) bool {
+	switch lower := strings.ToLower(access); {
+	case lower == "anonymous", lower == "authuser":
+		return true
+
+	case strings.HasPrefix(lower, "user+"):
+		user, err := url.PathUnescape(access[len("user+"):])
+		if err != nil {
+			return false
+		}
+
+		return strings.EqualFold(user, s.userName)
+
+	default:
+		return false
+	}
+}
+
+// isSupportedURLAuthAccess returns whether URLs can be authorized with the URLAUTH access identifier. submit+
+// identifiers are not supported as they are only valid for message submission servers, which can't be told apart.
+func isSupportedURLAuthAccess(access string) bool {
+	switch lower := strings.ToLower(access); {
+	case lower == "anonymous", lower == "authuser":
+		return true
+
+	case strings.HasPrefix(lower, "user+"):
+		return len(lower) > len("user+")
+
+	default:
+		return false
+	}
+}
+
+func isUnsupportedURLAuthMechanism(mechanism string) bool {
+	return !strings.EqualFold(mechanism, command.URLAuthMechanismInternal)
+}
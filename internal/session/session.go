@@ -6,12 +6,14 @@ import (
 	"bufio"
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"io"
 	"net"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ProtonMail/gluon/async"
@@ -48,6 +50,9 @@ type Session struct {
 	// userLock protects the session's user object.
 	userLock sync.Mutex
 
+	// userName is the name the client authenticated with, which is the user component of its IMAP URLs (RFC5092).
+	userName string
+
 	// caps is the server's IMAP caps.
 	caps []imap.Capability
 
@@ -66,6 +71,18 @@ type Session struct {
 	// tlsConfig holds TLS information (used, for example, for STARTTLS).
 	tlsConfig *tls.Config
 
+	// compressionEnabled is true if the client may enable compression with COMPRESS.
+	compressionEnabled bool
+
+	// compressionActive is true once the connection has been switched to compressed streams.
+	compressionActive bool
+
+	// imap4rev2 is set once the client has switched the session to IMAP4rev2 (RFC9051) with ENABLE.
+	imap4rev2 atomic.Bool
+
+	// utf8Accept is set once the client has enabled UTF8=ACCEPT (RFC6855) with ENABLE.
+	utf8Accept atomic.Bool
+
 	// idleBulkTime to control how often IDLE responses are sent. 0 means
 	// immediate response with no response merging.
 	idleBulkTime time.Duration
@@ -89,6 +106,9 @@ type Session struct {
 	// disableIMAPAuthenticate disables the IMAP AUTHENTICATE command (client can then only authenticate using LOGIN).
 	disableIMAPAuthenticate bool
 
+	// maxNonSyncLiteralSize is the largest non-synchronizing literal accepted from the client (0 means no limit).
+	maxNonSyncLiteralSize int
+
 	// panicHandler The panic handler.
 	panicHandler async.PanicHandler
 
@@ -110,11 +130,58 @@ func New(
 	inputCollector := command.NewInputCollector(bufio.NewReader(conn))
 	scanner := rfcparser.NewScannerWithReader(inputCollector)
 
-	caps := []imap.Capability{imap.IMAP4rev1, imap.IDLE, imap.UNSELECT, imap.UIDPLUS, imap.MOVE, imap.ID}
+	caps := []imap.Capability{
+		imap.IMAP4rev1,
+		imap.IDLE,
+		imap.UNSELECT,
+		imap.UIDPLUS,
+		imap.MOVE,
+		imap.ID,
+		imap.CONDSTORE,
+		imap.QRESYNC,
+		imap.ENABLE,
+		imap.NAMESPACE,
+		imap.SPECIALUSE,
+		imap.CREATESPECIALUSE,
+		imap.LISTEXTENDED,
+		imap.LISTSTATUS,
+		imap.ESEARCH,
+		imap.SEARCHRES,
+		imap.SORT,
+		imap.QUOTA,
+		imap.QUOTARESSTORAGE,
+		imap.QUOTARESMESSAGE,
+		imap.STATUSSIZE,
+		imap.METADATA,
+		imap.BINARY,
+		imap.UTF8ACCEPT,
+		imap.NOTIFY,
+		imap.MULTIAPPEND,
+		imap.CATENATE,
+		imap.URLAUTH,
+		imap.OBJECTID,
+		imap.PREVIEW,
+		imap.SAVEDATE,
+		imap.WITHIN,
+		imap.UIDONLY,
+		imap.PARTIAL,
+		imap.REPLACE,
+		imap.THREADORDEREDSUBJECT,
+		imap.THREADREFERENCES,
+	}
+
 	if !disableIMAPAuthenticate {
 		caps = append(caps, imap.AUTHPLAIN)
 	}
 
+	maxNonSyncLiteralSize := backend.GetIMAPLimits().MaxNonSyncLiteralSize()
+
+	if maxNonSyncLiteralSize > 0 {
+		caps = append(caps, imap.LITERALMINUS)
+	} else {
+		caps = append(caps, imap.LITERALPLUS)
+	}
+
 	return &Session{
 		conn:                    conn,
 		inputCollector:          inputCollector,
@@ -128,6 +195,7 @@ func New(
 		cmdProfilerBuilder:      profiler,
 		handleWG:                async.MakeWaitGroup(panicHandler),
 		disableIMAPAuthenticate: disableIMAPAuthenticate,
+		maxNonSyncLiteralSize:   maxNonSyncLiteralSize,
 		panicHandler:            panicHandler,
 		log:                     logrus.WithField("pkg", "gluon/session").WithField("session", sessionID),
 	}
@@ -159,6 +227,21 @@ func (s *Session) SetTLSConfig(cfg *tls.Config) {
 	s.addCapability(imap.StartTLS)
 }
 
+func (s *Session) EnableCompression() {
+	s.compressionEnabled = true
+
+	s.addCapability(imap.COMPRESSDEFLATE)
+}
+
+func (s *Session) EnableIMAP4rev2() {
+	s.addCapability(imap.IMAP4rev2)
+}
+
+// IsIMAP4rev2 returns whether the session was switched to IMAP4rev2, in which case responses follow RFC9051.
+func (s *Session) IsIMAP4rev2() bool {
+	return s.imap4rev2.Load()
+}
+
 func (s *Session) Serve(ctx context.Context) error {
 	defer s.done(ctx)
 	defer s.handleWG.Wait()
@@ -194,7 +277,13 @@ func (s *Session) serve(ctx context.Context) error {
 			}
 
 			if res.err != nil {
-				if err := response.Bad(res.command.Tag).WithError(res.err).Send(s); err != nil {
+				bad := response.Bad(res.command.Tag).WithError(res.err)
+
+				if errors.Is(res.err, rfcparser.ErrNonSyncLiteralTooBig) {
+					bad = bad.WithItems(response.ItemTooBig())
+				}
+
+				if err := bad.Send(s); err != nil {
 					return err
 				}
 
@@ -223,6 +312,22 @@ func (s *Session) serve(ctx context.Context) error {
 			case *command.Logout:
 				return s.handleLogout(ctx, res.command.Tag, cmd)
 
+			case *command.Compress:
+				err := s.handleCompress(ctx, res.command.Tag, cmd)
+
+				res.resume()
+
+				if err != nil {
+					errRes, ok := response.FromError(err)
+					if !ok {
+						errRes = response.No(res.command.Tag).WithError(err)
+					}
+
+					if err := errRes.Send(s); err != nil {
+						return fmt.Errorf("failed to send response to client: %w", err)
+					}
+				}
+
 			case *command.Idle:
 				if err := s.handleIdle(ctx, res.command.Tag, cmd, cmdCh); err != nil {
 					if err := response.No(res.command.Tag).WithError(err).Send(s); err != nil {
@@ -323,13 +428,36 @@ func (s *Session) greet() error {
 		Send(s)
 }
 
+// isUTF8MailboxNames returns whether mailbox names are exchanged in UTF-8 rather than in modified UTF-7, which is the
+// case once the client has enabled UTF8=ACCEPT (RFC6855) or IMAP4rev2 (RFC9051).
+func (s *Session) isUTF8MailboxNames() bool {
+	return s.utf8Accept.Load() || s.IsIMAP4rev2()
+}
+
+// decodeMailboxName returns the UTF-8 name of the given mailbox, which is encoded in modified UTF-7 unless the client
+// enabled UTF-8 mailbox names.
 func (s *Session) decodeMailboxName(name string) (string, error) {
+	decoder := utf7.Encoding.NewDecoder().String
+
+	if s.isUTF8MailboxNames() {
+		decoder = func(name string) (string, error) { return name, nil }
+	}
+
 	delimiter := s.backend.GetDelimiter()
 
 	split := strings.SplitAfterN(name, delimiter, 2)
 	if !strings.EqualFold(split[0], fmt.Sprintf("INBOX%v", delimiter)) || len(split) != 2 {
-		return utf7.Encoding.NewDecoder().String(name)
+		return decoder(name)
+	}
+
+	return decoder(fmt.Sprintf("INBOX%v%v", delimiter, split[1]))
+}
+
+// encodeMailboxName returns the given UTF-8 mailbox name as it must be sent to the client.
+func (s *Session) encodeMailboxName(name string) (string, error) {
+	if s.isUTF8MailboxNames() {
+		return name, nil
 	}
 
-	return utf7.Encoding.NewDecoder().String(fmt.Sprintf("INBOX%v%v", delimiter, split[1]))
+	return utf7.Encoding.NewEncoder().String(name)
 }
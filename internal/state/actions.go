@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ProtonMail/gluon/connector"
 	"github.com/ProtonMail/gluon/db"
 	"github.com/ProtonMail/gluon/imap"
 	"github.com/ProtonMail/gluon/internal/ids"
@@ -18,7 +19,7 @@ import (
 )
 
 func (state *State) actionCreateAndGetMailbox(ctx context.Context, tx db.Transaction, name string, uidValidity imap.UID) ([]Update, *db.Mailbox, error) {
-	updates, res, err := state.user.GetRemote().CreateMailbox(ctx, tx, strings.Split(name, state.delimiter))
+	updates, res, err := state.user.GetRemote().CreateMailbox(ctx, tx, strings.Split(name, state.delimiter), imap.NewFlagSet())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -50,13 +51,19 @@ func (state *State) actionCreateAndGetMailbox(ctx context.Context, tx db.Transac
 	return updates, mbox, err
 }
 
-func (state *State) actionCreateMailbox(ctx context.Context, tx db.Transaction, name string, uidValidity imap.UID) ([]Update, error) {
-	updates, res, err := state.user.GetRemote().CreateMailbox(ctx, tx, strings.Split(name, state.delimiter))
+func (state *State) actionCreateMailbox(
+	ctx context.Context,
+	tx db.Transaction,
+	name string,
+	specialUse imap.FlagSet,
+	uidValidity imap.UID,
+) ([]Update, imap.MailboxID, error) {
+	updates, res, err := state.user.GetRemote().CreateMailbox(ctx, tx, strings.Split(name, state.delimiter), specialUse)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	return updates, tx.CreateMailboxIfNotExists(ctx, res, state.delimiter, uidValidity)
+	return updates, res.ID, tx.CreateMailboxIfNotExists(ctx, res, state.delimiter, uidValidity)
 }
 
 func (state *State) actionDeleteMailbox(ctx context.Context, tx db.Transaction, mboxID db.MailboxIDPair) ([]Update, error) {
@@ -96,14 +103,138 @@ func (state *State) actionCreateMessage(
 	isSelectedMailbox bool,
 	cameFromDrafts bool,
 ) ([]Update, imap.UID, error) {
-	var updates []Update
-
 	createUpdates, internalID, res, newLiteral, err := state.user.GetRemote().CreateMessage(ctx, tx, mboxID.RemoteID, literal, flags, date)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	updates = append(updates, createUpdates...)
+	updates, messageUID, err := state.actionStoreCreatedMessage(ctx, tx, mboxID, internalID, res, newLiteral, isSelectedMailbox, cameFromDrafts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return append(createUpdates, updates...), messageUID, nil
+}
+
+// actionCreateMessages creates the messages on the remote with a single request, then stores them in the mailbox in
+// the order of the requests.
+func (state *State) actionCreateMessages(
+	ctx context.Context,
+	tx db.Transaction,
+	mboxID db.MailboxIDPair,
+	reqs []connector.CreateMessageReq,
+	isSelectedMailbox bool,
+	cameFromDrafts bool,
+) ([]Update, []imap.UID, error) {
+	updates, created, err := state.user.GetRemote().CreateMessages(ctx, tx, mboxID.RemoteID, reqs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	messageUIDs := make([]imap.UID, 0, len(created))
+
+	for _, c := range created {
+		storeUpdates, messageUID, err := state.actionStoreCreatedMessage(ctx, tx, mboxID, imap.NewInternalMessageID(), c.Message, c.Literal, isSelectedMailbox, cameFromDrafts)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		updates = append(updates, storeUpdates...)
+		messageUIDs = append(messageUIDs, messageUID)
+	}
+
+	return updates, messageUIDs, nil
+}
+
+// actionReplaceMessage replaces the message of mboxFromID with the literal appended to mboxToID (RFC8508). If the
+// remote updated the message in place, the old message is removed from all its mailboxes and its remote ID is given up
+// to the new one, which is added to the same mailboxes.
+func (state *State) actionReplaceMessage(
+	ctx context.Context,
+	tx db.Transaction,
+	messageID db.MessageIDPair,
+	mboxFromID, mboxToID db.MailboxIDPair,
+	literal []byte,
+	flags imap.FlagSet,
+	date time.Time,
+	isSelectedMailbox bool,
+	cameFromDrafts bool,
+) ([]Update, imap.UID, error) {
+	updates, internalID, res, newLiteral, err := state.user.GetRemote().ReplaceMessage(
+		ctx,
+		tx,
+		messageID.RemoteID,
+		mboxFromID.RemoteID,
+		mboxToID.RemoteID,
+		literal,
+		flags,
+		date,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	mboxIDs := []imap.InternalMailboxID{mboxFromID.InternalID}
+
+	if res.ID == messageID.RemoteID {
+		if mboxIDs, err = tx.GetMessageMailboxIDs(ctx, messageID.InternalID); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	for _, mboxID := range mboxIDs {
+		removeUpdates, err := RemoveMessagesFromMailbox(ctx, tx, mboxID, []imap.InternalMessageID{messageID.InternalID})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		updates = append(updates, removeUpdates...)
+	}
+
+	if res.ID == messageID.RemoteID {
+		if err := tx.MarkMessageAsDeletedAndAssignRandomRemoteID(ctx, messageID.InternalID); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	storeUpdates, messageUID, err := state.actionStoreCreatedMessage(ctx, tx, mboxToID, internalID, res, newLiteral, isSelectedMailbox, cameFromDrafts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	updates = append(updates, storeUpdates...)
+
+	// The message is still in its other mailboxes on the remote, e.g. in its labels.
+	if res.ID == messageID.RemoteID {
+		for _, mboxID := range mboxIDs {
+			if mboxID == mboxFromID.InternalID || mboxID == mboxToID.InternalID {
+				continue
+			}
+
+			_, update, err := AddMessagesToMailbox(ctx, tx, mboxID, []db.MessageIDPair{{InternalID: internalID, RemoteID: res.ID}}, nil, state.imapLimits)
+			if err != nil {
+				return nil, 0, err
+			}
+
+			updates = append(updates, update)
+		}
+	}
+
+	return updates, messageUID, nil
+}
+
+// actionStoreCreatedMessage stores a message which was created on the remote and adds it to the mailbox.
+func (state *State) actionStoreCreatedMessage(
+	ctx context.Context,
+	tx db.Transaction,
+	mboxID db.MailboxIDPair,
+	internalID imap.InternalMessageID,
+	res imap.Message,
+	newLiteral []byte,
+	isSelectedMailbox bool,
+	cameFromDrafts bool,
+) ([]Update, imap.UID, error) {
+	var updates []Update
 
 	{
 		// Handle the case where duplicate messages can return the same remote ID.
@@ -155,6 +286,7 @@ func (state *State) actionCreateMessage(
 		Body:        parsedMessage.Body,
 		Structure:   parsedMessage.Structure,
 		Envelope:    parsedMessage.Envelope,
+		Preview:     parsedMessage.Preview,
 		InternalID:  internalID,
 	}
 
@@ -214,6 +346,7 @@ func (state *State) actionCreateRecoveredMessage(
 		Body:        parsedMessage.Body,
 		Structure:   parsedMessage.Structure,
 		Envelope:    parsedMessage.Envelope,
+		Preview:     parsedMessage.Preview,
 		InternalID:  internalID,
 	}
 
@@ -373,6 +506,7 @@ func (state *State) actionImportRecoveredMessage(
 		Body:        parsedMessage.Body,
 		Structure:   parsedMessage.Structure,
 		Envelope:    parsedMessage.Envelope,
+		Preview:     parsedMessage.Preview,
 		InternalID:  internalID,
 	}
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/ProtonMail/gluon/connector"
 	"github.com/ProtonMail/gluon/db"
 	"github.com/ProtonMail/gluon/imap"
 )
@@ -24,8 +25,8 @@ type Connector interface {
 	// ClearAllConnMetadata clears all metadata values associated with the current connector.
 	ClearAllConnMetadata()
 
-	// CreateMailbox creates a new mailbox with the given name.
-	CreateMailbox(ctx context.Context, tx db.Transaction, name []string) ([]Update, imap.Mailbox, error)
+	// CreateMailbox creates a new mailbox with the given name and special-use attributes.
+	CreateMailbox(ctx context.Context, tx db.Transaction, name []string, specialUse imap.FlagSet) ([]Update, imap.Mailbox, error)
 
 	// UpdateMailbox sets the name of the mailbox with the given ID to the given new name.
 	UpdateMailbox(ctx context.Context, tx db.Transaction, mboxID imap.MailboxID, newName []string) ([]Update, error)
@@ -43,6 +44,28 @@ type Connector interface {
 		date time.Time,
 	) ([]Update, imap.InternalMessageID, imap.Message, []byte, error)
 
+	// CreateMessages appends the message literals to the mailbox with the given ID, either all of them or none.
+	// The created messages are returned in the order of the requests. Unless the connector creates them with a single
+	// request, this is only best-effort: see connector.MessageDeleter.
+	CreateMessages(
+		ctx context.Context,
+		tx db.Transaction,
+		mboxID imap.MailboxID,
+		reqs []connector.CreateMessageReq,
+	) ([]Update, []connector.CreatedMessage, error)
+
+	// ReplaceMessage appends the message literal to the mailbox mboxToID and removes the message with the given ID
+	// from the mailbox mboxFromID. The returned message keeps the ID of the replaced message if it was updated in place.
+	ReplaceMessage(
+		ctx context.Context,
+		tx db.Transaction,
+		messageID imap.MessageID,
+		mboxFromID, mboxToID imap.MailboxID,
+		literal []byte,
+		flags imap.FlagSet,
+		date time.Time,
+	) ([]Update, imap.InternalMessageID, imap.Message, []byte, error)
+
 	// GetMessageLiteral retrieves the message literal from the connector.
 	// Note: this can get called from different go routines.
 	GetMessageLiteral(ctx context.Context, id imap.MessageID) ([]byte, error)
@@ -83,4 +106,14 @@ type Connector interface {
 
 	// SetMessagesForwarded marks the message with the given ID as forwarded.
 	SetMessagesForwarded(ctx context.Context, tx db.Transaction, messageIDs []imap.MessageID, forwarded bool) ([]Update, error)
+
+	// GetNamespaces retrieves the mailbox namespaces exposed to clients.
+	GetNamespaces(ctx context.Context) imap.Namespaces
+
+	// GetQuota retrieves the usage and limits of the account. It returns false if the connector doesn't report any.
+	GetQuota(ctx context.Context) (imap.Quota, bool, error)
+
+	// SetSharedMetadata notifies the connector of changes to shared metadata entries of the given mailbox, or of the
+	// server if the mailbox ID is empty.
+	SetSharedMetadata(ctx context.Context, mboxID imap.MailboxID, entries []imap.MetadataEntry) error
 }
@@ -14,6 +14,13 @@ var (
 	ErrOperationNotAllowed            = errors.New("operation not allowed")
 	ErrMailboxNameBeginsWithSeparator = errors.New("invalid mailbox name: begins with hierarchy separator")
 	ErrMailboxNameAdjacentSeparator   = errors.New("invalid mailbox name: has adjacent hierarchy separators")
+
+	ErrOverQuota = errors.New("quota exceeded")
+
+	ErrMetadataMaxSize = errors.New("metadata value too large")
+	ErrMetadataTooMany = errors.New("too many metadata entries")
+
+	ErrURLAuthInvalid = errors.New("invalid URL authorization")
 )
 
 func IsStateError(err error) bool {
@@ -25,5 +32,9 @@ func IsStateError(err error) bool {
 		errors.Is(err, ErrSessionNotSelected) ||
 		errors.Is(err, ErrOperationNotAllowed) ||
 		errors.Is(err, ErrMailboxNameBeginsWithSeparator) ||
-		errors.Is(err, ErrMailboxNameAdjacentSeparator)
+		errors.Is(err, ErrMailboxNameAdjacentSeparator) ||
+		errors.Is(err, ErrOverQuota) ||
+		errors.Is(err, ErrMetadataMaxSize) ||
+		errors.Is(err, ErrMetadataTooMany) ||
+		errors.Is(err, ErrURLAuthInvalid)
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/ProtonMail/gluon/db"
 	"github.com/ProtonMail/gluon/imap"
 	"github.com/ProtonMail/gluon/imap/command"
+	"github.com/ProtonMail/gluon/internal/contexts"
 	"github.com/ProtonMail/gluon/internal/ids"
 	"github.com/ProtonMail/gluon/internal/response"
 	"github.com/ProtonMail/gluon/rfc822"
@@ -34,6 +35,7 @@ type Mailbox struct {
 
 type AppendOnlyMailbox interface {
 	Append(ctx context.Context, literal []byte, flags imap.FlagSet, date time.Time) (imap.UID, error)
+	AppendMany(ctx context.Context, reqs []connector.CreateMessageReq) ([]imap.UID, error)
 	Flush(ctx context.Context, permitExpunge bool) ([]response.Response, error)
 	UIDValidity() imap.UID
 	IsDrafts(ctx context.Context) (bool, error)
@@ -111,10 +113,76 @@ func (m *Mailbox) UIDNext(ctx context.Context) (imap.UID, error) {
 	})
 }
 
+// Size returns the total size of the messages in the mailbox (RFC8438).
+func (m *Mailbox) Size(ctx context.Context) (int64, error) {
+	return stateDBReadResult(ctx, m.state, func(ctx context.Context, client db.ReadOnly) (int64, error) {
+		return client.GetMailboxSize(ctx, m.id.InternalID)
+	})
+}
+
+func (m *Mailbox) HighestModSeq(ctx context.Context) (imap.ModSeq, error) {
+	return stateDBReadResult(ctx, m.state, func(ctx context.Context, client db.ReadOnly) (imap.ModSeq, error) {
+		return client.GetMailboxHighestModSeq(ctx, m.id.InternalID)
+	})
+}
+
+// Vanished returns the UIDs of the messages which were expunged from the mailbox after the given mod sequence.
+// If uidSet is not nil, only UIDs contained in it are returned. If the expunged UIDs since the mod sequence were
+// pruned, every UID which is not in the mailbox is returned instead, as RFC7162 allows.
+func (m *Mailbox) Vanished(ctx context.Context, modSeq imap.ModSeq, uidSet []command.SeqRange) (imap.SeqSet, error) {
+	type vanishedUIDs struct {
+		expunged []imap.UID
+		missing  []UIDInterval
+	}
+
+	vanished, err := stateDBReadResult(ctx, m.state, func(ctx context.Context, client db.ReadOnly) (vanishedUIDs, error) {
+		uids, err := client.GetMailboxExpungedUIDsSince(ctx, m.id.InternalID, modSeq)
+		if err != nil {
+			return vanishedUIDs{}, err
+		}
+
+		prunedModSeq, err := client.GetMailboxPrunedExpungedModSeq(ctx, m.id.InternalID)
+		if err != nil {
+			return vanishedUIDs{}, err
+		}
+
+		if modSeq >= prunedModSeq {
+			return vanishedUIDs{expunged: uids}, nil
+		}
+
+		uidNext, err := client.GetMailboxUID(ctx, m.id.InternalID)
+		if err != nil {
+			return vanishedUIDs{}, err
+		}
+
+		// Messages expunged after the snapshot was taken are still in it.
+		return vanishedUIDs{expunged: uids, missing: m.snap.messages.missingUIDs(uidNext)}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	expunged, missing := uidIntervalsFromUIDs(vanished.expunged), vanished.missing
+
+	if uidSet = m.snap.resolveSearchRes(uidSet, true); uidSet != nil {
+		known := uidSetIntervals(uidSet)
+
+		expunged = intersectUIDIntervals(expunged, known)
+		missing = intersectUIDIntervals(missing, known)
+	}
+
+	return uidIntervalsToSeqSet(unionUIDIntervals(expunged, missing)), nil
+}
+
 func (m *Mailbox) UIDValidity() imap.UID {
 	return m.uidValidity
 }
 
+// MailboxID returns the remote ID of the mailbox, reported as its MAILBOXID (RFC8474).
+func (m *Mailbox) MailboxID() imap.MailboxID {
+	return m.id.RemoteID
+}
+
 func (m *Mailbox) Subscribed() bool {
 	return m.subscribed
 }
@@ -170,66 +238,43 @@ func (m *Mailbox) AppendRegular(ctx context.Context, literal []byte, flags imap.
 		return 0, err
 	}
 
-	var appendIntoDrafts bool
-
 	attr, err := m.Attributes(ctx)
 	if err != nil {
 		return 0, err
 	}
 
-	// Force create message when appending to drafts so that IMAP clients can create new draft messages.
-	if !attr.Contains(imap.AttrDrafts) {
-		internalIDString, err := rfc822.GetHeaderValue(literal, ids.InternalIDKey)
+	appendIntoDrafts := attr.Contains(imap.AttrDrafts)
+
+	if appendIntoDrafts {
+		newLiteral, err := rfc822.EraseHeaderValue(literal, ids.InternalIDKey)
 		if err != nil {
-			return 0, err
+			m.log.WithError(err).Error("Failed to erase Gluon internal id from draft")
+		} else {
+			literal = newLiteral
 		}
+	}
 
-		if len(internalIDString) > 0 {
-			msgID, err := imap.InternalMessageIDFromString(internalIDString)
+	return stateDBWriteResult(ctx, m.state, func(ctx context.Context, tx db.Transaction) ([]Update, imap.UID, error) {
+		// Force create message when appending to drafts so that IMAP clients can create new draft messages.
+		if !appendIntoDrafts {
+			msgID, ok, err := m.getKnownMessageID(ctx, tx, literal)
 			if err != nil {
-				return 0, err
+				return nil, 0, err
 			}
 
-			if messageDeleted, err := stateDBReadResult(ctx, m.state, func(ctx context.Context, client db.ReadOnly) (bool, error) {
-				return client.GetMessageDeletedFlag(ctx, msgID)
-			}); err != nil {
-				if !errors.Is(err, db.ErrNotFound) {
-					return 0, err
-				}
+			// Only shuffle around messages that haven't been marked for deletion.
+			if ok {
+				m.log.Debugf("Appending duplicate message with Internal ID:%v", msgID.InternalID.ShortID())
 
-				m.log.WithError(err).Warn("The message has an unknown internal ID")
-			} else if !messageDeleted {
-				m.log.Debugf("Appending duplicate message with Internal ID:%v", msgID.ShortID())
-				// Only shuffle around messages that haven't been marked for deletion.
-				if res, err := stateDBWriteResult(ctx, m.state, func(ctx context.Context, tx db.Transaction) ([]Update, []db.UIDWithFlags, error) {
-					remoteID, err := tx.GetMessageRemoteID(ctx, msgID)
-					if err != nil {
-						return nil, nil, err
-					}
-
-					return m.state.actionAddMessagesToMailbox(ctx, tx,
-						[]db.MessageIDPair{{InternalID: msgID, RemoteID: remoteID}},
-						m.id,
-						m.snap == m.state.snap,
-					)
-				}); err != nil {
-					return 0, err
-				} else {
-					return res[0].UID, nil
+				updates, res, err := m.state.actionAddMessagesToMailbox(ctx, tx, []db.MessageIDPair{msgID}, m.id, m.snap == m.state.snap)
+				if err != nil {
+					return nil, 0, err
 				}
+
+				return updates, res[0].UID, nil
 			}
 		}
-	} else {
-		appendIntoDrafts = true
-		newLiteral, err := rfc822.EraseHeaderValue(literal, ids.InternalIDKey)
-		if err != nil {
-			m.log.WithError(err).Error("Failed to erase Gluon internal id from draft")
-		} else {
-			literal = newLiteral
-		}
-	}
 
-	return stateDBWriteResult(ctx, m.state, func(ctx context.Context, tx db.Transaction) ([]Update, imap.UID, error) {
 		return m.state.actionCreateMessage(ctx, tx, m.snap.mboxID, literal, flags, date, m.snap == m.state.snap, appendIntoDrafts)
 	})
 }
@@ -237,6 +282,11 @@ func (m *Mailbox) AppendRegular(ctx context.Context, literal []byte, flags imap.
 var ErrKnownRecoveredMessage = errors.New("known recovered message, possible duplication")
 
 func (m *Mailbox) Append(ctx context.Context, literal []byte, flags imap.FlagSet, date time.Time) (imap.UID, error) {
+	// Messages exceeding the quota would be rejected by the remote; don't store them in the recovery mailbox.
+	if err := m.state.checkQuota(ctx, len(literal), 1); err != nil {
+		return 0, err
+	}
+
 	uid, err := m.AppendRegular(ctx, literal, flags, date)
 	if err != nil {
 		// Can't store messages that exceed size limits
@@ -260,6 +310,146 @@ func (m *Mailbox) Append(ctx context.Context, literal []byte, flags imap.FlagSet
 	return uid, err
 }
 
+// AppendMany appends several messages at once (RFC3502). Either all the messages are appended or none of them is;
+// unlike Append, failed messages are not stored in the recovery mailbox.
+func (m *Mailbox) AppendMany(ctx context.Context, reqs []connector.CreateMessageReq) ([]imap.UID, error) {
+	var size int
+
+	for _, req := range reqs {
+		size += len(req.Literal)
+	}
+
+	if err := m.state.checkQuota(ctx, size, len(reqs)); err != nil {
+		return nil, err
+	}
+
+	if err := stateDBRead(ctx, m.state, func(ctx context.Context, client db.ReadOnly) error {
+		messageCount, uid, err := client.GetMailboxMessageCountAndUID(ctx, m.snap.mboxID.InternalID)
+		if err != nil {
+			return err
+		}
+
+		if err := m.state.imapLimits.CheckMailBoxMessageCount(messageCount, len(reqs)); err != nil {
+			return err
+		}
+
+		return m.state.imapLimits.CheckUIDCount(uid, len(reqs))
+	}); err != nil {
+		return nil, err
+	}
+
+	isDrafts, err := m.IsDrafts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if isDrafts {
+		for i := range reqs {
+			// Force create messages when appending to drafts so that IMAP clients can create new draft messages.
+			if newLiteral, err := rfc822.EraseHeaderValue(reqs[i].Literal, ids.InternalIDKey); err != nil {
+				m.log.WithError(err).Error("Failed to erase Gluon internal id from draft")
+			} else {
+				reqs[i].Literal = newLiteral
+			}
+		}
+	}
+
+	return stateDBWriteResult(ctx, m.state, func(ctx context.Context, tx db.Transaction) ([]Update, []imap.UID, error) {
+		// Messages which are already known are added to the mailbox instead of being created again.
+		known := make(map[int]db.MessageIDPair)
+
+		if !isDrafts {
+			for i, req := range reqs {
+				msgID, ok, err := m.getKnownMessageID(ctx, tx, req.Literal)
+				if err != nil {
+					return nil, nil, err
+				} else if ok {
+					known[i] = msgID
+				}
+			}
+		}
+
+		var newReqs []connector.CreateMessageReq
+
+		for i, req := range reqs {
+			if _, ok := known[i]; !ok {
+				newReqs = append(newReqs, req)
+			}
+		}
+
+		var (
+			updates    []Update
+			createdIDs []imap.UID
+		)
+
+		if len(newReqs) > 0 {
+			createUpdates, uids, err := m.state.actionCreateMessages(ctx, tx, m.snap.mboxID, newReqs, m.snap == m.state.snap, isDrafts)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			updates, createdIDs = createUpdates, uids
+		}
+
+		messageUIDs := make([]imap.UID, 0, len(reqs))
+
+		for i := range reqs {
+			msgID, ok := known[i]
+			if !ok {
+				messageUIDs, createdIDs = append(messageUIDs, createdIDs[0]), createdIDs[1:]
+				continue
+			}
+
+			addUpdates, res, err := m.state.actionAddMessagesToMailbox(ctx, tx, []db.MessageIDPair{msgID}, m.id, m.snap == m.state.snap)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			updates = append(updates, addUpdates...)
+			messageUIDs = append(messageUIDs, res[0].UID)
+		}
+
+		return updates, messageUIDs, nil
+	})
+}
+
+// getKnownMessageID returns the ID of the message whose gluon internal ID is set in the literal's header, if the
+// message exists and isn't marked for deletion.
+func (m *Mailbox) getKnownMessageID(ctx context.Context, client db.ReadOnly, literal []byte) (db.MessageIDPair, bool, error) {
+	internalIDString, err := rfc822.GetHeaderValue(literal, ids.InternalIDKey)
+	if err != nil {
+		return db.MessageIDPair{}, false, err
+	}
+
+	if len(internalIDString) == 0 {
+		return db.MessageIDPair{}, false, nil
+	}
+
+	msgID, err := imap.InternalMessageIDFromString(internalIDString)
+	if err != nil {
+		return db.MessageIDPair{}, false, err
+	}
+
+	if messageDeleted, err := client.GetMessageDeletedFlag(ctx, msgID); err != nil {
+		if !errors.Is(err, db.ErrNotFound) {
+			return db.MessageIDPair{}, false, err
+		}
+
+		m.log.WithError(err).Warn("The message has an unknown internal ID")
+
+		return db.MessageIDPair{}, false, nil
+	} else if messageDeleted {
+		return db.MessageIDPair{}, false, nil
+	}
+
+	remoteID, err := client.GetMessageRemoteID(ctx, msgID)
+	if err != nil {
+		return db.MessageIDPair{}, false, err
+	}
+
+	return db.MessageIDPair{InternalID: msgID, RemoteID: remoteID}, true, nil
+}
+
 func (m *Mailbox) IsDrafts(ctx context.Context) (bool, error) {
 	attrs, err := m.Attributes(ctx)
 	if err != nil {
@@ -379,25 +569,160 @@ func (m *Mailbox) Move(ctx context.Context, seq []command.SeqRange, name string)
 	return res, nil
 }
 
-func (m *Mailbox) Store(ctx context.Context, seqSet []command.SeqRange, action command.StoreAction, flags imap.FlagSet) error {
+// Replace replaces the message with the given sequence number (or UID if this is a UID command) with the literal
+// appended to the mailbox with the given name (RFC8508). The APPENDUID item of the new message is returned.
+// The literal is validated by the given function, depending on whether the target mailbox is the drafts mailbox,
+// within the transaction which replaces the message.
+func (m *Mailbox) Replace(
+	ctx context.Context,
+	seqNum command.SeqNum,
+	name string,
+	literal []byte,
+	flags imap.FlagSet,
+	date time.Time,
+	validate func(isDrafts bool) error,
+) (response.Item, error) {
+	if strings.EqualFold(name, ids.GluonRecoveryMailboxName) || m.state.user.GetRecoveryMailboxID().InternalID == m.snap.mboxID.InternalID {
+		return nil, ErrOperationNotAllowed
+	}
+
+	messages, err := m.snap.getMessagesInRange(ctx, []command.SeqRange{{Begin: seqNum, End: seqNum}})
+	if err != nil {
+		return nil, err
+	} else if len(messages) == 0 {
+		return nil, ErrNoSuchMessage
+	}
+
+	// The replaced message still counts towards the quota, so only the growth of the new message is checked.
+	oldSize, err := stateDBReadResult(ctx, m.state, func(ctx context.Context, client db.ReadOnly) (int, error) {
+		_, size, err := client.GetMessageDateAndSize(ctx, messages[0].ID.InternalID)
+
+		return size, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.state.checkQuota(ctx, max(len(literal)-oldSize, 0), 0); err != nil {
+		return nil, err
+	}
+
+	// The replacement is a new message, even if it was built from the replaced one.
+	if newLiteral, err := rfc822.EraseHeaderValue(literal, ids.InternalIDKey); err != nil {
+		m.log.WithError(err).Error("Failed to erase Gluon internal id from replacement message")
+	} else {
+		literal = newLiteral
+	}
+
+	return stateDBWriteResult(ctx, m.state, func(ctx context.Context, tx db.Transaction) ([]Update, response.Item, error) {
+		mbox, err := tx.GetMailboxByName(ctx, name)
+		if err != nil {
+			if errors.Is(err, db.ErrNotFound) {
+				return nil, nil, ErrNoSuchMailbox
+			}
+
+			return nil, nil, err
+		}
+
+		_, uid, err := tx.GetMailboxMessageCountAndUID(ctx, mbox.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if err := m.state.imapLimits.CheckUIDCount(uid, 1); err != nil {
+			return nil, nil, err
+		}
+
+		attrs, err := tx.GetMailboxAttributes(ctx, mbox.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		isDrafts := attrs.Contains(imap.AttrDrafts)
+
+		if err := validate(isDrafts); err != nil {
+			return nil, nil, err
+		}
+
+		updates, uid, err := m.state.actionReplaceMessage(
+			ctx,
+			tx,
+			messages[0].ID,
+			m.snap.mboxID,
+			db.NewMailboxIDPair(mbox),
+			literal,
+			flags,
+			date,
+			m.snap == m.state.snap && mbox.ID == m.snap.mboxID.InternalID,
+			isDrafts,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return updates, response.ItemAppendUID(mbox.UIDValidity, uid), nil
+	})
+}
+
+// Store applies the flag action to the messages in the given set. If unchangedSince is not nil, only messages whose
+// mod sequence is less than or equal to it are modified (RFC7162). The sequence numbers (or UIDs if this is a UID
+// command) of the messages which failed that test are returned.
+func (m *Mailbox) Store(ctx context.Context, seqSet []command.SeqRange, action command.StoreAction, flags imap.FlagSet, unchangedSince *imap.ModSeq) ([]imap.SeqID, error) {
 	messages, err := m.snap.getMessagesInRange(ctx, seqSet)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return stateDBWrite(ctx, m.state, func(ctx context.Context, tx db.Transaction) ([]Update, error) {
+	if unchangedSince != nil {
+		m.state.Enable(imap.CONDSTORE)
+	}
+
+	return stateDBWriteResult(ctx, m.state, func(ctx context.Context, tx db.Transaction) ([]Update, []imap.SeqID, error) {
+		var modified []imap.SeqID
+
+		if unchangedSince != nil {
+			modSeqs, err := tx.GetMessagesModSeq(ctx, m.id.InternalID, xslices.Map(messages, func(msg snapMsgWithSeq) imap.InternalMessageID {
+				return msg.ID.InternalID
+			}))
+			if err != nil {
+				return nil, nil, err
+			}
+
+			messages = xslices.Filter(messages, func(msg snapMsgWithSeq) bool {
+				if modSeqs[msg.ID.InternalID] <= *unchangedSince {
+					return true
+				}
+
+				if contexts.IsUID(ctx) {
+					modified = append(modified, imap.SeqID(msg.UID))
+				} else {
+					modified = append(modified, msg.Seq)
+				}
+
+				return false
+			})
+		}
+
+		var (
+			updates []Update
+			err     error
+		)
+
 		switch action {
 		case command.StoreActionAddFlags:
-			return m.state.actionAddMessageFlags(ctx, tx, messages, flags)
+			updates, err = m.state.actionAddMessageFlags(ctx, tx, messages, flags)
 
 		case command.StoreActionRemFlags:
-			return m.state.actionRemoveMessageFlags(ctx, tx, messages, flags)
+			updates, err = m.state.actionRemoveMessageFlags(ctx, tx, messages, flags)
 
 		case command.StoreActionSetFlags:
-			return m.state.actionSetMessageFlags(ctx, tx, messages, flags)
+			updates, err = m.state.actionSetMessageFlags(ctx, tx, messages, flags)
+
+		default:
+			err = fmt.Errorf("unknown flag action")
 		}
 
-		return nil, fmt.Errorf("unknown flag action")
+		return updates, modified, err
 	})
 }
 
@@ -6,7 +6,9 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/ProtonMail/gluon/async"
 	"github.com/ProtonMail/gluon/db"
@@ -27,11 +29,38 @@ func (m *Mailbox) Fetch(ctx context.Context, cmd *command.Fetch, ch chan respons
 		return err
 	}
 
+	if cmd.Partial != nil {
+		if snapMessages, err = m.getPartialMessages(ctx, cmd, snapMessages); err != nil {
+			return err
+		}
+	}
+
 	operations := make([]func(snapMsgWithSeq, *db.Message, []byte) (response.Item, error), 0, len(cmd.Attributes))
 
+	uidOnly := m.state.IsEnabled(imap.UIDONLY)
+
+	// The mod sequences and save dates are loaded for all the messages at once, before fetching them.
+	var (
+		modSeqs   map[imap.InternalMessageID]imap.ModSeq
+		saveDates map[imap.InternalMessageID]time.Time
+	)
+
+	// Previews built on demand are stored once the messages are fetched, so they are only built once.
+	var (
+		builtPreviews     = make(map[imap.InternalMessageID]string)
+		builtPreviewsLock sync.Mutex
+	)
+
+	fetchModSeq := func(msg snapMsgWithSeq, _ *db.Message, _ []byte) (response.Item, error) {
+		return response.ItemModSeq(modSeqs[msg.ID.InternalID]), nil
+	}
+
 	var (
 		needsLiteral bool
 		wantUID      bool
+		wantFlags    bool
+		wantModSeq   bool
+		wantSaveDate bool
 		setSeen      bool
 		isBodyFetch  bool
 	)
@@ -40,12 +69,15 @@ func (m *Mailbox) Fetch(ctx context.Context, cmd *command.Fetch, ch chan respons
 		switch attribute := attribute.(type) {
 		case *command.FetchAttributeAll:
 			// Macro equivalent to: (FLAGS INTERNALDATE RFC822.SIZE ENVELOPE).
+			wantFlags = true
 			operations = append(operations, fetchFlags, fetchInternalDate, fetchRFC822Size, fetchEnvelope)
 		case *command.FetchAttributeFast:
 			// Macro equivalent to: (FLAGS INTERNALDATE RFC822.SIZE).
+			wantFlags = true
 			operations = append(operations, fetchFlags, fetchInternalDate, fetchRFC822Size)
 		case *command.FetchAttributeFull:
 			// Macro equivalent to: (FLAGS INTERNALDATE RFC822.SIZE ENVELOPE BODY).
+			wantFlags = true
 			operations = append(operations, fetchFlags, fetchInternalDate, fetchRFC822Size, fetchEnvelope, fetchBody)
 		case *command.FetchAttributeUID:
 			wantUID = true
@@ -70,7 +102,45 @@ func (m *Mailbox) Fetch(ctx context.Context, cmd *command.Fetch, ch chan respons
 		case *command.FetchAttributeRFC822Size:
 			operations = append(operations, fetchRFC822Size)
 		case *command.FetchAttributeFlags:
+			wantFlags = true
+
 			operations = append(operations, fetchFlags)
+		case *command.FetchAttributeModSeq:
+			wantModSeq = true
+
+			m.state.Enable(imap.CONDSTORE)
+
+			operations = append(operations, fetchModSeq)
+		case *command.FetchAttributeEmailID:
+			operations = append(operations, fetchEmailID)
+		case *command.FetchAttributeThreadID:
+			operations = append(operations, fetchThreadID)
+		case *command.FetchAttributeSaveDate:
+			wantSaveDate = true
+
+			op := func(msg snapMsgWithSeq, _ *db.Message, _ []byte) (response.Item, error) {
+				return response.ItemSaveDate(saveDates[msg.ID.InternalID]), nil
+			}
+
+			operations = append(operations, op)
+		case *command.FetchAttributePreview:
+			op := func(msg snapMsgWithSeq, message *db.Message, _ []byte) (response.Item, error) {
+				preview, built, err := m.fetchPreview(ctx, attribute, msg, message)
+				if err != nil {
+					return nil, err
+				}
+
+				if built {
+					builtPreviewsLock.Lock()
+					defer builtPreviewsLock.Unlock()
+
+					builtPreviews[msg.ID.InternalID] = *preview
+				}
+
+				return response.ItemPreview(preview), nil
+			}
+
+			operations = append(operations, op)
 		case *command.FetchAttributeEnvelope:
 			operations = append(operations, fetchEnvelope)
 		case *command.FetchAttributeInternalDate:
@@ -91,10 +161,62 @@ func (m *Mailbox) Fetch(ctx context.Context, cmd *command.Fetch, ch chan respons
 				return fetchAttributeBodySection(attribute, literal)
 			}
 
+			operations = append(operations, op)
+		case *command.FetchAttributeBinarySection:
+			needsLiteral = true
+			isBodyFetch = true
+
+			if !attribute.Peek {
+				setSeen = true
+			}
+
+			op := func(_ snapMsgWithSeq, _ *db.Message, literal []byte) (response.Item, error) {
+				return fetchAttributeBinarySection(attribute, literal)
+			}
+
+			operations = append(operations, op)
+		case *command.FetchAttributeBinarySize:
+			needsLiteral = true
+
+			op := func(_ snapMsgWithSeq, _ *db.Message, literal []byte) (response.Item, error) {
+				return fetchAttributeBinarySize(attribute, literal)
+			}
+
 			operations = append(operations, op)
 		}
 	}
 
+	if cmd.ChangedSince != 0 {
+		m.state.Enable(imap.CONDSTORE)
+	}
+
+	// Once CONDSTORE is enabled, the MODSEQ item must be returned along with FLAGS and with CHANGEDSINCE (RFC7162).
+	if !wantModSeq && m.state.IsEnabled(imap.CONDSTORE) && (wantFlags || cmd.ChangedSince != 0) {
+		wantModSeq = true
+
+		operations = append(operations, fetchModSeq)
+	}
+
+	msgIDs := xslices.Map(snapMessages, func(msg snapMsgWithSeq) imap.InternalMessageID {
+		return msg.ID.InternalID
+	})
+
+	if wantModSeq || cmd.ChangedSince != 0 {
+		if modSeqs, err = stateDBReadResult(ctx, m.state, func(ctx context.Context, client db.ReadOnly) (map[imap.InternalMessageID]imap.ModSeq, error) {
+			return client.GetMessagesModSeq(ctx, m.id.InternalID, msgIDs)
+		}); err != nil {
+			return err
+		}
+	}
+
+	if wantSaveDate {
+		if saveDates, err = stateDBReadResult(ctx, m.state, func(ctx context.Context, client db.ReadOnly) (map[imap.InternalMessageID]time.Time, error) {
+			return client.GetMessagesSaveDate(ctx, m.id.InternalID, msgIDs)
+		}); err != nil {
+			return err
+		}
+	}
+
 	const minCountForParallelism = 4
 
 	var parallelism int
@@ -128,6 +250,13 @@ func (m *Mailbox) Fetch(ctx context.Context, cmd *command.Fetch, ch chan respons
 			return err
 		}
 
+		if cmd.ChangedSince != 0 && uint64(modSeqs[msg.ID.InternalID]) <= cmd.ChangedSince {
+			// remove message from the list to avoid being processed for seen flag changes later.
+			snapMessages[i].snapMsg = nil
+
+			return nil
+		}
+
 		var literal []byte
 
 		if needsLiteral {
@@ -150,7 +279,8 @@ func (m *Mailbox) Fetch(ctx context.Context, cmd *command.Fetch, ch chan respons
 			items = append(items, item)
 		}
 
-		if contexts.IsUID(ctx) && !wantUID {
+		// UIDFETCH responses already identify the message by UID (RFC9586).
+		if contexts.IsUID(ctx) && !wantUID && !uidOnly {
 			items = append(items, response.ItemUID(msg.UID))
 		}
 
@@ -170,13 +300,32 @@ func (m *Mailbox) Fetch(ctx context.Context, cmd *command.Fetch, ch chan respons
 			m.log.WithField("UID", msg.UID).WithField("messageID", msg.ID.String()).Debug("Fetch Body")
 		}
 
-		ch <- response.Fetch(msg.Seq).WithItems(items...)
+		if uidOnly {
+			ch <- response.UIDFetch(msg.UID).WithItems(items...)
+		} else {
+			ch <- response.Fetch(msg.Seq).WithItems(items...)
+		}
 
 		return nil
 	}); err != nil {
 		return err
 	}
 
+	if len(builtPreviews) != 0 {
+		if err := stateDBWrite(ctx, m.state, func(ctx context.Context, tx db.Transaction) ([]Update, error) {
+			for id, preview := range builtPreviews {
+				if err := tx.SetMessagePreview(ctx, id, preview); err != nil {
+					return nil, err
+				}
+			}
+
+			return nil, nil
+		}); err != nil {
+			// The previews were already returned, they will be built again next time.
+			m.log.WithError(err).Warn("Failed to store message previews")
+		}
+	}
+
 	msgsToBeMarkedSeen := xslices.Filter(snapMessages, func(s snapMsgWithSeq) bool {
 		return s.snapMsg != nil
 	})
@@ -232,6 +381,60 @@ func fetchBodyStructure(_ snapMsgWithSeq, message *db.Message, _ []byte) (respon
 	return response.ItemBodyStructure(message.BodyStructure), nil
 }
 
+func fetchEmailID(msg snapMsgWithSeq, _ *db.Message, _ []byte) (response.Item, error) {
+	return response.ItemEmailID(msg.ID.RemoteID), nil
+}
+
+func fetchThreadID(_ snapMsgWithSeq, message *db.Message, _ []byte) (response.Item, error) {
+	return response.ItemThreadID(message.ThreadID), nil
+}
+
+// fetchPreview returns the preview stored when the message was created. Messages created before previews were stored
+// have their preview built on demand, unless the client only wants readily available previews; built reports whether
+// that happened.
+func (m *Mailbox) fetchPreview(ctx context.Context, attribute *command.FetchAttributePreview, msg snapMsgWithSeq, message *db.Message) (preview *string, built bool, err error) {
+	if message.Preview != nil || attribute.Lazy {
+		return message.Preview, false, nil
+	}
+
+	literal, err := m.state.getLiteral(ctx, msg.ID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	text, err := imap.Preview(rfc822.Parse(literal))
+	if err != nil {
+		m.log.WithError(err).Warn("Failed to build message preview")
+
+		return nil, false, nil
+	}
+
+	return &text, true, nil
+}
+
+// getPartialMessages returns the range of the messages to fetch requested by the PARTIAL fetch modifier (RFC9394).
+// The range applies to the messages which changed since the CHANGEDSINCE mod-sequence, if it is given.
+func (m *Mailbox) getPartialMessages(ctx context.Context, cmd *command.Fetch, snapMessages []snapMsgWithSeq) ([]snapMsgWithSeq, error) {
+	if cmd.ChangedSince != 0 {
+		modSeqs, err := stateDBReadResult(ctx, m.state, func(ctx context.Context, client db.ReadOnly) (map[imap.InternalMessageID]imap.ModSeq, error) {
+			return client.GetMessagesModSeq(ctx, m.id.InternalID, xslices.Map(snapMessages, func(msg snapMsgWithSeq) imap.InternalMessageID {
+				return msg.ID.InternalID
+			}))
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		snapMessages = xslices.Filter(snapMessages, func(msg snapMsgWithSeq) bool {
+			return uint64(modSeqs[msg.ID.InternalID]) > cmd.ChangedSince
+		})
+	}
+
+	begin, end := cmd.Partial.Bounds(len(snapMessages))
+
+	return snapMessages[begin:end], nil
+}
+
 func fetchUID(msg snapMsgWithSeq, _ *db.Message, _ []byte) (response.Item, error) {
 	return response.ItemUID(msg.UID), nil
 }
@@ -251,6 +454,45 @@ func fetchAttributeBodySection(attribute *command.FetchAttributeBodySection, lit
 	return item, nil
 }
 
+func fetchAttributeBinarySection(attribute *command.FetchAttributeBinarySection, literal []byte) (response.Item, error) {
+	b, err := fetchBinaryLiteral(attribute.Section, literal)
+	if err != nil {
+		return nil, err
+	}
+
+	item := response.ItemBinaryLiteral(renderParts(attribute.Section), b)
+
+	if attribute.Partial != nil {
+		item.WithPartial(int(attribute.Partial.Offset), int(attribute.Partial.Count))
+	}
+
+	return item, nil
+}
+
+func fetchAttributeBinarySize(attribute *command.FetchAttributeBinarySize, literal []byte) (response.Item, error) {
+	b, err := fetchBinaryLiteral(attribute.Section, literal)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.ItemBinarySize(renderParts(attribute.Section), len(b)), nil
+}
+
+// fetchBinaryLiteral returns the content of the given part with its content transfer encoding removed. The whole
+// message is returned as is if no part is given.
+func fetchBinaryLiteral(part []int, literal []byte) ([]byte, error) {
+	if len(part) == 0 {
+		return literal, nil
+	}
+
+	section, err := rfc822.Parse(literal).Part(part...)
+	if err != nil {
+		return nil, err
+	}
+
+	return section.DecodedBody()
+}
+
 func fetchBodyLiteral(section command.BodySection, literal []byte) ([]byte, string, error) {
 	if section == nil {
 		return literal, "", nil
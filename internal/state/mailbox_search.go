@@ -23,7 +23,9 @@ import (
 
 var totalActiveSearchRequests int32
 
-func (m *Mailbox) Search(ctx context.Context, keys []command.SearchKey, decoder *encoding.Decoder) ([]uint32, error) {
+// Search returns the sequence numbers (or UIDs if this is a UID command) of the messages matching the given keys.
+// If the search used the MODSEQ key, the highest mod sequence of all matching messages is returned as well.
+func (m *Mailbox) Search(ctx context.Context, keys []command.SearchKey, decoder *encoding.Decoder) ([]uint32, imap.ModSeq, error) {
 	var mapFn func(snapMsgWithSeq) uint32
 
 	if contexts.IsUID(ctx) {
@@ -38,13 +40,57 @@ func (m *Mailbox) Search(ctx context.Context, keys []command.SearchKey, decoder
 
 	op, err := buildSearchOpListWithKeys(m, keys, decoder)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
+	}
+
+	if op.needsModSeq {
+		m.state.Enable(imap.CONDSTORE)
 	}
 
 	msgCount := m.snap.len()
 
 	result := make([]uint32, msgCount)
+	modSeqs := make([]imap.ModSeq, msgCount)
+
+	if err := m.searchMessages(ctx, op, func(i int, data *searchData) {
+		result[i] = mapFn(data.message)
+		modSeqs[i] = data.modSeq
+	}); err != nil {
+		return nil, 0, err
+	}
+
+	var highestModSeq imap.ModSeq
+
+	for _, modSeq := range modSeqs {
+		if modSeq > highestModSeq {
+			highestModSeq = modSeq
+		}
+	}
+
+	return xslices.Filter(result, func(v uint32) bool {
+		return v != 0
+	}), highestModSeq, nil
+}
+
+// SaveSearchResult saves the given search result so later commands can refer to it with "$" (RFC5182).
+// The values are message UIDs if this is a UID context and sequence numbers otherwise.
+func (m *Mailbox) SaveSearchResult(ctx context.Context, ids []uint32) {
+	uids := make([]imap.UID, 0, len(ids))
+
+	for _, id := range ids {
+		if contexts.IsUID(ctx) {
+			uids = append(uids, imap.UID(id))
+		} else if msg, ok := m.snap.messages.getWithSeqID(imap.SeqID(id)); ok {
+			uids = append(uids, msg.UID)
+		}
+	}
+
+	m.state.searchRes = uids
+}
 
+// searchMessages calls fn with the search data of every message which matches the given search operation.
+// The calls happen concurrently, but fn is called at most once for every message index.
+func (m *Mailbox) searchMessages(ctx context.Context, op *buildSearchOpResult, fn func(int, *searchData)) error {
 	activeSearchRequests := atomic.AddInt32(&totalActiveSearchRequests, 1)
 	defer atomic.AddInt32(&totalActiveSearchRequests, -1)
 
@@ -56,7 +102,12 @@ func (m *Mailbox) Search(ctx context.Context, keys []command.SearchKey, decoder
 		parallelism = runtime.NumCPU() / int(activeSearchRequests)
 	}
 
-	if err := parallel.DoContext(ctx, parallelism, msgCount, func(ctx context.Context, i int) error {
+	batch, err := m.loadSearchBatchData(ctx, op)
+	if err != nil {
+		return err
+	}
+
+	return parallel.DoContext(ctx, parallelism, m.snap.len(), func(ctx context.Context, i int) error {
 		defer async.HandlePanic(m.state.panicHandler)
 
 		msg, ok := m.snap.messages.getWithSeqID(imap.SeqID(uint32(i + 1)))
@@ -64,13 +115,63 @@ func (m *Mailbox) Search(ctx context.Context, keys []command.SearchKey, decoder
 			return nil
 		}
 
-		matches, err := applySearch(ctx, m, msg, op)
+		data, matches, err := applySearch(ctx, m, msg, op, batch)
 		if err != nil {
 			return err
 		}
 
 		if matches {
-			result[i] = mapFn(msg)
+			fn(i, data)
+		}
+
+		return nil
+	})
+}
+
+// searchBatchData holds the search data which is loaded for all the messages of the mailbox at once.
+type searchBatchData struct {
+	modSeqs   map[imap.InternalMessageID]imap.ModSeq
+	threadIDs map[imap.InternalMessageID]string
+	saveDates map[imap.InternalMessageID]time.Time
+}
+
+func (m *Mailbox) loadSearchBatchData(ctx context.Context, op *buildSearchOpResult) (*searchBatchData, error) {
+	var batch searchBatchData
+
+	if !op.needsModSeq && !op.needsThreadID && !op.needsSaveDate {
+		return &batch, nil
+	}
+
+	ids := xslices.Map(m.snap.messages.all(), func(msg *snapMsg) imap.InternalMessageID {
+		return msg.ID.InternalID
+	})
+
+	if err := stateDBRead(ctx, m.state, func(ctx context.Context, client db.ReadOnly) error {
+		if op.needsModSeq {
+			modSeqs, err := client.GetMessagesModSeq(ctx, m.id.InternalID, ids)
+			if err != nil {
+				return err
+			}
+
+			batch.modSeqs = modSeqs
+		}
+
+		if op.needsThreadID {
+			threadIDs, err := client.GetMessagesThreadID(ctx, ids)
+			if err != nil {
+				return err
+			}
+
+			batch.threadIDs = threadIDs
+		}
+
+		if op.needsSaveDate {
+			saveDates, err := client.GetMessagesSaveDate(ctx, m.id.InternalID, ids)
+			if err != nil {
+				return err
+			}
+
+			batch.saveDates = saveDates
 		}
 
 		return nil
@@ -78,12 +179,10 @@ func (m *Mailbox) Search(ctx context.Context, keys []command.SearchKey, decoder
 		return nil, err
 	}
 
-	return xslices.Filter(result, func(v uint32) bool {
-		return v != 0
-	}), nil
+	return &batch, nil
 }
 
-func buildSearchData(ctx context.Context, m *Mailbox, op *buildSearchOpResult, message snapMsgWithSeq) (searchData, error) {
+func buildSearchData(ctx context.Context, m *Mailbox, op *buildSearchOpResult, message snapMsgWithSeq, batch *searchBatchData) (searchData, error) {
 	data := searchData{message: message}
 
 	if op.needsMessage {
@@ -108,6 +207,18 @@ func buildSearchData(ctx context.Context, m *Mailbox, op *buildSearchOpResult, m
 		data.literal = l
 	}
 
+	if op.needsModSeq {
+		data.modSeq = batch.modSeqs[message.ID.InternalID]
+	}
+
+	if op.needsThreadID {
+		data.threadID = batch.threadIDs[message.ID.InternalID]
+	}
+
+	if op.needsSaveDate {
+		data.saveDate = batch.saveDates[message.ID.InternalID]
+	}
+
 	if op.needsHeader {
 		headerBytes, _ := rfc822.Split(data.literal)
 
@@ -122,18 +233,18 @@ func buildSearchData(ctx context.Context, m *Mailbox, op *buildSearchOpResult, m
 	return data, nil
 }
 
-func applySearch(ctx context.Context, m *Mailbox, msg snapMsgWithSeq, searchOp *buildSearchOpResult) (bool, error) {
-	data, err := buildSearchData(ctx, m, searchOp, msg)
+func applySearch(ctx context.Context, m *Mailbox, msg snapMsgWithSeq, searchOp *buildSearchOpResult, batch *searchBatchData) (*searchData, bool, error) {
+	data, err := buildSearchData(ctx, m, searchOp, msg, batch)
 	if err != nil {
-		return false, err
+		return nil, false, err
 	}
 
 	ok, err := searchOp.op(&data)
 	if err != nil {
-		return false, err
+		return nil, false, err
 	}
 
-	return ok, nil
+	return &data, ok, nil
 }
 
 type searchData struct {
@@ -143,22 +254,31 @@ type searchData struct {
 		date time.Time
 		size int
 	}
-	header *rfc822.Header
+	header   *rfc822.Header
+	modSeq   imap.ModSeq
+	threadID string
+	saveDate time.Time
 }
 
 type searchOp = func(*searchData) (bool, error)
 
 type buildSearchOpResult struct {
-	op           searchOp
-	needsLiteral bool
-	needsMessage bool
-	needsHeader  bool
+	op            searchOp
+	needsLiteral  bool
+	needsMessage  bool
+	needsHeader   bool
+	needsModSeq   bool
+	needsThreadID bool
+	needsSaveDate bool
 }
 
 func (b *buildSearchOpResult) merge(other *buildSearchOpResult) {
 	b.needsLiteral = b.needsLiteral || other.needsLiteral
 	b.needsMessage = b.needsMessage || other.needsMessage
 	b.needsHeader = b.needsHeader || other.needsHeader
+	b.needsModSeq = b.needsModSeq || other.needsModSeq
+	b.needsThreadID = b.needsThreadID || other.needsThreadID
+	b.needsSaveDate = b.needsSaveDate || other.needsSaveDate
 }
 
 type searchOpResultOption interface {
@@ -196,6 +316,36 @@ func needsDBMessage() searchOpResultOption {
 	return &withDBMessageSearchOpResultOption{}
 }
 
+type withModSeqSearchOpResultOption struct{}
+
+func (withModSeqSearchOpResultOption) apply(s *buildSearchOpResult) {
+	s.needsModSeq = true
+}
+
+func needsModSeq() searchOpResultOption {
+	return &withModSeqSearchOpResultOption{}
+}
+
+type withThreadIDSearchOpResultOption struct{}
+
+func (withThreadIDSearchOpResultOption) apply(s *buildSearchOpResult) {
+	s.needsThreadID = true
+}
+
+func needsThreadID() searchOpResultOption {
+	return &withThreadIDSearchOpResultOption{}
+}
+
+type withSaveDateSearchOpResultOption struct{}
+
+func (withSaveDateSearchOpResultOption) apply(s *buildSearchOpResult) {
+	s.needsSaveDate = true
+}
+
+func needsSaveDate() searchOpResultOption {
+	return &withSaveDateSearchOpResultOption{}
+}
+
 func newBuildSearchOpResult(op searchOp, needs ...searchOpResultOption) *buildSearchOpResult {
 	r := &buildSearchOpResult{op: op}
 
@@ -319,6 +469,33 @@ func buildSearchOp(m *Mailbox, key command.SearchKey, decoder *encoding.Decoder)
 	case *command.SearchKeyList:
 		return buildSearchOpList(m, key.Keys, decoder)
 
+	case *command.SearchKeyModSeq:
+		return buildSearchOpModSeq(key)
+
+	case *command.SearchKeyEmailID:
+		return buildSearchOpEmailID(key)
+
+	case *command.SearchKeyThreadID:
+		return buildSearchOpThreadID(key)
+
+	case *command.SearchKeySavedBefore:
+		return buildSearchOpSavedBefore(key)
+
+	case *command.SearchKeySavedOn:
+		return buildSearchOpSavedOn(key)
+
+	case *command.SearchKeySavedSince:
+		return buildSearchOpSavedSince(key)
+
+	case *command.SearchKeySaveDateSupported:
+		return buildSearchOpAll()
+
+	case *command.SearchKeyOlder:
+		return buildSearchOpOlder(key)
+
+	case *command.SearchKeyYounger:
+		return buildSearchOpYounger(key)
+
 	default:
 		return nil, fmt.Errorf("bad search keyword")
 	}
@@ -477,6 +654,74 @@ func buildSearchOpLarger(key *command.SearchKeyLarger) (*buildSearchOpResult, er
 	return newBuildSearchOpResult(op, needsDBMessage()), nil
 }
 
+func buildSearchOpModSeq(key *command.SearchKeyModSeq) (*buildSearchOpResult, error) {
+	op := func(s *searchData) (bool, error) {
+		return uint64(s.modSeq) >= key.Value, nil
+	}
+
+	return newBuildSearchOpResult(op, needsModSeq()), nil
+}
+
+func buildSearchOpEmailID(key *command.SearchKeyEmailID) (*buildSearchOpResult, error) {
+	op := func(s *searchData) (bool, error) {
+		return s.message.ID.RemoteID.ObjectID() == key.Value, nil
+	}
+
+	return newBuildSearchOpResult(op), nil
+}
+
+func buildSearchOpThreadID(key *command.SearchKeyThreadID) (*buildSearchOpResult, error) {
+	op := func(s *searchData) (bool, error) {
+		return s.threadID != "" && imap.ObjectID(s.threadID) == key.Value, nil
+	}
+
+	return newBuildSearchOpResult(op, needsThreadID()), nil
+}
+
+func buildSearchOpSavedBefore(key *command.SearchKeySavedBefore) (*buildSearchOpResult, error) {
+	op := func(s *searchData) (bool, error) {
+		return convertToDateWithoutTZ(s.saveDate).Before(key.Value), nil
+	}
+
+	return newBuildSearchOpResult(op, needsSaveDate()), nil
+}
+
+func buildSearchOpSavedOn(key *command.SearchKeySavedOn) (*buildSearchOpResult, error) {
+	op := func(s *searchData) (bool, error) {
+		return convertToDateWithoutTZ(s.saveDate).Equal(key.Value), nil
+	}
+
+	return newBuildSearchOpResult(op, needsSaveDate()), nil
+}
+
+func buildSearchOpSavedSince(key *command.SearchKeySavedSince) (*buildSearchOpResult, error) {
+	op := func(s *searchData) (bool, error) {
+		return !convertToDateWithoutTZ(s.saveDate).Before(key.Value), nil
+	}
+
+	return newBuildSearchOpResult(op, needsSaveDate()), nil
+}
+
+func buildSearchOpOlder(key *command.SearchKeyOlder) (*buildSearchOpResult, error) {
+	cutoff := time.Now().Add(-time.Duration(key.Value) * time.Second)
+
+	op := func(s *searchData) (bool, error) {
+		return !s.dbMessage.date.After(cutoff), nil
+	}
+
+	return newBuildSearchOpResult(op, needsDBMessage()), nil
+}
+
+func buildSearchOpYounger(key *command.SearchKeyYounger) (*buildSearchOpResult, error) {
+	cutoff := time.Now().Add(-time.Duration(key.Value) * time.Second)
+
+	op := func(s *searchData) (bool, error) {
+		return !s.dbMessage.date.Before(cutoff), nil
+	}
+
+	return newBuildSearchOpResult(op, needsDBMessage()), nil
+}
+
 func buildSearchOpNew() (*buildSearchOpResult, error) {
 	op := func(s *searchData) (bool, error) {
 		return s.message.flags.ContainsUnchecked(imap.FlagRecentLowerCase) && !s.message.flags.ContainsUnchecked(imap.FlagSeenLowerCase), nil
@@ -0,0 +1,193 @@
+package state
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/gluon/imap"
+	"github.com/ProtonMail/gluon/imap/command"
+	"github.com/ProtonMail/gluon/internal/contexts"
+	"github.com/ProtonMail/gluon/rfc5322"
+	"github.com/ProtonMail/gluon/rfc822"
+	"github.com/bradenaw/juniper/xslices"
+	"golang.org/x/text/encoding"
+)
+
+// Sort returns the sequence numbers (or UIDs if this is a UID command) of the messages matching the given keys,
+// ordered according to the given sort criteria as defined in RFC5256.
+// If the search used the MODSEQ key, the highest mod sequence of all matching messages is returned as well.
+func (m *Mailbox) Sort(
+	ctx context.Context,
+	criteria []command.SortCriterion,
+	keys []command.SearchKey,
+	decoder *encoding.Decoder,
+) ([]uint32, imap.ModSeq, error) {
+	op, err := buildSearchOpListWithKeys(m, keys, decoder)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if op.needsModSeq {
+		m.state.Enable(imap.CONDSTORE)
+	}
+
+	for _, criterion := range criteria {
+		op.merge(newBuildSearchOpResult(nil, getSortKeyNeeds(criterion.Key)...))
+	}
+
+	msgs := make([]*sortData, m.snap.len())
+
+	if err := m.searchMessages(ctx, op, func(i int, data *searchData) {
+		msgs[i] = newSortData(data)
+	}); err != nil {
+		return nil, 0, err
+	}
+
+	msgs = xslices.Filter(msgs, func(msg *sortData) bool {
+		return msg != nil
+	})
+
+	// Messages are in sequence order, which breaks ties as required by RFC5256.
+	sort.SliceStable(msgs, func(i, j int) bool {
+		for _, criterion := range criteria {
+			res := compareSortData(msgs[i], msgs[j], criterion.Key)
+
+			if criterion.Reverse {
+				res = -res
+			}
+
+			if res != 0 {
+				return res < 0
+			}
+		}
+
+		return false
+	})
+
+	var highestModSeq imap.ModSeq
+
+	result := xslices.Map(msgs, func(msg *sortData) uint32 {
+		if msg.modSeq > highestModSeq {
+			highestModSeq = msg.modSeq
+		}
+
+		if contexts.IsUID(ctx) {
+			return uint32(msg.message.UID)
+		}
+
+		return uint32(msg.message.Seq)
+	})
+
+	return result, highestModSeq, nil
+}
+
+// sortData holds the values of a message which it can be sorted by.
+type sortData struct {
+	message      snapMsgWithSeq
+	modSeq       imap.ModSeq
+	internalDate time.Time
+	sentDate     time.Time
+	size         int
+	cc           string
+	from         string
+	to           string
+	subject      string
+}
+
+func newSortData(data *searchData) *sortData {
+	msg := &sortData{
+		message:      data.message,
+		modSeq:       data.modSeq,
+		internalDate: data.dbMessage.date,
+		sentDate:     data.dbMessage.date,
+		size:         data.dbMessage.size,
+	}
+
+	if data.header != nil {
+		// Messages without a valid date are sorted by their internal date.
+		if date, err := rfc5322.ParseDateTime(data.header.Get("Date")); err == nil {
+			msg.sentDate = date
+		}
+
+		msg.cc = getSortAddress(data.header, "Cc")
+		msg.from = getSortAddress(data.header, "From")
+		msg.to = getSortAddress(data.header, "To")
+		msg.subject = toASCIIUpper(rfc5322.ParseBaseSubject(data.header.Get("Subject")))
+	}
+
+	return msg
+}
+
+func getSortKeyNeeds(key command.SortKey) []searchOpResultOption {
+	switch key {
+	case command.SortKeyArrival, command.SortKeySize:
+		return []searchOpResultOption{needsDBMessage()}
+
+	case command.SortKeyDate:
+		return []searchOpResultOption{needsDBMessage(), needsHeader()}
+
+	case command.SortKeyCC, command.SortKeyFrom, command.SortKeyTo, command.SortKeySubject:
+		return []searchOpResultOption{needsHeader()}
+
+	default:
+		return nil
+	}
+}
+
+func compareSortData(a, b *sortData, key command.SortKey) int {
+	switch key {
+	case command.SortKeyArrival:
+		return a.internalDate.Compare(b.internalDate)
+
+	case command.SortKeyCC:
+		return strings.Compare(a.cc, b.cc)
+
+	case command.SortKeyDate:
+		return a.sentDate.Compare(b.sentDate)
+
+	case command.SortKeyFrom:
+		return strings.Compare(a.from, b.from)
+
+	case command.SortKeySize:
+		return a.size - b.size
+
+	case command.SortKeySubject:
+		return strings.Compare(a.subject, b.subject)
+
+	case command.SortKeyTo:
+		return strings.Compare(a.to, b.to)
+
+	default:
+		return 0
+	}
+}
+
+// getSortAddress returns the mailbox (local-part) of the first address of the given header field, which is what
+// messages are sorted by according to RFC5256.
+func getSortAddress(header *rfc822.Header, key string) string {
+	addresses, err := rfc5322.ParseAddressList(header.Get(key))
+	if err != nil || len(addresses) == 0 {
+		return ""
+	}
+
+	mailbox := addresses[0].Address
+
+	if idx := strings.LastIndex(mailbox, "@"); idx >= 0 {
+		mailbox = mailbox[:idx]
+	}
+
+	return toASCIIUpper(mailbox)
+}
+
+// toASCIIUpper maps the string to upper case according to the i;ascii-casemap collation (RFC4790).
+func toASCIIUpper(s string) string {
+	return strings.Map(func(r rune) rune {
+		if 'a' <= r && r <= 'z' {
+			return r - 'a' + 'A'
+		}
+
+		return r
+	}, s)
+}
@@ -0,0 +1,91 @@
+package state
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ProtonMail/gluon/imap"
+	"github.com/ProtonMail/gluon/imap/command"
+	"github.com/ProtonMail/gluon/internal/contexts"
+	"github.com/ProtonMail/gluon/rfc5322"
+	"github.com/bradenaw/juniper/xslices"
+	"golang.org/x/text/encoding"
+)
+
+// Thread returns the threads formed by the messages matching the given keys according to the given threading
+// algorithm as defined in RFC5256. The threads refer to messages by sequence number (or UID if this is a UID command).
+func (m *Mailbox) Thread(
+	ctx context.Context,
+	algorithm command.ThreadAlgorithm,
+	keys []command.SearchKey,
+	decoder *encoding.Decoder,
+) ([]imap.Thread, error) {
+	op, err := buildSearchOpListWithKeys(m, keys, decoder)
+	if err != nil {
+		return nil, err
+	}
+
+	if op.needsModSeq {
+		m.state.Enable(imap.CONDSTORE)
+	}
+
+	// Messages without a valid date are threaded by their internal date.
+	op.merge(newBuildSearchOpResult(nil, needsDBMessage(), needsHeader()))
+
+	msgs := make([]*threadMessage, m.snap.len())
+
+	if err := m.searchMessages(ctx, op, func(i int, data *searchData) {
+		msgs[i] = newThreadMessage(ctx, data)
+	}); err != nil {
+		return nil, err
+	}
+
+	msgs = xslices.Filter(msgs, func(msg *threadMessage) bool {
+		return msg != nil
+	})
+
+	switch algorithm {
+	case command.ThreadAlgorithmOrderedSubject:
+		return threadByOrderedSubject(msgs), nil
+
+	case command.ThreadAlgorithmReferences:
+		return threadByReferences(msgs), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported thread algorithm: %v", algorithm)
+	}
+}
+
+func newThreadMessage(ctx context.Context, data *searchData) *threadMessage {
+	msg := &threadMessage{
+		sentDate: data.dbMessage.date,
+	}
+
+	if contexts.IsUID(ctx) {
+		msg.id = uint32(data.message.UID)
+	} else {
+		msg.id = uint32(data.message.Seq)
+	}
+
+	if date, err := rfc5322.ParseDateTime(data.header.Get("Date")); err == nil {
+		msg.sentDate = date
+	}
+
+	subject, isReply := rfc5322.ExtractBaseSubject(data.header.Get("Subject"))
+
+	msg.subject = toASCIIUpper(subject)
+	msg.isReply = isReply
+
+	if ids := rfc5322.ParseMessageIDList(data.header.Get("Message-ID")); len(ids) > 0 {
+		msg.messageID = ids[0]
+	}
+
+	// The first message ID of In-Reply-To is used if References is missing or invalid.
+	if refs := rfc5322.ParseMessageIDList(data.header.Get("References")); len(refs) > 0 {
+		msg.references = refs
+	} else if refs := rfc5322.ParseMessageIDList(data.header.Get("In-Reply-To")); len(refs) > 0 {
+		msg.references = refs[:1]
+	}
+
+	return msg
+}
@@ -15,6 +15,18 @@ type Match struct {
 	Name      string
 	Delimiter string
 	Atts      imap.FlagSet
+
+	// Subscribed indicates whether the mailbox is subscribed.
+	Subscribed bool
+
+	// NonExistent indicates whether the mailbox doesn't exist, e.g. a deleted superior or subscription.
+	NonExistent bool
+
+	// HasChildren indicates whether the mailbox has existing inferiors.
+	HasChildren bool
+
+	// HasSubscribedChildren indicates whether the mailbox has subscribed inferiors.
+	HasSubscribedChildren bool
 }
 
 type matchMailbox struct {
@@ -129,6 +141,21 @@ func prepareMatch(
 	}, true, nil
 }
 
+// listSuperiorSet returns the names of all the mailboxes superior to any of the given mailbox names.
+func listSuperiorSet(names map[string]struct{}, delimiter string) map[string]struct{} {
+	superiors := make(map[string]struct{})
+
+	for name := range names {
+		for _, superior := range listSuperiors(name, delimiter) {
+			if superior != "" {
+				superiors[superior] = struct{}{}
+			}
+		}
+	}
+
+	return superiors
+}
+
 // GOMSRV-100: validate this implementation.
 func match(ref, pattern, del, mailboxName string) (string, bool) {
 	if pattern == "" {
@@ -3,6 +3,8 @@ package state
 import (
 	"fmt"
 	"testing"
+
+	"github.com/stretchr/testify/require"
 )
 
 func TestMatch(t *testing.T) {
@@ -92,3 +94,19 @@ func TestMatch(t *testing.T) {
 		})
 	}
 }
+
+func TestListSuperiorSet(t *testing.T) {
+	names := map[string]struct{}{
+		"INBOX":     {},
+		"foo/bar":   {},
+		"foo/baz/q": {},
+		"/rooted":   {},
+	}
+
+	require.Equal(t, map[string]struct{}{
+		"foo":     {},
+		"foo/baz": {},
+	}, listSuperiorSet(names, "/"))
+
+	require.Empty(t, listSuperiorSet(names, ""))
+}
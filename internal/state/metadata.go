@@ -0,0 +1,123 @@
+package state
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ProtonMail/gluon/db"
+	"github.com/ProtonMail/gluon/imap"
+	"github.com/bradenaw/juniper/xslices"
+)
+
+const (
+	// MetadataMaxSize is the maximum size of a metadata entry value.
+	MetadataMaxSize = 64 * 1024
+
+	// MetadataMaxEntries is the maximum number of metadata entries of a mailbox or of the server.
+	MetadataMaxEntries = 256
+)
+
+// GetMetadata returns the metadata entries of the mailbox with the given name, or of the server if the name is empty.
+func (state *State) GetMetadata(ctx context.Context, name string) ([]imap.MetadataEntry, error) {
+	return stateDBReadResult(ctx, state, func(ctx context.Context, client db.ReadOnly) ([]imap.MetadataEntry, error) {
+		var mboxID imap.InternalMailboxID
+
+		if name != "" {
+			mbox, err := client.GetMailboxByName(ctx, name)
+			if err != nil {
+				if errors.Is(err, db.ErrNotFound) {
+					return nil, ErrNoSuchMailbox
+				}
+
+				return nil, err
+			}
+
+			mboxID = mbox.ID
+		}
+
+		return client.GetMetadata(ctx, mboxID)
+	})
+}
+
+// SetMetadata sets the metadata entries of the mailbox with the given name, or of the server if the name is empty.
+// Entries with a nil value are removed. The connector is notified of changes to shared entries before they are stored.
+func (state *State) SetMetadata(ctx context.Context, name string, entries []imap.MetadataEntry) error {
+	for _, entry := range entries {
+		if len(entry.Value) > MetadataMaxSize {
+			return ErrMetadataMaxSize
+		}
+	}
+
+	mbox, err := stateDBReadResult(ctx, state, func(ctx context.Context, client db.ReadOnly) (db.MailboxIDPair, error) {
+		var mbox db.MailboxIDPair
+
+		if name != "" {
+			dbMBox, err := client.GetMailboxByName(ctx, name)
+			if err != nil {
+				if errors.Is(err, db.ErrNotFound) {
+					return db.MailboxIDPair{}, ErrNoSuchMailbox
+				}
+
+				return db.MailboxIDPair{}, err
+			}
+
+			mbox = db.NewMailboxIDPair(dbMBox)
+		}
+
+		return mbox, checkMetadataEntryCount(ctx, client, mbox.InternalID, entries)
+	})
+	if err != nil {
+		return err
+	}
+
+	// The connector is notified outside of the transaction so that it doesn't hold the database while it syncs.
+	if shared := xslices.Filter(entries, func(entry imap.MetadataEntry) bool {
+		return imap.IsSharedMetadataEntry(entry.Name)
+	}); len(shared) > 0 {
+		if err := state.user.GetRemote().SetSharedMetadata(ctx, mbox.RemoteID, shared); err != nil {
+			return err
+		}
+	}
+
+	return stateDBWrite(ctx, state, func(ctx context.Context, tx db.Transaction) ([]Update, error) {
+		if err := checkMetadataEntryCount(ctx, tx, mbox.InternalID, entries); err != nil {
+			return nil, err
+		}
+
+		return nil, tx.SetMetadata(ctx, mbox.InternalID, entries)
+	})
+}
+
+// checkMetadataEntryCount returns ErrMetadataTooMany if setting the given entries would leave the mailbox, or the
+// server if the mailbox ID is zero, with more than MetadataMaxEntries entries.
+func checkMetadataEntryCount(
+	ctx context.Context,
+	client db.ReadOnly,
+	mboxID imap.InternalMailboxID,
+	entries []imap.MetadataEntry,
+) error {
+	existing, err := client.GetMetadata(ctx, mboxID)
+	if err != nil {
+		return err
+	}
+
+	names := make(map[string]struct{}, len(existing))
+
+	for _, entry := range existing {
+		names[entry.Name] = struct{}{}
+	}
+
+	for _, entry := range entries {
+		if entry.Value == nil {
+			delete(names, entry.Name)
+		} else {
+			names[entry.Name] = struct{}{}
+		}
+	}
+
+	if len(names) > MetadataMaxEntries {
+		return ErrMetadataTooMany
+	}
+
+	return nil
+}
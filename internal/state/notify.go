@@ -0,0 +1,340 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ProtonMail/gluon/db"
+	"github.com/ProtonMail/gluon/imap"
+	"github.com/ProtonMail/gluon/imap/command"
+	"github.com/ProtonMail/gluon/internal/response"
+	"golang.org/x/exp/slices"
+)
+
+// NotifyEvents lists the NOTIFY (RFC5465) events supported for mailboxes other than the selected one.
+var NotifyEvents = []command.NotifyEvent{
+	command.NotifyEventMessageNew,
+	command.NotifyEventMessageExpunge,
+	command.NotifyEventFlagChange,
+	command.NotifyEventMailboxName,
+	command.NotifyEventSubscriptionChange,
+}
+
+// NotifyOptions holds the events the client asked to be notified about with NOTIFY (RFC5465).
+type NotifyOptions struct {
+	// EventGroups holds the event groups of the NOTIFY command, with their mailbox names already decoded.
+	EventGroups []command.NotifyEventGroup
+
+	// EncodeName encodes mailbox names the way the client expects them in responses.
+	EncodeName func(string) (string, error)
+}
+
+// maxNotifyRes is the number of pending notifications after which the client is considered to be lagging too far
+// behind; notifications are then turned off as described in RFC5465.
+const maxNotifyRes = 1000
+
+// notifyEvent is a change to a mailbox which may be reported to the clients which asked for it with NOTIFY.
+type notifyEvent struct {
+	event command.NotifyEvent
+
+	// mboxID identifies the mailbox of message events.
+	mboxID imap.InternalMailboxID
+
+	// name and oldName identify the mailbox of mailbox events, as it might not exist anymore when the event is reported.
+	name, oldName string
+}
+
+// notifyUpdate is implemented by the updates which carry events that NOTIFY clients may ask for, even when the
+// affected mailboxes are not selected.
+type notifyUpdate interface {
+	notifyEvents(ctx context.Context, client db.ReadOnly) ([]notifyEvent, error)
+}
+
+type mailboxNotifyStateUpdate struct {
+	notifyEvent
+}
+
+// NewMailboxNameStateUpdate reports that the mailbox with the given name was created, deleted or renamed from oldName.
+func NewMailboxNameStateUpdate(name, oldName string) Update {
+	return &mailboxNotifyStateUpdate{notifyEvent: notifyEvent{
+		event:   command.NotifyEventMailboxName,
+		name:    name,
+		oldName: oldName,
+	}}
+}
+
+// NewSubscriptionChangeStateUpdate reports that the mailbox with the given name was subscribed or unsubscribed.
+func NewSubscriptionChangeStateUpdate(name string) Update {
+	return &mailboxNotifyStateUpdate{notifyEvent: notifyEvent{
+		event: command.NotifyEventSubscriptionChange,
+		name:  name,
+	}}
+}
+
+// Filter returns false as the update doesn't affect the selected mailbox; it is only reported to NOTIFY clients.
+func (u *mailboxNotifyStateUpdate) Filter(*State) bool {
+	return false
+}
+
+func (u *mailboxNotifyStateUpdate) Apply(context.Context, db.Transaction, *State) error {
+	return nil
+}
+
+func (u *mailboxNotifyStateUpdate) notifyEvents(context.Context, db.ReadOnly) ([]notifyEvent, error) {
+	return []notifyEvent{u.notifyEvent}, nil
+}
+
+func (u *mailboxNotifyStateUpdate) String() string {
+	return fmt.Sprintf("MailboxNotifyStateUpdate: event = %v name = %v oldName = %v", u.event, u.name, u.oldName)
+}
+
+// SetNotify replaces the events the client wants to be notified about. Nil disables notifications.
+func (state *State) SetNotify(opts *NotifyOptions) {
+	state.notify = opts
+	state.notifyRes = nil
+}
+
+// NotifyStatus returns the status of the mailboxes whose message events the client wants to be notified about.
+func (state *State) NotifyStatus(ctx context.Context) ([]response.Response, error) {
+	if state.notify == nil {
+		return nil, nil
+	}
+
+	return stateDBReadResult(ctx, state, func(ctx context.Context, client db.ReadOnly) ([]response.Response, error) {
+		mboxes, err := client.GetAllMailboxesWithAttr(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		recoveryMailboxID := state.user.GetRecoveryMailboxID().InternalID
+
+		var res []response.Response
+
+		for _, mbox := range mboxes {
+			if state.snap != nil && state.snap.mboxID.InternalID == mbox.ID {
+				continue
+			}
+
+			// The recovery mailbox is hidden while it is empty, as in LIST.
+			if mbox.ID == recoveryMailboxID {
+				if count, err := client.GetMailboxMessageCount(ctx, mbox.ID); err != nil {
+					return nil, err
+				} else if count == 0 {
+					continue
+				}
+			}
+
+			group, ok := state.getNotifyEventGroup(mbox.Name, mbox.Subscribed)
+			if !ok || !slices.Contains(group.Events, command.NotifyEventMessageNew) {
+				continue
+			}
+
+			status, err := state.getNotifyStatus(ctx, client, &mbox.Mailbox)
+			if err != nil {
+				return nil, err
+			}
+
+			res = append(res, status)
+		}
+
+		return res, nil
+	})
+}
+
+// PopNotifications returns the pending notifications. They are otherwise sent along with the updates of the selected
+// mailbox, or right away during IDLE.
+func (state *State) PopNotifications() []response.Response {
+	res := state.notifyRes
+
+	state.notifyRes = nil
+
+	return res
+}
+
+// applyNotifyUpdate reports the events carried by the update which the client asked for with NOTIFY.
+func (state *State) applyNotifyUpdate(ctx context.Context, client db.ReadOnly, update Update) error {
+	if state.notify == nil {
+		return nil
+	}
+
+	u, ok := update.(notifyUpdate)
+	if !ok {
+		return nil
+	}
+
+	events, err := u.notifyEvents(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	var reported []notifyEvent
+
+	for _, event := range events {
+		if slices.Contains(reported, event) {
+			continue
+		}
+
+		res, err := state.getNotifyResponse(ctx, client, event)
+		if err != nil {
+			return err
+		}
+
+		if res != nil {
+			state.pushNotification(res)
+		}
+
+		// Notifications were turned off because too many of them are pending.
+		if state.notify == nil {
+			return nil
+		}
+
+		reported = append(reported, event)
+	}
+
+	return nil
+}
+
+// getNotifyResponse returns the response reporting the given event, or nil if the client didn't ask for it.
+func (state *State) getNotifyResponse(ctx context.Context, client db.ReadOnly, event notifyEvent) (response.Response, error) {
+	switch event.event {
+	case command.NotifyEventMailboxName, command.NotifyEventSubscriptionChange:
+		mbox, err := client.GetMailboxByName(ctx, event.name)
+		if err != nil && !errors.Is(err, db.ErrNotFound) {
+			return nil, err
+		}
+
+		if group, ok := state.getNotifyEventGroup(event.name, mbox != nil && mbox.Subscribed); !ok || !slices.Contains(group.Events, event.event) {
+			return nil, nil
+		}
+
+		return state.getNotifyList(mbox, event)
+
+	default:
+		// Changes to the selected mailbox are already reported with the usual untagged responses.
+		if state.snap != nil && state.snap.mboxID.InternalID == event.mboxID {
+			return nil, nil
+		}
+
+		mbox, err := client.GetMailboxByID(ctx, event.mboxID)
+		if err != nil {
+			if errors.Is(err, db.ErrNotFound) {
+				return nil, nil
+			}
+
+			return nil, err
+		}
+
+		if group, ok := state.getNotifyEventGroup(mbox.Name, mbox.Subscribed); !ok || !slices.Contains(group.Events, event.event) {
+			return nil, nil
+		}
+
+		return state.getNotifyStatus(ctx, client, mbox)
+	}
+}
+
+// getNotifyEventGroup returns the first event group whose filter matches the mailbox.
+func (state *State) getNotifyEventGroup(name string, subscribed bool) (command.NotifyEventGroup, bool) {
+	for _, group := range state.notify.EventGroups {
+		if notifyFilterMatches(group, name, subscribed, state.delimiter) {
+			return group, true
+		}
+	}
+
+	return command.NotifyEventGroup{}, false
+}
+
+func (state *State) getNotifyStatus(ctx context.Context, client db.ReadOnly, mbox *db.Mailbox) (response.Response, error) {
+	count, uidNext, err := client.GetMailboxMessageCountAndUID(ctx, mbox.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	unseen, err := client.GetMailboxUnseenCount(ctx, mbox.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := state.notify.EncodeName(mbox.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Status().WithMailbox(name).WithItems(
+		response.ItemMessages(count),
+		response.ItemUIDNext(uidNext),
+		response.ItemUnseen(uint32(unseen)),
+	), nil
+}
+
+func (state *State) getNotifyList(mbox *db.Mailbox, event notifyEvent) (response.Response, error) {
+	name, err := state.notify.EncodeName(event.name)
+	if err != nil {
+		return nil, err
+	}
+
+	attributes := imap.NewFlagSet()
+
+	if mbox == nil {
+		attributes.AddToSelf(imap.AttrNonExistent)
+	} else if mbox.Subscribed {
+		attributes.AddToSelf(imap.AttrSubscribed)
+	}
+
+	res := response.List().WithName(name).WithDelimiter(state.delimiter).WithAttributes(attributes)
+
+	if event.oldName != "" {
+		oldName, err := state.notify.EncodeName(event.oldName)
+		if err != nil {
+			return nil, err
+		}
+
+		res = res.WithOldName(oldName)
+	}
+
+	return res, nil
+}
+
+// pushNotification sends the response right away during IDLE and queues it otherwise. If too many notifications are
+// pending, they are dropped and notifications are turned off, which the client is told with NOTIFICATIONOVERFLOW.
+func (state *State) pushNotification(res response.Response) {
+	switch {
+	case state.idleCh != nil:
+		state.idleCh <- res
+
+	case len(state.notifyRes) >= maxNotifyRes:
+		state.notify = nil
+		state.notifyRes = []response.Response{
+			response.Ok().WithItems(response.ItemNotificationOverflow()).WithMessage("Too many notifications, NOTIFY is off"),
+		}
+
+	default:
+		state.notifyRes = append(state.notifyRes, res)
+	}
+}
+
+func notifyFilterMatches(group command.NotifyEventGroup, name string, subscribed bool, delimiter string) bool {
+	switch group.Filter {
+	case command.NotifyFilterInboxes:
+		return name == imap.Inbox
+
+	case command.NotifyFilterPersonal:
+		return true
+
+	case command.NotifyFilterSubscribed:
+		return subscribed
+
+	case command.NotifyFilterSubtree:
+		return slices.ContainsFunc(group.Mailboxes, func(mailbox string) bool {
+			return name == mailbox || (delimiter != "" && strings.HasPrefix(name, mailbox+delimiter))
+		})
+
+	case command.NotifyFilterMailboxes:
+		return slices.Contains(group.Mailboxes, name)
+
+	default:
+		// The selected mailbox is reported with the usual untagged responses.
+		return false
+	}
+}
@@ -0,0 +1,27 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/ProtonMail/gluon/internal/response"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPushNotificationOverflow(t *testing.T) {
+	state := &State{notify: &NotifyOptions{}}
+
+	for i := 0; i < maxNotifyRes; i++ {
+		state.pushNotification(response.Status())
+	}
+
+	require.NotNil(t, state.notify)
+	require.Len(t, state.notifyRes, maxNotifyRes)
+
+	// The pending notifications are replaced by the overflow response and NOTIFY is turned off.
+	state.pushNotification(response.Status())
+
+	require.Nil(t, state.notify)
+	require.Equal(t, []response.Response{
+		response.Ok().WithItems(response.ItemNotificationOverflow()).WithMessage("Too many notifications, NOTIFY is off"),
+	}, state.PopNotifications())
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/ProtonMail/gluon/db"
 	"github.com/ProtonMail/gluon/imap"
+	"github.com/ProtonMail/gluon/imap/command"
 	"github.com/ProtonMail/gluon/internal/contexts"
 	"github.com/ProtonMail/gluon/internal/response"
 	"github.com/ProtonMail/gluon/reporter"
@@ -22,6 +23,22 @@ func (r *responderStateUpdate) Apply(ctx context.Context, tx db.Transaction, s *
 	return s.PushResponder(ctx, tx, r.responders...)
 }
 
+// notifyEvents reports the expunged messages of a mailbox as MessageExpunge events.
+func (r *responderStateUpdate) notifyEvents(context.Context, db.ReadOnly) ([]notifyEvent, error) {
+	filter, ok := r.SnapFilter.(*MessageAndMBoxIDStateFilter)
+	if !ok {
+		return nil, nil
+	}
+
+	for _, responder := range r.responders {
+		if _, ok := responder.(*expunge); ok {
+			return []notifyEvent{{event: command.NotifyEventMessageExpunge, mboxID: filter.MBoxID}}, nil
+		}
+	}
+
+	return nil, nil
+}
+
 func (r *responderStateUpdate) String() string {
 	return fmt.Sprintf("ResponderStateUpdate: %v Responders=%v",
 		r.SnapFilter.String(),
@@ -224,6 +241,10 @@ func (e *ExistsStateUpdate) Apply(ctx context.Context, tx db.Transaction, s *Sta
 	})...)
 }
 
+func (e *ExistsStateUpdate) notifyEvents(context.Context, db.ReadOnly) ([]notifyEvent, error) {
+	return []notifyEvent{{event: command.NotifyEventMessageNew, mboxID: e.MboxID}}, nil
+}
+
 func (e *ExistsStateUpdate) String() string {
 	var originState string
 	if e.originStateSet {
@@ -257,11 +278,23 @@ func (u *expunge) handle(ctx context.Context, snap *snapshot, _ StateID) ([]resp
 		return nil, nil, nil
 	}
 
-	seq, err := snap.getMessageSeq(u.messageID)
+	uid, err := snap.getMessageUID(u.messageID)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	// Once QRESYNC or UIDONLY is enabled, expunged messages are reported by UID (RFC7162, RFC9586), so there is no
+	// need to resolve their sequence number.
+	var seq imap.SeqID
+
+	byUID := snap.state.IsEnabled(imap.QRESYNC) || snap.state.IsEnabled(imap.UIDONLY)
+
+	if !byUID {
+		if seq, err = snap.getMessageSeq(u.messageID); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	if err := snap.expungeMessage(u.messageID); err != nil {
 		return nil, nil, err
 	}
@@ -271,6 +304,10 @@ func (u *expunge) handle(ctx context.Context, snap *snapshot, _ StateID) ([]resp
 		return nil, nil, nil
 	}
 
+	if byUID {
+		return []response.Response{response.Vanished(uid)}, nil, nil
+	}
+
 	return []response.Response{response.Expunge(seq)}, nil, nil
 }
 
@@ -298,6 +335,9 @@ type fetch struct {
 	asUID                    bool
 	asSilent                 bool
 	cameFromDifferentMailbox bool
+
+	// modSeq is the message's mod sequence after the change. It is only reported when non-zero.
+	modSeq imap.ModSeq
 }
 
 func NewFetch(messageID imap.InternalMessageID, flags imap.FlagSet, asUID, asSilent, cameFromDifferentMailbox bool, fetchFlagOp int) *fetch {
@@ -311,6 +351,12 @@ func NewFetch(messageID imap.InternalMessageID, flags imap.FlagSet, asUID, asSil
 	}
 }
 
+func (u *fetch) withModSeq(modSeq imap.ModSeq) *fetch {
+	u.modSeq = modSeq
+
+	return u
+}
+
 func (u *fetch) handle(_ context.Context, snap *snapshot, _ StateID) ([]response.Response, responderDBUpdate, error) {
 	if !snap.hasMessage(u.messageID) {
 		return nil, nil, nil
@@ -360,16 +406,27 @@ func (u *fetch) handle(_ context.Context, snap *snapshot, _ StateID) ([]response
 
 	items := []response.Item{response.ItemFlags(newFlags)}
 
-	// When handling any UID command, we should always include the message's UID.
-	if u.asUID {
-		uid, err := snap.getMessageUID(u.messageID)
-		if err != nil {
-			return nil, nil, err
-		}
+	uid, err := snap.getMessageUID(u.messageID)
+	if err != nil {
+		return nil, nil, err
+	}
 
+	// Once UIDONLY is enabled, messages are identified by UID rather than by sequence number (RFC9586).
+	uidOnly := snap.state.IsEnabled(imap.UIDONLY)
+
+	// When handling any UID command, we should always include the message's UID.
+	if u.asUID && !uidOnly {
 		items = append(items, response.ItemUID(uid))
 	}
 
+	if u.modSeq != 0 {
+		items = append(items, response.ItemModSeq(u.modSeq))
+	}
+
+	if uidOnly {
+		return []response.Response{response.UIDFetch(uid).WithItems(items...)}, nil, nil
+	}
+
 	seq, err := snap.getMessageSeq(u.messageID)
 	if err != nil {
 		return nil, nil, err
@@ -69,7 +69,7 @@ func (snap *snapshot) getMessageSeq(messageID imap.InternalMessageID) (imap.SeqI
 }
 
 func (snap *snapshot) getMessageUID(messageID imap.InternalMessageID) (imap.UID, error) {
-	msg, ok := snap.messages.get(messageID)
+	msg, ok := snap.messages.getNoSeq(messageID)
 	if !ok {
 		return 0, ErrNoSuchMessage
 	}
@@ -78,7 +78,7 @@ func (snap *snapshot) getMessageUID(messageID imap.InternalMessageID) (imap.UID,
 }
 
 func (snap *snapshot) getMessageFlags(messageID imap.InternalMessageID) (imap.FlagSet, error) {
-	msg, ok := snap.messages.get(messageID)
+	msg, ok := snap.messages.getNoSeq(messageID)
 	if !ok {
 		return nil, ErrNoSuchMessage
 	}
@@ -87,7 +87,7 @@ func (snap *snapshot) getMessageFlags(messageID imap.InternalMessageID) (imap.Fl
 }
 
 func (snap *snapshot) setMessageFlags(messageID imap.InternalMessageID, flags imap.FlagSet) error {
-	msg, ok := snap.messages.get(messageID)
+	msg, ok := snap.messages.getNoSeq(messageID)
 	if !ok {
 		return ErrNoSuchMessage
 	}
@@ -136,6 +136,8 @@ func (snap *snapshot) getAllMessagesIDsMarkedDelete() []db.MessageIDPair {
 }
 
 func (snap *snapshot) getMessagesInRange(ctx context.Context, seq []command.SeqRange) ([]snapMsgWithSeq, error) {
+	seq = snap.resolveSearchRes(seq, contexts.IsUID(ctx))
+
 	switch {
 	case contexts.IsUID(ctx):
 		return snap.getMessagesInUIDRange(seq)
@@ -164,11 +166,37 @@ func (u UIDInterval) contains(uid imap.UID) bool {
 }
 
 func (snap *snapshot) resolveSeqInterval(seq []command.SeqRange) ([]SeqInterval, error) {
-	return snap.messages.resolveSeqInterval(seq)
+	return snap.messages.resolveSeqInterval(snap.resolveSearchRes(seq, false))
 }
 
 func (snap *snapshot) resolveUIDInterval(seq []command.SeqRange) ([]UIDInterval, error) {
-	return snap.messages.resolveUIDInterval(seq)
+	return snap.messages.resolveUIDInterval(snap.resolveSearchRes(seq, true))
+}
+
+// resolveSearchRes replaces the "$" sequence set with the saved search result (RFC5182): the saved UIDs if asUID is
+// true, or the sequence numbers of the saved messages which still exist otherwise. Other sets are returned unchanged.
+func (snap *snapshot) resolveSearchRes(seq []command.SeqRange, asUID bool) []command.SeqRange {
+	if !command.IsSearchResSeqSet(seq) {
+		return seq
+	}
+
+	res := make([]command.SeqRange, 0, len(snap.state.searchRes))
+
+	for _, uid := range snap.state.searchRes {
+		var num command.SeqNum
+
+		if asUID {
+			num = command.SeqNum(uid)
+		} else if msg, ok := snap.messages.getWithUID(uid); ok {
+			num = command.SeqNum(msg.Seq)
+		} else {
+			continue
+		}
+
+		res = append(res, command.SeqRange{Begin: num, End: num})
+	}
+
+	return res
 }
 
 func (snap *snapshot) getMessagesInSeqRange(seq []command.SeqRange) ([]snapMsgWithSeq, error) {
@@ -121,6 +121,31 @@ func (list *snapMsgList) all() []*snapMsg {
 	return list.msg
 }
 
+// missingUIDs returns the sorted intervals of the UIDs below uidNext which are not in the list.
+func (list *snapMsgList) missingUIDs(uidNext imap.UID) []UIDInterval {
+	var result []UIDInterval
+
+	next := imap.UID(1)
+
+	for _, msg := range list.msg {
+		if next >= uidNext {
+			break
+		}
+
+		if next < msg.UID {
+			result = append(result, UIDInterval{begin: next, end: min(msg.UID, uidNext) - 1})
+		}
+
+		next = msg.UID + 1
+	}
+
+	if next < uidNext {
+		result = append(result, UIDInterval{begin: next, end: uidNext - 1})
+	}
+
+	return result
+}
+
 func (list *snapMsgList) len() int {
 	return len(list.msg)
 }
@@ -165,6 +190,13 @@ func (list *snapMsgList) has(msgID imap.InternalMessageID) bool {
 	return ok
 }
 
+// getNoSeq returns the message without resolving its sequence number, which requires a search of the list.
+func (list *snapMsgList) getNoSeq(msgID imap.InternalMessageID) (*snapMsg, bool) {
+	snapshotMsg, ok := list.idx[msgID]
+
+	return snapshotMsg, ok
+}
+
 func (list *snapMsgList) get(msgID imap.InternalMessageID) (snapMsgWithSeq, bool) {
 	snapshotMsg, ok := list.idx[msgID]
 	if !ok {
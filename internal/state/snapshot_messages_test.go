@@ -68,6 +68,15 @@ func TestMessages(t *testing.T) {
 		require.Equal(t, imap.UID(50), msg5.UID)
 	}
 
+	{
+		msg3, ok := msg.getNoSeq(id3)
+		require.True(t, ok)
+		require.Equal(t, imap.UID(30), msg3.UID)
+
+		_, ok = msg.getNoSeq(id4)
+		require.False(t, ok)
+	}
+
 	{
 		require.Equal(t, must(msg.get(id1)), must(msg.seq(1)))
 		require.Equal(t, must(msg.get(id3)), must(msg.seq(2)))
@@ -232,3 +241,16 @@ func must[T any](val T, ok bool) T {
 
 	return val
 }
+
+func TestSnapListMissingUIDs(t *testing.T) {
+	msg := newMsgList(4)
+
+	require.NoError(t, msg.insert(messageIDPair(imap.NewInternalMessageID(), "2"), 2, imap.NewFlagSet()))
+	require.NoError(t, msg.insert(messageIDPair(imap.NewInternalMessageID(), "5"), 5, imap.NewFlagSet()))
+	require.NoError(t, msg.insert(messageIDPair(imap.NewInternalMessageID(), "6"), 6, imap.NewFlagSet()))
+
+	require.Equal(t, []UIDInterval{{begin: 1, end: 1}, {begin: 3, end: 4}, {begin: 7, end: 8}}, msg.missingUIDs(9))
+	require.Equal(t, []UIDInterval{{begin: 1, end: 1}, {begin: 3, end: 3}}, msg.missingUIDs(4))
+	require.Equal(t, []UIDInterval{{begin: 1, end: 1}, {begin: 3, end: 4}, {begin: 7, end: 999999}}, msg.missingUIDs(1000000))
+	require.Empty(t, newMsgList(0).missingUIDs(1))
+}
@@ -9,6 +9,7 @@ import (
 	"sync/atomic"
 
 	"github.com/ProtonMail/gluon/async"
+	"github.com/ProtonMail/gluon/connector"
 	"github.com/ProtonMail/gluon/db"
 	"github.com/ProtonMail/gluon/imap"
 	"github.com/ProtonMail/gluon/internal/ids"
@@ -50,6 +51,20 @@ type State struct {
 
 	imapLimits limits.IMAP
 
+	// enabled records the extensions enabled by the client, either explicitly with ENABLE (RFC5161) or implicitly
+	// by using one of their commands. Once enabled, an extension stays enabled for the remainder of the session.
+	enabled map[imap.Capability]struct{}
+
+	// searchRes holds the UIDs saved by the last SEARCH command with the SAVE result option (RFC5182).
+	// It is cleared whenever the selected mailbox is closed.
+	searchRes []imap.UID
+
+	// notify holds the events the client asked to be notified about with NOTIFY (RFC5465), if any.
+	notify *NotifyOptions
+
+	// notifyRes holds the notifications which were not sent yet.
+	notifyRes []response.Response
+
 	panicHandler async.PanicHandler
 
 	log *logrus.Entry
@@ -72,6 +87,7 @@ func NewState(user UserInterface, delimiter string, imapLimits limits.IMAP, pani
 		delimiter:    delimiter,
 		updatesQueue: async.NewQueuedChannel[Update](32, 128, panicHandler, fmt.Sprintf("gluon-state-%v", stateID)),
 		imapLimits:   imapLimits,
+		enabled:      make(map[imap.Capability]struct{}),
 		panicHandler: panicHandler,
 		log:          logrus.WithField("pkg", "gluon/state").WithField("state", stateID),
 	}
@@ -81,7 +97,25 @@ func (state *State) UserID() string {
 	return state.user.GetUserID()
 }
 
-func (state *State) List(ctx context.Context, ref, pattern string, lsub bool, fn func(map[string]Match) error) error {
+// ListOptions holds the LIST-EXTENDED (RFC5258) selection options which change the set of listed mailboxes.
+type ListOptions struct {
+	// Subscribed restricts the matches to subscribed mailboxes, including those which no longer exist.
+	Subscribed bool
+
+	// RecursiveMatch also matches mailboxes which are not subscribed but have subscribed inferiors.
+	RecursiveMatch bool
+}
+
+// List matches the mailboxes against the given patterns. When lsub is set, the legacy LSUB semantics apply and the
+// options are ignored.
+func (state *State) List(
+	ctx context.Context,
+	ref string,
+	patterns []string,
+	lsub bool,
+	opts ListOptions,
+	fn func(map[string]Match) error,
+) error {
 	return stateDBRead(ctx, state, func(ctx context.Context, client db.ReadOnly) error {
 		mailboxes, err := client.GetAllMailboxesWithAttr(ctx)
 		if err != nil {
@@ -118,20 +152,25 @@ func (state *State) List(ctx context.Context, ref, pattern string, lsub bool, fn
 			}
 		})
 
-		var deletedSubscriptions map[imap.MailboxID]*db.DeletedSubscription
-
-		if lsub {
-			deletedSubscriptions, err = client.GetDeletedSubscriptionSet(ctx)
-			if err != nil {
-				return err
-			}
+		deletedSubscriptions, err := client.GetDeletedSubscriptionSet(ctx)
+		if err != nil {
+			return err
 		}
 
 		// Convert existing mailboxes over to match format.
 		matchMailboxes := make([]matchMailbox, 0, len(mailboxes))
+		existing := make(map[string]struct{}, len(mailboxes))
+		subscribed := make(map[string]struct{})
+
 		for _, mbox := range mailboxes {
 			delete(deletedSubscriptions, mbox.RemoteID)
 
+			existing[mbox.Name] = struct{}{}
+
+			if mbox.Subscribed {
+				subscribed[mbox.Name] = struct{}{}
+			}
+
 			// Only include subscribed mailboxes when LSUB is used.
 			if lsub && !mbox.Subscribed {
 				continue
@@ -144,24 +183,52 @@ func (state *State) List(ctx context.Context, ref, pattern string, lsub bool, fn
 			})
 		}
 
-		if lsub {
-			// Insert any remaining mailboxes that have been deleted but are still subscribed.
-			for _, s := range deletedSubscriptions {
-				if state.user.GetRemote().GetMailboxVisibility(ctx, s.RemoteID) != imap.Visible {
-					continue
-				}
+		// Insert any remaining mailboxes that have been deleted but are still subscribed.
+		for _, s := range deletedSubscriptions {
+			if state.user.GetRemote().GetMailboxVisibility(ctx, s.RemoteID) != imap.Visible {
+				continue
+			}
+
+			subscribed[s.Name] = struct{}{}
 
+			if lsub || opts.Subscribed {
 				matchMailboxes = append(matchMailboxes, matchMailbox{
 					Name:       s.Name,
-					Subscribed: true,
+					Subscribed: lsub,
 					EntMBox:    nil,
 				})
 			}
 		}
 
-		matches, err := getMatches(ctx, client, matchMailboxes, ref, pattern, state.delimiter, lsub)
-		if err != nil {
-			return err
+		matches := make(map[string]Match)
+
+		withChildren := listSuperiorSet(existing, state.delimiter)
+		withSubscribedChildren := listSuperiorSet(subscribed, state.delimiter)
+
+		for _, pattern := range patterns {
+			patternMatches, err := getMatches(ctx, client, matchMailboxes, ref, pattern, state.delimiter, lsub)
+			if err != nil {
+				return err
+			}
+
+			for name, match := range patternMatches {
+				matches[name] = match
+			}
+		}
+
+		for name, match := range matches {
+			_, match.Subscribed = subscribed[name]
+			_, isExisting := existing[name]
+
+			match.NonExistent = !isExisting
+			_, match.HasChildren = withChildren[name]
+			_, match.HasSubscribedChildren = withSubscribedChildren[name]
+
+			if opts.Subscribed && !match.Subscribed && !(opts.RecursiveMatch && match.HasSubscribedChildren) {
+				delete(matches, name)
+			} else {
+				matches[name] = match
+			}
 		}
 
 		return fn(matches)
@@ -236,35 +303,43 @@ func (state *State) Examine(ctx context.Context, name string, fn func(*Mailbox)
 	return fn(newMailbox(mbox, state, state.snap))
 }
 
-func (state *State) Create(ctx context.Context, name string) error {
+// Create creates the mailbox with the given name, along with any missing superiors, and returns its remote ID. The
+// special-use attributes, if any, only apply to the mailbox itself.
+func (state *State) Create(ctx context.Context, name string, specialUse imap.FlagSet) (imap.MailboxID, error) {
 	uidValidity, err := state.user.GenerateUIDValidity()
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	if err := state.imapLimits.CheckUIDValidity(uidValidity); err != nil {
-		return err
+		return "", err
 	}
 
 	if strings.HasPrefix(strings.ToLower(name), ids.GluonRecoveryMailboxNameLowerCase) {
-		return ErrOperationNotAllowed
+		return "", ErrOperationNotAllowed
 	}
 
 	if state.delimiter != "" {
 		if strings.HasPrefix(name, state.delimiter) {
-			return ErrMailboxNameBeginsWithSeparator
+			return "", ErrMailboxNameBeginsWithSeparator
 		}
 
 		if strings.Contains(name, state.delimiter+state.delimiter) {
-			return ErrMailboxNameAdjacentSeparator
+			return "", ErrMailboxNameAdjacentSeparator
 		}
 	}
 
-	return stateDBWrite(ctx, state, func(ctx context.Context, tx db.Transaction) ([]Update, error) {
+	for _, attr := range specialUse.ToSlice() {
+		if !imap.IsSpecialUseAttribute(attr) {
+			return "", fmt.Errorf("%w: %v", connector.ErrUnsupportedSpecialUse, attr)
+		}
+	}
+
+	return stateDBWriteResult(ctx, state, func(ctx context.Context, tx db.Transaction) ([]Update, imap.MailboxID, error) {
 		if mailboxCount, err := tx.GetMailboxCount(ctx); err != nil {
-			return nil, err
+			return nil, "", err
 		} else if err := state.imapLimits.CheckMailBoxCount(mailboxCount); err != nil {
-			return nil, err
+			return nil, "", err
 		}
 
 		var mboxesToCreate []string
@@ -275,14 +350,14 @@ func (state *State) Create(ctx context.Context, name string) error {
 		}
 
 		if exists, err := tx.MailboxExistsWithName(ctx, name); err != nil {
-			return nil, err
+			return nil, "", err
 		} else if exists {
-			return nil, ErrExistingMailbox
+			return nil, "", ErrExistingMailbox
 		}
 
 		for _, superior := range listSuperiors(name, state.delimiter) {
 			if exists, err := tx.MailboxExistsWithName(ctx, superior); err != nil {
-				return nil, err
+				return nil, "", err
 			} else if exists {
 				continue
 			}
@@ -290,20 +365,24 @@ func (state *State) Create(ctx context.Context, name string) error {
 			mboxesToCreate = append(mboxesToCreate, superior)
 		}
 
-		mboxesToCreate = append(mboxesToCreate, name)
-
 		var allUpdates []Update
 
 		for _, mboxName := range mboxesToCreate {
-			updates, err := state.actionCreateMailbox(ctx, tx, mboxName, uidValidity)
+			updates, _, err := state.actionCreateMailbox(ctx, tx, mboxName, imap.NewFlagSet(), uidValidity)
 			if err != nil {
-				return nil, err
+				return nil, "", err
 			}
 
 			allUpdates = append(allUpdates, updates...)
+			allUpdates = append(allUpdates, NewMailboxNameStateUpdate(mboxName, ""))
 		}
 
-		return allUpdates, nil
+		updates, mboxID, err := state.actionCreateMailbox(ctx, tx, name, specialUse, uidValidity)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return append(allUpdates, append(updates, NewMailboxNameStateUpdate(name, ""))...), mboxID, nil
 	})
 }
 
@@ -328,7 +407,7 @@ func (state *State) Delete(ctx context.Context, name string) (bool, error) {
 			return nil, 0, err
 		}
 
-		return update, mbox.ID, nil
+		return append(update, NewMailboxNameStateUpdate(mbox.Name, "")), mbox.ID, nil
 	})
 	if err != nil {
 		return false, err
@@ -385,20 +464,27 @@ func (state *State) Rename(ctx context.Context, oldName, newName string) error {
 				return nil, err
 			}
 
-			updates, res, err := state.user.GetRemote().CreateMailbox(ctx, tx, strings.Split(m, state.delimiter))
+			updates, res, err := state.user.GetRemote().CreateMailbox(ctx, tx, strings.Split(m, state.delimiter), imap.NewFlagSet())
 			if err != nil {
 				return nil, err
 			}
 
 			allUpdates = append(allUpdates, updates...)
+			allUpdates = append(allUpdates, NewMailboxNameStateUpdate(m, ""))
 
 			if err := tx.CreateMailboxIfNotExists(ctx, res, state.delimiter, uidValidity); err != nil {
 				return nil, err
 			}
 		}
 
+		// Renaming INBOX moves its messages to a new mailbox and leaves INBOX in place.
 		if oldName == imap.Inbox {
-			return state.renameInbox(ctx, tx, mbox, newName)
+			updates, err := state.renameInbox(ctx, tx, mbox, newName)
+			if err != nil {
+				return nil, err
+			}
+
+			return append(allUpdates, append(updates, NewMailboxNameStateUpdate(newName, ""))...), nil
 		}
 
 		updates, err := state.actionUpdateMailbox(ctx, tx, mbox.RemoteID, newName)
@@ -407,6 +493,7 @@ func (state *State) Rename(ctx context.Context, oldName, newName string) error {
 		}
 
 		allUpdates = append(allUpdates, updates...)
+		allUpdates = append(allUpdates, NewMailboxNameStateUpdate(newName, oldName))
 
 		// Locally update all inferiors so we don't wait for update
 		mailboxes, err := tx.GetAllMailboxesWithAttr(ctx)
@@ -433,6 +520,8 @@ func (state *State) Rename(ctx context.Context, oldName, newName string) error {
 			if err := tx.RenameMailboxWithRemoteID(ctx, mbox.RemoteID, newInferior); err != nil {
 				return nil, err
 			}
+
+			allUpdates = append(allUpdates, NewMailboxNameStateUpdate(newInferior, inferior))
 		}
 
 		return allUpdates, nil
@@ -454,7 +543,7 @@ func (state *State) Subscribe(ctx context.Context, name string) error {
 			return nil, ErrAlreadySubscribed
 		}
 
-		return nil, tx.SetMailboxSubscribed(ctx, mbox.ID, true)
+		return []Update{NewSubscriptionChangeStateUpdate(name)}, tx.SetMailboxSubscribed(ctx, mbox.ID, true)
 	})
 }
 
@@ -476,7 +565,7 @@ func (state *State) Unsubscribe(ctx context.Context, name string) error {
 			return nil, ErrAlreadyUnsubscribed
 		}
 
-		return nil, tx.SetMailboxSubscribed(ctx, mbox.ID, false)
+		return []Update{NewSubscriptionChangeStateUpdate(name)}, tx.SetMailboxSubscribed(ctx, mbox.ID, false)
 	})
 }
 
@@ -564,6 +653,95 @@ func (state *State) Selected(ctx context.Context, fn func(*Mailbox) error) error
 	return fn(newMailbox(mbox, state, state.snap))
 }
 
+// Enable marks the given extensions as enabled for this state and returns the ones which were not enabled before.
+// Enabling QRESYNC implicitly enables CONDSTORE as well (RFC7162).
+func (state *State) Enable(caps ...imap.Capability) []imap.Capability {
+	var enabled []imap.Capability
+
+	for _, c := range caps {
+		if state.IsEnabled(c) {
+			continue
+		}
+
+		state.enabled[c] = struct{}{}
+
+		if c == imap.QRESYNC {
+			state.enabled[imap.CONDSTORE] = struct{}{}
+		}
+
+		enabled = append(enabled, c)
+	}
+
+	return enabled
+}
+
+// IsEnabled returns whether the given extension was enabled for this state.
+func (state *State) IsEnabled(c imap.Capability) bool {
+	_, ok := state.enabled[c]
+
+	return ok
+}
+
+// GetNamespaces returns the mailbox namespaces declared by the connector.
+func (state *State) GetNamespaces(ctx context.Context) imap.Namespaces {
+	return state.user.GetRemote().GetNamespaces(ctx)
+}
+
+// GetQuota returns the usage and limits of the account as reported by the connector (RFC9208). If the connector
+// doesn't report the usage, that of the limited resources is computed from the messages in the database. It returns
+// false if there is no quota.
+func (state *State) GetQuota(ctx context.Context) (imap.Quota, bool, error) {
+	quota, ok, err := state.user.GetRemote().GetQuota(ctx)
+	if err != nil || !ok {
+		return imap.Quota{}, false, err
+	}
+
+	if quota.StorageUsage == 0 && quota.MessageUsage == 0 && (quota.StorageLimit > 0 || quota.MessageLimit > 0) {
+		if err := stateDBRead(ctx, state, func(ctx context.Context, client db.ReadOnly) error {
+			if quota.StorageLimit > 0 {
+				size, err := client.GetTotalMessageSize(ctx)
+				if err != nil {
+					return err
+				}
+
+				quota.StorageUsage = size
+			}
+
+			if quota.MessageLimit > 0 {
+				count, err := client.GetTotalActiveMessageCount(ctx)
+				if err != nil {
+					return err
+				}
+
+				quota.MessageUsage = int64(count)
+			}
+
+			return nil
+		}); err != nil {
+			return imap.Quota{}, false, err
+		}
+	}
+
+	return quota, true, nil
+}
+
+// checkQuota returns ErrOverQuota if storing the given number of new messages of the given total size would exceed
+// the account's quota. Nothing is checked if the connector doesn't report a quota.
+// COPY and MOVE are not checked: they only add existing messages to other mailboxes, which leaves the usage unchanged
+// as each message is counted once regardless of the number of mailboxes it belongs to.
+func (state *State) checkQuota(ctx context.Context, size, count int) error {
+	quota, ok, err := state.GetQuota(ctx)
+	if err != nil {
+		return err
+	}
+
+	if ok && quota.IsOverQuota(int64(size), int64(count)) {
+		return ErrOverQuota
+	}
+
+	return nil
+}
+
 func (state *State) IsSelected() bool {
 	return state.snap != nil
 }
@@ -617,13 +795,20 @@ func (state *State) QueueUpdates(updates ...Update) bool {
 func (state *State) ApplyUpdate(ctx context.Context, update Update) error {
 	state.log.WithField("Update", update).Debugf("Applying state update on state %v", state.StateID)
 
-	if !update.Filter(state) {
-		return nil
+	var err error
+
+	if update.Filter(state) {
+		err = state.user.GetDB().Write(ctx, func(ctx context.Context, tx db.Transaction) error {
+			return state.ApplyUpdateWithTx(ctx, tx, update)
+		})
+	} else if _, ok := update.(notifyUpdate); ok && state.notify != nil {
+		// Updates which only need to be reported to NOTIFY clients don't modify the database.
+		err = stateDBRead(ctx, state, func(ctx context.Context, client db.ReadOnly) error {
+			return state.applyNotifyUpdate(ctx, client, update)
+		})
 	}
 
-	if err := state.user.GetDB().Write(ctx, func(ctx context.Context, tx db.Transaction) error {
-		return update.Apply(ctx, tx, state)
-	}); err != nil {
+	if err != nil {
 		// Only one has been reported in Sentry so far...
 		reporter.MessageWithContext(ctx,
 			"Failed to apply state update",
@@ -636,6 +821,18 @@ func (state *State) ApplyUpdate(ctx context.Context, update Update) error {
 	return nil
 }
 
+// ApplyUpdateWithTx applies the update within the given transaction if it passes the update's filter, and reports its
+// events to NOTIFY clients.
+func (state *State) ApplyUpdateWithTx(ctx context.Context, tx db.Transaction, update Update) error {
+	if update.Filter(state) {
+		if err := update.Apply(ctx, tx, state); err != nil {
+			return err
+		}
+	}
+
+	return state.applyNotifyUpdate(ctx, tx, update)
+}
+
 func (state *State) HasMessage(id imap.InternalMessageID) bool {
 	return state.snap != nil && state.snap.hasMessage(id)
 }
@@ -644,6 +841,16 @@ func (state *State) IsValid() bool {
 	return !state.invalid
 }
 
+// getMessagesModSeq returns the mod sequence of the given messages in the selected mailbox if CONDSTORE is enabled and
+// nil otherwise.
+func (state *State) getMessagesModSeq(ctx context.Context, client db.ReadOnly, messageIDs []imap.InternalMessageID) (map[imap.InternalMessageID]imap.ModSeq, error) {
+	if !state.IsEnabled(imap.CONDSTORE) || state.snap == nil {
+		return nil, nil
+	}
+
+	return client.GetMessagesModSeq(ctx, state.snap.mboxID.InternalID, messageIDs)
+}
+
 func (state *State) markInvalid() {
 	state.invalid = true
 }
@@ -749,6 +956,8 @@ func (state *State) flushResponses(ctx context.Context, permitExpunge bool) ([]r
 	default: // fallthrough
 	}
 
+	responses = append(responses, state.PopNotifications()...)
+
 	var dbUpdates []responderDBUpdate
 
 	for _, responder := range state.popResponders(permitExpunge) {
@@ -886,6 +1095,8 @@ func (state *State) close() error {
 
 	state.res = nil
 
+	state.searchRes = nil
+
 	return nil
 }
 
@@ -0,0 +1,326 @@
+package state
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ProtonMail/gluon/imap"
+)
+
+// threadMessage holds the values of a message which are needed to thread it as defined in RFC5256.
+type threadMessage struct {
+	id         uint32
+	sentDate   time.Time
+	subject    string
+	isReply    bool
+	messageID  string
+	references []string
+}
+
+// threadByOrderedSubject implements the ORDEREDSUBJECT threading algorithm (RFC5256 section 3).
+// The given messages are expected to be in sequence order, which is used to break ties.
+func threadByOrderedSubject(msgs []*threadMessage) []imap.Thread {
+	sorted := make([]*threadMessage, len(msgs))
+	copy(sorted, msgs)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].subject != sorted[j].subject {
+			return sorted[i].subject < sorted[j].subject
+		}
+
+		return sorted[i].sentDate.Before(sorted[j].sentDate)
+	})
+
+	var (
+		threads []imap.Thread
+		firsts  []*threadMessage
+	)
+
+	for i := 0; i < len(sorted); {
+		thread := imap.Thread{ID: sorted[i].id}
+
+		j := i + 1
+
+		for ; j < len(sorted) && sorted[j].subject == sorted[i].subject; j++ {
+			thread.Children = append(thread.Children, imap.Thread{ID: sorted[j].id})
+		}
+
+		threads = append(threads, thread)
+		firsts = append(firsts, sorted[i])
+
+		i = j
+	}
+
+	// Threads are sorted by the sent date of their first message.
+	idx := make([]int, len(threads))
+	for i := range idx {
+		idx[i] = i
+	}
+
+	sort.SliceStable(idx, func(i, j int) bool {
+		return firsts[idx[i]].sentDate.Before(firsts[idx[j]].sentDate)
+	})
+
+	result := make([]imap.Thread, len(threads))
+	for i, v := range idx {
+		result[i] = threads[v]
+	}
+
+	return result
+}
+
+// threadContainer is a node of the REFERENCES threading algorithm. Containers without a message are placeholders
+// for messages which are referenced but not part of the result.
+type threadContainer struct {
+	message  *threadMessage
+	parent   *threadContainer
+	children []*threadContainer
+}
+
+// threadByReferences implements the REFERENCES threading algorithm (RFC5256 section 3).
+// The given messages are expected to be in sequence order, which is used to break ties.
+func threadByReferences(msgs []*threadMessage) []imap.Thread {
+	var (
+		containers = make(map[string]*threadContainer)
+		all        []*threadContainer
+	)
+
+	getContainer := func(id string) *threadContainer {
+		if c, ok := containers[id]; ok {
+			return c
+		}
+
+		c := &threadContainer{}
+
+		containers[id] = c
+		all = append(all, c)
+
+		return c
+	}
+
+	// (1) Link the messages according to their references.
+	for _, msg := range msgs {
+		var c *threadContainer
+
+		if existing, ok := containers[msg.messageID]; ok && msg.messageID != "" && existing.message == nil {
+			c = existing
+		} else if !ok && msg.messageID != "" {
+			c = getContainer(msg.messageID)
+		} else {
+			// Messages without or with a duplicate Message-ID are treated as if they had a unique one.
+			c = &threadContainer{}
+			all = append(all, c)
+		}
+
+		c.message = msg
+
+		var prev *threadContainer
+
+		for _, ref := range msg.references {
+			refContainer := getContainer(ref)
+
+			if prev != nil && refContainer.parent == nil && !refContainer.isLinkedTo(prev) {
+				prev.addChild(refContainer)
+			}
+
+			prev = refContainer
+		}
+
+		c.removeFromParent()
+
+		if prev != nil && !c.isLinkedTo(prev) {
+			prev.addChild(c)
+		}
+	}
+
+	// (2) Gather the containers without a parent.
+	var roots []*threadContainer
+
+	for _, c := range all {
+		if c.parent == nil {
+			roots = append(roots, c)
+		}
+	}
+
+	// (4) Prune the placeholders.
+	roots = pruneThreadContainers(roots, true)
+
+	// (5) Sort the threads and their children by sent date.
+	sortThreadContainers(roots)
+
+	// (6) Merge the threads which have the same base subject.
+	roots = groupThreadContainersBySubject(roots)
+
+	sortThreadContainers(roots)
+
+	threads := make([]imap.Thread, 0, len(roots))
+
+	for _, root := range roots {
+		threads = append(threads, root.toThread())
+	}
+
+	return threads
+}
+
+func (c *threadContainer) addChild(child *threadContainer) {
+	child.removeFromParent()
+
+	child.parent = c
+	c.children = append(c.children, child)
+}
+
+func (c *threadContainer) removeFromParent() {
+	if c.parent == nil {
+		return
+	}
+
+	for i, child := range c.parent.children {
+		if child == c {
+			c.parent.children = append(c.parent.children[:i], c.parent.children[i+1:]...)
+			break
+		}
+	}
+
+	c.parent = nil
+}
+
+// isLinkedTo returns whether linking the two containers would introduce a loop.
+func (c *threadContainer) isLinkedTo(other *threadContainer) bool {
+	return c == other || c.hasDescendant(other) || other.hasDescendant(c)
+}
+
+func (c *threadContainer) hasDescendant(other *threadContainer) bool {
+	for _, child := range c.children {
+		if child == other || child.hasDescendant(other) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// first returns the message by which the container is sorted and grouped: its own, or the one of its first child.
+func (c *threadContainer) first() *threadMessage {
+	if c.message != nil || len(c.children) == 0 {
+		return c.message
+	}
+
+	return c.children[0].first()
+}
+
+func (c *threadContainer) toThread() imap.Thread {
+	var thread imap.Thread
+
+	if c.message != nil {
+		thread.ID = c.message.id
+	}
+
+	for _, child := range c.children {
+		thread.Children = append(thread.Children, child.toThread())
+	}
+
+	return thread
+}
+
+// pruneThreadContainers removes the placeholders without children and replaces the others by their children, unless
+// this would promote more than one child to the root.
+func pruneThreadContainers(containers []*threadContainer, isRoot bool) []*threadContainer {
+	var result []*threadContainer
+
+	for _, c := range containers {
+		c.children = pruneThreadContainers(c.children, false)
+
+		if c.message == nil {
+			if len(c.children) == 0 {
+				continue
+			}
+
+			if !isRoot || len(c.children) == 1 {
+				for _, child := range c.children {
+					child.parent = c.parent
+				}
+
+				result = append(result, c.children...)
+
+				continue
+			}
+		}
+
+		result = append(result, c)
+	}
+
+	return result
+}
+
+func sortThreadContainers(containers []*threadContainer) {
+	for _, c := range containers {
+		sortThreadContainers(c.children)
+	}
+
+	sort.SliceStable(containers, func(i, j int) bool {
+		return containers[i].first().sentDate.Before(containers[j].first().sentDate)
+	})
+}
+
+func groupThreadContainersBySubject(roots []*threadContainer) []*threadContainer {
+	subjects := make(map[string]*threadContainer)
+
+	for _, c := range roots {
+		subject := c.first().subject
+		if subject == "" {
+			continue
+		}
+
+		other, ok := subjects[subject]
+
+		switch {
+		case !ok:
+			subjects[subject] = c
+
+		case other.message != nil && c.message == nil:
+			subjects[subject] = c
+
+		case other.message != nil && other.message.isReply && c.message != nil && !c.message.isReply:
+			subjects[subject] = c
+		}
+	}
+
+	var result []*threadContainer
+
+	for _, c := range roots {
+		subject := c.first().subject
+
+		other, ok := subjects[subject]
+		if subject == "" || !ok || other == c {
+			result = append(result, c)
+			continue
+		}
+
+		switch {
+		case other.message == nil && c.message == nil:
+			for _, child := range append([]*threadContainer{}, c.children...) {
+				other.addChild(child)
+			}
+
+		case other.message == nil:
+			other.addChild(c)
+
+		case c.message.isReply && !other.message.isReply:
+			other.addChild(c)
+
+		default:
+			// Turn the other container into a placeholder for both threads, keeping its position among the roots.
+			moved := &threadContainer{message: other.message}
+
+			for _, child := range append([]*threadContainer{}, other.children...) {
+				moved.addChild(child)
+			}
+
+			other.message = nil
+			other.addChild(moved)
+			other.addChild(c)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,96 @@
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/gluon/imap"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestThreadMessage(id uint32, day int, subject string, isReply bool, messageID string, references ...string) *threadMessage {
+	return &threadMessage{
+		id:         id,
+		sentDate:   time.Date(2023, time.January, day, 0, 0, 0, 0, time.UTC),
+		subject:    subject,
+		isReply:    isReply,
+		messageID:  messageID,
+		references: references,
+	}
+}
+
+func TestThreadByOrderedSubject(t *testing.T) {
+	threads := threadByOrderedSubject([]*threadMessage{
+		newTestThreadMessage(1, 3, "A", false, ""),
+		newTestThreadMessage(2, 1, "B", false, ""),
+		newTestThreadMessage(3, 2, "A", true, ""),
+		newTestThreadMessage(4, 4, "B", true, ""),
+		newTestThreadMessage(5, 5, "C", false, ""),
+	})
+
+	require.Equal(t, []imap.Thread{
+		{ID: 2, Children: []imap.Thread{{ID: 4}}},
+		{ID: 3, Children: []imap.Thread{{ID: 1}}},
+		{ID: 5},
+	}, threads)
+}
+
+func TestThreadByReferences(t *testing.T) {
+	threads := threadByReferences([]*threadMessage{
+		newTestThreadMessage(1, 1, "HELLO", false, "a"),
+		newTestThreadMessage(2, 2, "HELLO", true, "b", "a"),
+		newTestThreadMessage(3, 3, "HELLO", true, "c", "a"),
+		newTestThreadMessage(4, 4, "HELLO", true, "d", "a", "b"),
+		newTestThreadMessage(5, 5, "OTHER", false, "e"),
+
+		// The parent isn't part of the result, but the subject matches another thread.
+		newTestThreadMessage(6, 6, "OTHER", true, "f", "x"),
+	})
+
+	require.Equal(t, []imap.Thread{
+		{ID: 1, Children: []imap.Thread{
+			{ID: 2, Children: []imap.Thread{{ID: 4}}},
+			{ID: 3},
+		}},
+		{ID: 5, Children: []imap.Thread{{ID: 6}}},
+	}, threads)
+}
+
+func TestThreadByReferencesPlaceholders(t *testing.T) {
+	threads := threadByReferences([]*threadMessage{
+		// Siblings of a missing parent are kept under a placeholder.
+		newTestThreadMessage(1, 2, "P", false, "a", "x"),
+		newTestThreadMessage(2, 1, "Q", false, "b", "x"),
+
+		// Unrelated threads with the same subject are grouped under a placeholder.
+		newTestThreadMessage(3, 3, "SAME", false, "c"),
+		newTestThreadMessage(4, 4, "SAME", false, "d"),
+
+		// Messages without subject are never grouped.
+		newTestThreadMessage(5, 5, "", false, ""),
+		newTestThreadMessage(6, 6, "", false, ""),
+	})
+
+	require.Equal(t, []imap.Thread{
+		{Children: []imap.Thread{{ID: 2}, {ID: 1}}},
+		{Children: []imap.Thread{{ID: 3}, {ID: 4}}},
+		{ID: 5},
+		{ID: 6},
+	}, threads)
+}
+
+func TestThreadByReferencesLoop(t *testing.T) {
+	threads := threadByReferences([]*threadMessage{
+		newTestThreadMessage(1, 1, "A", false, "a", "b"),
+		newTestThreadMessage(2, 2, "B", false, "b", "a"),
+		newTestThreadMessage(3, 3, "C", false, "c", "c"),
+		newTestThreadMessage(4, 4, "D", false, "c"),
+	})
+
+	// Links which would introduce a loop are ignored and duplicate Message-IDs are treated as unique.
+	require.Equal(t, []imap.Thread{
+		{ID: 2, Children: []imap.Thread{{ID: 1}}},
+		{ID: 3},
+		{ID: 4},
+	}, threads)
+}
@@ -0,0 +1,116 @@
+package state
+
+import (
+	"math"
+
+	"github.com/ProtonMail/gluon/imap"
+	"github.com/ProtonMail/gluon/imap/command"
+	"golang.org/x/exp/slices"
+)
+
+// uidSetIntervals returns the sorted and disjoint intervals of the UIDs of the given set. Since the UIDs may belong
+// to messages that no longer exist, "*" is treated as the largest possible UID rather than the largest UID in the
+// mailbox.
+func uidSetIntervals(uidSet []command.SeqRange) []UIDInterval {
+	resolve := func(n command.SeqNum) imap.UID {
+		if n.IsAsterisk() {
+			return imap.UID(math.MaxUint32)
+		}
+
+		return imap.UID(uint32(n))
+	}
+
+	intervals := make([]UIDInterval, 0, len(uidSet))
+
+	for _, uidRange := range uidSet {
+		begin, end := resolve(uidRange.Begin), resolve(uidRange.End)
+
+		if begin > end {
+			begin, end = end, begin
+		}
+
+		intervals = append(intervals, UIDInterval{begin: begin, end: end})
+	}
+
+	slices.SortFunc(intervals, func(a, b UIDInterval) bool {
+		return a.begin < b.begin
+	})
+
+	var res []UIDInterval
+
+	for _, interval := range intervals {
+		res = appendUIDInterval(res, interval)
+	}
+
+	return res
+}
+
+// uidIntervalsFromUIDs returns the sorted and disjoint intervals of the given sorted UIDs.
+func uidIntervalsFromUIDs(uids []imap.UID) []UIDInterval {
+	var res []UIDInterval
+
+	for _, uid := range uids {
+		res = appendUIDInterval(res, UIDInterval{begin: uid, end: uid})
+	}
+
+	return res
+}
+
+// unionUIDIntervals returns the sorted and disjoint intervals of the UIDs contained in either of the given sorted and
+// disjoint intervals.
+func unionUIDIntervals(a, b []UIDInterval) []UIDInterval {
+	res := make([]UIDInterval, 0, len(a)+len(b))
+
+	for len(a) > 0 || len(b) > 0 {
+		if len(b) == 0 || (len(a) > 0 && a[0].begin <= b[0].begin) {
+			res, a = appendUIDInterval(res, a[0]), a[1:]
+		} else {
+			res, b = appendUIDInterval(res, b[0]), b[1:]
+		}
+	}
+
+	return res
+}
+
+// intersectUIDIntervals returns the sorted and disjoint intervals of the UIDs contained in both of the given sorted
+// and disjoint intervals.
+func intersectUIDIntervals(a, b []UIDInterval) []UIDInterval {
+	var res []UIDInterval
+
+	for len(a) > 0 && len(b) > 0 {
+		if begin, end := max(a[0].begin, b[0].begin), min(a[0].end, b[0].end); begin <= end {
+			res = append(res, UIDInterval{begin: begin, end: end})
+		}
+
+		if a[0].end < b[0].end {
+			a = a[1:]
+		} else {
+			b = b[1:]
+		}
+	}
+
+	return res
+}
+
+// appendUIDInterval appends the interval to the sorted and disjoint intervals, merging it with the last one if they
+// overlap or are adjacent. The interval must not begin before the last one.
+func appendUIDInterval(intervals []UIDInterval, interval UIDInterval) []UIDInterval {
+	if n := len(intervals); n > 0 && uint64(interval.begin) <= uint64(intervals[n-1].end)+1 {
+		intervals[n-1].end = max(intervals[n-1].end, interval.end)
+
+		return intervals
+	}
+
+	return append(intervals, interval)
+}
+
+// uidIntervalsToSeqSet returns the sequence set of the UIDs of the given sorted and disjoint intervals.
+func uidIntervalsToSeqSet(intervals []UIDInterval) imap.SeqSet {
+	res := make(imap.SeqSet, 0, len(intervals))
+
+	for _, interval := range intervals {
+		res = append(res, imap.SeqVal{Begin: imap.SeqID(interval.begin), End: imap.SeqID(interval.end)})
+	}
+
+	return res
+}
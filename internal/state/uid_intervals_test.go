@@ -0,0 +1,46 @@
+package state
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ProtonMail/gluon/imap"
+	"github.com/ProtonMail/gluon/imap/command"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUIDSetIntervals(t *testing.T) {
+	require.Equal(t, []UIDInterval{{begin: 1, end: 5}, {begin: 8, end: math.MaxUint32}}, uidSetIntervals([]command.SeqRange{
+		{Begin: 10, End: command.SeqNumValueAsterisk},
+		{Begin: 4, End: 2},
+		{Begin: 1, End: 1},
+		{Begin: 5, End: 5},
+		{Begin: 8, End: 12},
+	}))
+}
+
+func TestUIDIntervalsFromUIDs(t *testing.T) {
+	require.Equal(t, []UIDInterval{{begin: 1, end: 3}, {begin: 5, end: 5}, {begin: 7, end: 8}}, uidIntervalsFromUIDs([]imap.UID{1, 2, 3, 5, 7, 8}))
+	require.Empty(t, uidIntervalsFromUIDs(nil))
+}
+
+func TestUnionUIDIntervals(t *testing.T) {
+	a := []UIDInterval{{begin: 1, end: 3}, {begin: 10, end: 20}, {begin: 30, end: 30}}
+	b := []UIDInterval{{begin: 4, end: 5}, {begin: 15, end: 25}, {begin: 40, end: 50}}
+
+	require.Equal(t, []UIDInterval{{begin: 1, end: 5}, {begin: 10, end: 25}, {begin: 30, end: 30}, {begin: 40, end: 50}}, unionUIDIntervals(a, b))
+	require.Equal(t, a, unionUIDIntervals(a, nil))
+	require.Equal(t, b, unionUIDIntervals(nil, b))
+}
+
+func TestIntersectUIDIntervals(t *testing.T) {
+	a := []UIDInterval{{begin: 1, end: 3}, {begin: 10, end: 20}, {begin: 30, end: 30}}
+	b := []UIDInterval{{begin: 2, end: 12}, {begin: 18, end: 30}}
+
+	require.Equal(t, []UIDInterval{{begin: 2, end: 3}, {begin: 10, end: 12}, {begin: 18, end: 20}, {begin: 30, end: 30}}, intersectUIDIntervals(a, b))
+	require.Empty(t, intersectUIDIntervals(a, nil))
+}
+
+func TestAppendUIDIntervalMaxUID(t *testing.T) {
+	require.Equal(t, []UIDInterval{{begin: 1, end: math.MaxUint32}}, appendUIDInterval([]UIDInterval{{begin: 1, end: math.MaxUint32}}, UIDInterval{begin: math.MaxUint32, end: math.MaxUint32}))
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/ProtonMail/gluon/db"
 	"github.com/ProtonMail/gluon/imap"
+	"github.com/ProtonMail/gluon/imap/command"
 	"github.com/ProtonMail/gluon/internal/contexts"
 	"github.com/ProtonMail/gluon/internal/ids"
 	"github.com/bradenaw/juniper/xslices"
@@ -40,6 +41,23 @@ func (u *messageFlagsComboStateUpdate) Apply(ctx context.Context, tx db.Transact
 	return nil
 }
 
+func (u *messageFlagsComboStateUpdate) notifyEvents(ctx context.Context, client db.ReadOnly) ([]notifyEvent, error) {
+	var events []notifyEvent
+
+	for _, v := range u.updates {
+		if v, ok := v.(notifyUpdate); ok {
+			e, err := v.notifyEvents(ctx, client)
+			if err != nil {
+				return nil, err
+			}
+
+			events = append(events, e...)
+		}
+	}
+
+	return events, nil
+}
+
 func (u *messageFlagsComboStateUpdate) addUpdate(update Update) {
 	u.updates = append(u.updates, update)
 }
@@ -66,6 +84,11 @@ func newMessageFlagsAddedStateUpdate(flags imap.FlagSet, mboxID db.MailboxIDPair
 }
 
 func (u *messageFlagsAddedStateUpdate) Apply(ctx context.Context, tx db.Transaction, s *State) error {
+	modSeqs, err := s.getMessagesModSeq(ctx, tx, u.messageIDs)
+	if err != nil {
+		return err
+	}
+
 	for _, messageID := range u.messageIDs {
 		newFlags := u.flags
 
@@ -76,7 +99,7 @@ func (u *messageFlagsAddedStateUpdate) Apply(ctx context.Context, tx db.Transact
 			s.StateID == u.stateID && contexts.IsSilent(ctx),
 			s.snap.mboxID != u.mboxID,
 			FetchFlagOpAdd,
-		)); err != nil {
+		).withModSeq(modSeqs[messageID])); err != nil {
 			return err
 		}
 	}
@@ -84,6 +107,10 @@ func (u *messageFlagsAddedStateUpdate) Apply(ctx context.Context, tx db.Transact
 	return nil
 }
 
+func (u *messageFlagsAddedStateUpdate) notifyEvents(context.Context, db.ReadOnly) ([]notifyEvent, error) {
+	return []notifyEvent{{event: command.NotifyEventFlagChange, mboxID: u.mboxID.InternalID}}, nil
+}
+
 func (u *messageFlagsAddedStateUpdate) String() string {
 	return fmt.Sprintf("MessagFlagsAddedStateUpdate: mbox = %v messages = %v flags = %v",
 		u.mboxID.InternalID.ShortID(),
@@ -217,6 +244,11 @@ func NewMessageFlagsRemovedStateUpdate(flags imap.FlagSet, mboxID db.MailboxIDPa
 }
 
 func (u *messageFlagsRemovedStateUpdate) Apply(ctx context.Context, tx db.Transaction, s *State) error {
+	modSeqs, err := s.getMessagesModSeq(ctx, tx, u.messageIDs)
+	if err != nil {
+		return err
+	}
+
 	for _, messageID := range u.messageIDs {
 		newFlags := u.flags
 
@@ -227,7 +259,7 @@ func (u *messageFlagsRemovedStateUpdate) Apply(ctx context.Context, tx db.Transa
 			s.StateID == u.stateID && contexts.IsSilent(ctx),
 			s.snap.mboxID != u.mboxID,
 			FetchFlagOpRem,
-		)); err != nil {
+		).withModSeq(modSeqs[messageID])); err != nil {
 			return err
 		}
 	}
@@ -235,6 +267,10 @@ func (u *messageFlagsRemovedStateUpdate) Apply(ctx context.Context, tx db.Transa
 	return nil
 }
 
+func (u *messageFlagsRemovedStateUpdate) notifyEvents(context.Context, db.ReadOnly) ([]notifyEvent, error) {
+	return []notifyEvent{{event: command.NotifyEventFlagChange, mboxID: u.mboxID.InternalID}}, nil
+}
+
 func (u *messageFlagsRemovedStateUpdate) String() string {
 	return fmt.Sprintf("MessagFlagsRemovedStateUpdate: mbox = %v messages = %v flags = %v",
 		u.mboxID.InternalID,
@@ -365,6 +401,11 @@ func NewMessageFlagsSetStateUpdate(flags imap.FlagSet, mboxID db.MailboxIDPair,
 }
 
 func (u *messageFlagsSetStateUpdate) Apply(ctx context.Context, tx db.Transaction, state *State) error {
+	modSeqs, err := state.getMessagesModSeq(ctx, tx, u.messageIDs)
+	if err != nil {
+		return err
+	}
+
 	for _, messageID := range u.messageIDs {
 		newFlags := u.flags
 
@@ -375,7 +416,7 @@ func (u *messageFlagsSetStateUpdate) Apply(ctx context.Context, tx db.Transactio
 			state.StateID == u.stateID && contexts.IsSilent(ctx),
 			state.snap.mboxID != u.mboxID,
 			FetchFlagOpSet,
-		)); err != nil {
+		).withModSeq(modSeqs[messageID])); err != nil {
 			return err
 		}
 	}
@@ -383,6 +424,10 @@ func (u *messageFlagsSetStateUpdate) Apply(ctx context.Context, tx db.Transactio
 	return nil
 }
 
+func (u *messageFlagsSetStateUpdate) notifyEvents(context.Context, db.ReadOnly) ([]notifyEvent, error) {
+	return []notifyEvent{{event: command.NotifyEventFlagChange, mboxID: u.mboxID.InternalID}}, nil
+}
+
 func (u *messageFlagsSetStateUpdate) String() string {
 	return fmt.Sprintf("MessageFlagsSetStateUpdate: mbox = %v messages = %v flags=%v",
 		u.mboxID.InternalID.ShortID(),
@@ -6,7 +6,9 @@ import (
 
 	"github.com/ProtonMail/gluon/db"
 	"github.com/ProtonMail/gluon/imap"
+	"github.com/ProtonMail/gluon/imap/command"
 	"github.com/ProtonMail/gluon/internal/contexts"
+	"github.com/bradenaw/juniper/xslices"
 )
 
 type RemoteAddMessageFlagsStateUpdate struct {
@@ -22,7 +24,16 @@ func NewRemoteAddMessageFlagsStateUpdate(messageID imap.InternalMessageID, flag
 }
 
 func (u *RemoteAddMessageFlagsStateUpdate) Apply(ctx context.Context, tx db.Transaction, s *State) error {
-	return s.PushResponder(ctx, tx, NewFetch(u.MessageID, imap.NewFlagSet(u.flag), contexts.IsUID(ctx), contexts.IsSilent(ctx), false, FetchFlagOpAdd))
+	modSeqs, err := s.getMessagesModSeq(ctx, tx, []imap.InternalMessageID{u.MessageID})
+	if err != nil {
+		return err
+	}
+
+	return s.PushResponder(ctx, tx, NewFetch(u.MessageID, imap.NewFlagSet(u.flag), contexts.IsUID(ctx), contexts.IsSilent(ctx), false, FetchFlagOpAdd).withModSeq(modSeqs[u.MessageID]))
+}
+
+func (u *RemoteAddMessageFlagsStateUpdate) notifyEvents(ctx context.Context, client db.ReadOnly) ([]notifyEvent, error) {
+	return getMessageFlagChangeEvents(ctx, client, u.MessageID)
 }
 
 func (u *RemoteAddMessageFlagsStateUpdate) String() string {
@@ -42,7 +53,16 @@ func NewRemoteRemoveMessageFlagsStateUpdate(messageID imap.InternalMessageID, fl
 }
 
 func (u *RemoteRemoveMessageFlagsStateUpdate) Apply(ctx context.Context, tx db.Transaction, s *State) error {
-	return s.PushResponder(ctx, tx, NewFetch(u.MessageID, imap.NewFlagSet(u.flag), contexts.IsUID(ctx), contexts.IsSilent(ctx), false, FetchFlagOpRem))
+	modSeqs, err := s.getMessagesModSeq(ctx, tx, []imap.InternalMessageID{u.MessageID})
+	if err != nil {
+		return err
+	}
+
+	return s.PushResponder(ctx, tx, NewFetch(u.MessageID, imap.NewFlagSet(u.flag), contexts.IsUID(ctx), contexts.IsSilent(ctx), false, FetchFlagOpRem).withModSeq(modSeqs[u.MessageID]))
+}
+
+func (u *RemoteRemoveMessageFlagsStateUpdate) notifyEvents(ctx context.Context, client db.ReadOnly) ([]notifyEvent, error) {
+	return getMessageFlagChangeEvents(ctx, client, u.MessageID)
 }
 
 func (u *RemoteRemoveMessageFlagsStateUpdate) String() string {
@@ -53,3 +73,15 @@ type RemoteMessageDeletedStateUpdate struct {
 	MessageIDStateFilter
 	remoteID imap.MessageID
 }
+
+// getMessageFlagChangeEvents returns the FlagChange events of all the mailboxes containing the given message.
+func getMessageFlagChangeEvents(ctx context.Context, client db.ReadOnly, messageID imap.InternalMessageID) ([]notifyEvent, error) {
+	mboxIDs, err := client.GetMessageMailboxIDs(ctx, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	return xslices.Map(mboxIDs, func(mboxID imap.InternalMailboxID) notifyEvent {
+		return notifyEvent{event: command.NotifyEventFlagChange, mboxID: mboxID}
+	}), nil
+}
@@ -0,0 +1,172 @@
+package state
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/gluon/db"
+	"github.com/ProtonMail/gluon/imap"
+	"github.com/ProtonMail/gluon/imap/command"
+)
+
+// accessKeySize is the size of the mailbox access keys used to authorize URLAUTH (RFC4467) URLs.
+const accessKeySize = 16
+
+// GenerateURLAuth authorizes the given URLAUTH rump URL with the access key of its mailbox, which is created if
+// needed, and returns the authorized URL.
+func (state *State) GenerateURLAuth(ctx context.Context, u *command.URL) (string, error) {
+	return stateDBWriteResult(ctx, state, func(ctx context.Context, tx db.Transaction) ([]Update, string, error) {
+		mbox, err := tx.GetMailboxByName(ctx, u.Mailbox)
+		if err != nil {
+			if errors.Is(err, db.ErrNotFound) {
+				return nil, "", ErrNoSuchMailbox
+			}
+
+			return nil, "", err
+		}
+
+		key, err := tx.GetMailboxAccessKey(ctx, mbox.ID)
+		if err != nil {
+			if !errors.Is(err, db.ErrNotFound) {
+				return nil, "", err
+			}
+
+			key = make([]byte, accessKeySize)
+
+			if _, err := rand.Read(key); err != nil {
+				return nil, "", err
+			}
+
+			if err := tx.SetMailboxAccessKey(ctx, mbox.ID, key); err != nil {
+				return nil, "", err
+			}
+		}
+
+		return nil, u.Rump + ":" + strings.ToLower(command.URLAuthMechanismInternal) + ":" + signURL(key, u.Rump), nil
+	})
+}
+
+// ResetURLAuthKeys invalidates the URLs authorized for the mailbox with the given name, or for all the mailboxes if
+// the name is empty.
+func (state *State) ResetURLAuthKeys(ctx context.Context, name string) error {
+	return stateDBWrite(ctx, state, func(ctx context.Context, tx db.Transaction) ([]Update, error) {
+		if name == "" {
+			return nil, tx.DeleteMailboxAccessKeys(ctx)
+		}
+
+		mbox, err := tx.GetMailboxByName(ctx, name)
+		if err != nil {
+			if errors.Is(err, db.ErrNotFound) {
+				return nil, ErrNoSuchMailbox
+			}
+
+			return nil, err
+		}
+
+		return nil, tx.DeleteMailboxAccessKeys(ctx, mbox.ID)
+	})
+}
+
+// FetchURL returns the content of the message, or of the part of it, the URL points at. URLs carrying a URLAUTH
+// authorization are only resolved if it is valid.
+func (state *State) FetchURL(ctx context.Context, u *command.URL) ([]byte, error) {
+	msgID, err := stateDBReadResult(ctx, state, func(ctx context.Context, client db.ReadOnly) (db.MessageIDPair, error) {
+		mbox, err := client.GetMailboxByName(ctx, u.Mailbox)
+		if err != nil {
+			if errors.Is(err, db.ErrNotFound) {
+				return db.MessageIDPair{}, ErrNoSuchMailbox
+			}
+
+			return db.MessageIDPair{}, err
+		}
+
+		if u.Access != "" {
+			if err := verifyURLAuth(ctx, client, mbox.ID, u); err != nil {
+				return db.MessageIDPair{}, err
+			}
+		}
+
+		if u.UIDValidity != 0 && u.UIDValidity != mbox.UIDValidity {
+			return db.MessageIDPair{}, ErrNoSuchMessage
+		}
+
+		msgID, err := client.GetMailboxMessageIDWithUID(ctx, mbox.ID, u.UID)
+		if err != nil {
+			if errors.Is(err, db.ErrNotFound) {
+				return db.MessageIDPair{}, ErrNoSuchMessage
+			}
+
+			return db.MessageIDPair{}, err
+		}
+
+		return msgID, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	literal, err := state.getLiteral(ctx, msgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Section != nil {
+		if literal, err = fetchBodySection(u.Section, literal); err != nil {
+			return nil, err
+		}
+	}
+
+	if u.Partial != nil {
+		if int(u.Partial.Offset) >= len(literal) {
+			return []byte{}, nil
+		}
+
+		literal = literal[u.Partial.Offset:]
+
+		if u.Partial.Count >= 0 && int(u.Partial.Count) < len(literal) {
+			literal = literal[:u.Partial.Count]
+		}
+	}
+
+	return literal, nil
+}
+
+func verifyURLAuth(ctx context.Context, client db.ReadOnly, mboxID imap.InternalMailboxID, u *command.URL) error {
+	if !strings.EqualFold(u.Mechanism, command.URLAuthMechanismInternal) {
+		return ErrURLAuthInvalid
+	}
+
+	if !u.Expire.IsZero() && time.Now().After(u.Expire) {
+		return ErrURLAuthInvalid
+	}
+
+	key, err := client.GetMailboxAccessKey(ctx, mboxID)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return ErrURLAuthInvalid
+		}
+
+		return err
+	}
+
+	if !hmac.Equal([]byte(signURL(key, u.Rump)), []byte(strings.ToLower(u.Token))) {
+		return ErrURLAuthInvalid
+	}
+
+	return nil
+}
+
+// signURL returns the URLAUTH token of the INTERNAL mechanism for the given rump URL.
+func signURL(key []byte, rump string) string {
+	mac := hmac.New(sha1.New, key)
+
+	mac.Write([]byte(rump))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -15,8 +15,13 @@ type IMAP struct {
 	maxMessageCountPerMailbox int64
 	maxUIDValidity            int64
 	maxUID                    int64
+	maxNonSyncLiteralSize     int64
 }
 
+// LiteralMinusMaxSize is the largest non-synchronizing literal that clients may send to a server advertising
+// LITERAL- as defined in RFC7888.
+const LiteralMinusMaxSize = 4096
+
 func (i IMAP) CheckMailBoxCount(mailboxCount int) error {
 	if int64(mailboxCount) >= i.maxMailboxCount {
 		return ErrMaxMailboxCountReached
@@ -53,6 +58,20 @@ func (i IMAP) CheckUIDValidity(uid imap.UID) error {
 	return nil
 }
 
+// MaxNonSyncLiteralSize returns the largest non-synchronizing literal accepted by the server. A value of 0 means that
+// non-synchronizing literals are not limited beyond the regular literal size limit (LITERAL+).
+func (i IMAP) MaxNonSyncLiteralSize() int {
+	return int(i.maxNonSyncLiteralSize)
+}
+
+// WithMaxNonSyncLiteralSize returns a copy of the limits in which non-synchronizing literals are limited to the given
+// size (LITERAL-). RFC7888 clients expect to be able to send at least LiteralMinusMaxSize bytes.
+func (i IMAP) WithMaxNonSyncLiteralSize(size uint32) IMAP {
+	i.maxNonSyncLiteralSize = int64(size)
+
+	return i
+}
+
 func DefaultLimits() IMAP {
 	var maxInt int64
 	if bits.UintSize == 64 {
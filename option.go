@@ -231,6 +231,28 @@ func WithDisableIMAPAuthenticate() Option {
 	return &withDisableIMAPAuthenticate{}
 }
 
+type withCompression struct{}
+
+func (withCompression) config(builder *serverBuilder) {
+	builder.compression = true
+}
+
+// WithCompression allows clients to compress the connection with COMPRESS=DEFLATE (RFC4978).
+func WithCompression() Option {
+	return &withCompression{}
+}
+
+type withIMAP4rev2 struct{}
+
+func (withIMAP4rev2) config(builder *serverBuilder) {
+	builder.imap4rev2 = true
+}
+
+// WithIMAP4rev2 advertises IMAP4rev2 (RFC9051) alongside IMAP4rev1. Clients switch a session to IMAP4rev2 with ENABLE.
+func WithIMAP4rev2() Option {
+	return &withIMAP4rev2{}
+}
+
 type withUIDValidityGenerator struct {
 	generator imap.UIDValidityGenerator
 }
@@ -31,6 +31,24 @@ const (
 	CmdTypeUIDStore
 	CmdTypeUIDFetch
 	CmdTypeUIDSearch
+	CmdTypeEnable
+	CmdTypeNamespace
+	CmdTypeCompress
+	CmdTypeSort
+	CmdTypeUIDSort
+	CmdTypeThread
+	CmdTypeUIDThread
+	CmdTypeGetQuota
+	CmdTypeGetQuotaRoot
+	CmdTypeGetMetadata
+	CmdTypeSetMetadata
+	CmdTypeNotify
+	CmdTypeResetKey
+	CmdTypeGenURLAuth
+	CmdTypeURLFetch
+	CmdTypeReplace
+	CmdTypeUIDReplace
+	CmdTypeMultiAppend
 	CmdTypeTotal
 )
 
@@ -96,6 +114,42 @@ func CmdTypeToString(cmdType int) string {
 		return "USTORE "
 	case CmdTypeUIDSearch:
 		return "USEARCH"
+	case CmdTypeEnable:
+		return "ENABLE "
+	case CmdTypeNamespace:
+		return "NSPACE "
+	case CmdTypeCompress:
+		return "COMPRES"
+	case CmdTypeSort:
+		return "SORT   "
+	case CmdTypeUIDSort:
+		return "USORT  "
+	case CmdTypeThread:
+		return "THREAD "
+	case CmdTypeUIDThread:
+		return "UTHREAD"
+	case CmdTypeGetQuota:
+		return "GQUOTA "
+	case CmdTypeGetQuotaRoot:
+		return "GQROOT "
+	case CmdTypeGetMetadata:
+		return "GETMETA"
+	case CmdTypeSetMetadata:
+		return "SETMETA"
+	case CmdTypeNotify:
+		return "NOTIFY "
+	case CmdTypeResetKey:
+		return "RESETKY"
+	case CmdTypeGenURLAuth:
+		return "GENURLA"
+	case CmdTypeURLFetch:
+		return "URLFTCH"
+	case CmdTypeReplace:
+		return "REPLACE"
+	case CmdTypeUIDReplace:
+		return "UREPLAC"
+	case CmdTypeMultiAppend:
+		return "MAPPEND"
 
 	default:
 		return "Unknown"
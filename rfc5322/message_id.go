@@ -0,0 +1,42 @@
+package rfc5322
+
+import (
+	"strings"
+)
+
+// ParseMessageIDList parses the message identifiers of a Message-ID, In-Reply-To or References header as defined in
+// RFC5322 section 3.6.4. Parsing is lenient as required by RFC5256: anything enclosed in angle brackets is considered
+// to be a message identifier (without the brackets), while comments and any other text are skipped.
+func ParseMessageIDList(input string) []string {
+	var (
+		ids   []string
+		depth int
+	)
+
+	for i := 0; i < len(input); i++ {
+		switch c := input[i]; {
+		case c == '\\' && depth > 0:
+			i++
+
+		case c == '(':
+			depth++
+
+		case c == ')' && depth > 0:
+			depth--
+
+		case c == '<' && depth == 0:
+			end := strings.IndexByte(input[i+1:], '>')
+			if end < 0 {
+				return ids
+			}
+
+			if id := strings.Join(strings.Fields(input[i+1:i+1+end]), ""); id != "" {
+				ids = append(ids, id)
+			}
+
+			i += end + 1
+		}
+	}
+
+	return ids
+}
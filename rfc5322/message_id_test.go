@@ -0,0 +1,31 @@
+package rfc5322
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMessageIDList(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{input: ``, want: nil},
+		{input: `<1234@local.machine.example>`, want: []string{`1234@local.machine.example`}},
+		{input: ` <1@pm.me>  <2@pm.me>`, want: []string{`1@pm.me`, `2@pm.me`}},
+		{input: "<1@pm.me>\r\n <2@pm.me>", want: []string{`1@pm.me`, `2@pm.me`}},
+		{input: `<1@pm.me> (comment <3@pm.me> \) ) <2@pm.me>`, want: []string{`1@pm.me`, `2@pm.me`}},
+		{input: `Your message of "Fri, 21 Nov 1997" <1@pm.me>`, want: []string{`1@pm.me`}},
+		{input: `<1@pm.me> <2@pm.`, want: []string{`1@pm.me`}},
+		{input: `<>`, want: nil},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.input, func(t *testing.T) {
+			assert.Equal(t, test.want, ParseMessageIDList(test.input))
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package rfc5322
+
+import (
+	"mime"
+	"strings"
+)
+
+// ParseBaseSubject extracts the base subject of a subject header as defined in RFC5256 section 2.1. The base subject
+// is meant to be compared with other base subjects using the i;ascii-casemap collation.
+func ParseBaseSubject(input string) string {
+	subject, _ := ExtractBaseSubject(input)
+
+	return subject
+}
+
+// ExtractBaseSubject behaves like ParseBaseSubject but also reports whether the subject denotes a reply or a forward,
+// that is, whether a subj-refwd, subj-trailer or subj-fwd was removed during the extraction.
+func ExtractBaseSubject(input string) (string, bool) {
+	// (1) Convert any RFC 2047 encoded-words in the subject to UTF-8 and reduce all whitespace to a single space.
+	decoder := mime.WordDecoder{CharsetReader: CharsetReader}
+
+	if decoded, err := decoder.DecodeHeader(input); err == nil {
+		input = decoded
+	}
+
+	subject := strings.Join(strings.Fields(input), " ")
+
+	var isReplyOrForward bool
+
+	for {
+		// (2) Remove all trailing text of the subject that matches the subj-trailer ABNF.
+		for hasSuffixFold(subject, "(fwd)") {
+			subject = strings.TrimRight(subject[:len(subject)-len("(fwd)")], " ")
+			isReplyOrForward = true
+		}
+
+		// (3) Remove all prefix text of the subject that matches the subj-leader ABNF.
+		// (4) Remove a subj-blob prefix if it doesn't leave an empty base subject.
+		// (5) Repeat (3) and (4) until no matches remain.
+		for {
+			if strings.HasPrefix(subject, " ") {
+				subject = strings.TrimLeft(subject, " ")
+			} else if trimmed, ok := trimSubjectRefwd(subject); ok {
+				subject = trimmed
+				isReplyOrForward = true
+			} else if trimmed, ok := trimSubjectBlob(subject); ok && trimmed != "" {
+				subject = trimmed
+			} else {
+				break
+			}
+		}
+
+		// (6) If the resulting text begins with the subj-fwd-hdr ABNF and ends with the subj-fwd-trl ABNF, remove them
+		// and go back to (2).
+		if hasPrefixFold(subject, "[fwd:") && strings.HasSuffix(subject, "]") {
+			subject = strings.TrimSpace(subject[len("[fwd:") : len(subject)-len("]")])
+			isReplyOrForward = true
+
+			continue
+		}
+
+		return subject, isReplyOrForward
+	}
+}
+
+// trimSubjectRefwd removes a leading subj-leader which isn't just whitespace.
+func trimSubjectRefwd(subject string) (string, bool) {
+	// subj-leader     = (*subj-blob subj-refwd) / WSP
+	// subj-refwd      = ("re" / ("fw" ["d"])) *WSP [subj-blob] ":"
+	rest := subject
+
+	for {
+		trimmed, ok := trimSubjectBlob(rest)
+		if !ok {
+			break
+		}
+
+		rest = trimmed
+	}
+
+	switch {
+	case hasPrefixFold(rest, "re"):
+		rest = rest[len("re"):]
+
+	case hasPrefixFold(rest, "fwd"):
+		rest = rest[len("fwd"):]
+
+	case hasPrefixFold(rest, "fw"):
+		rest = rest[len("fw"):]
+
+	default:
+		return subject, false
+	}
+
+	rest = strings.TrimLeft(rest, " ")
+
+	if trimmed, ok := trimSubjectBlob(rest); ok {
+		rest = trimmed
+	}
+
+	if !strings.HasPrefix(rest, ":") {
+		return subject, false
+	}
+
+	return rest[len(":"):], true
+}
+
+// trimSubjectBlob removes a leading subj-blob.
+func trimSubjectBlob(subject string) (string, bool) {
+	// subj-blob       = "[" *BLOBCHAR "]" *WSP
+	// BLOBCHAR        = %x01-5a / %x5c / %x5e-7f
+	//                 ; any CHAR except '[' and ']'
+	if !strings.HasPrefix(subject, "[") {
+		return subject, false
+	}
+
+	end := strings.IndexAny(subject[1:], "[]")
+	if end < 0 || subject[1+end] != ']' {
+		return subject, false
+	}
+
+	return strings.TrimLeft(subject[end+2:], " "), true
+}
+
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
+
+func hasSuffixFold(s, suffix string) bool {
+	return len(s) >= len(suffix) && strings.EqualFold(s[len(s)-len(suffix):], suffix)
+}
@@ -0,0 +1,62 @@
+package rfc5322
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBaseSubject(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: `Hello`, want: `Hello`},
+		{input: `  Hello    world `, want: `Hello world`},
+		{input: `Re: Hello`, want: `Hello`},
+		{input: `RE: re: Fwd: FW: Hello`, want: `Hello`},
+		{input: `Re [2]: Hello`, want: `Hello`},
+		{input: `[list] Re: Hello`, want: `Hello`},
+		{input: `[list] [tag] Hello`, want: `Hello`},
+		{input: `[list]`, want: `[list]`},
+		{input: `Hello (fwd)`, want: `Hello`},
+		{input: `Hello (FWD) (fwd)`, want: `Hello`},
+		{input: `[Fwd: Re: Hello]`, want: `Hello`},
+		{input: `Re: [Fwd: Hello (fwd)]`, want: `Hello`},
+		{input: `Re:`, want: ``},
+		{input: `Reply to this`, want: `Reply to this`},
+		{input: `=?UTF-8?B?UmU6IEhlbGxv?=`, want: `Hello`},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.input, func(t *testing.T) {
+			assert.Equal(t, test.want, ParseBaseSubject(test.input))
+		})
+	}
+}
+
+func TestExtractBaseSubject(t *testing.T) {
+	tests := []struct {
+		input     string
+		want      string
+		wantReply bool
+	}{
+		{input: `Hello`, want: `Hello`},
+		{input: `[list] Hello`, want: `Hello`},
+		{input: `Re: Hello`, want: `Hello`, wantReply: true},
+		{input: `Hello (fwd)`, want: `Hello`, wantReply: true},
+		{input: `[Fwd: Hello]`, want: `Hello`, wantReply: true},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.input, func(t *testing.T) {
+			subject, isReply := ExtractBaseSubject(test.input)
+			assert.Equal(t, test.want, subject)
+			assert.Equal(t, test.wantReply, isReply)
+		})
+	}
+}
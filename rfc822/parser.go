@@ -7,11 +7,15 @@ import (
 	"fmt"
 	"io"
 	"mime/quotedprintable"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 )
 
-var ErrNoSuchPart = errors.New("no such parts exists")
+var (
+	ErrNoSuchPart      = errors.New("no such parts exists")
+	ErrUnknownEncoding = errors.New("unknown content transfer encoding")
+)
 
 type Section struct {
 	identifier   []int
@@ -76,15 +80,18 @@ func (section *Section) DecodedBody() ([]byte, error) {
 		return nil, err
 	}
 
-	switch header.Get("Content-Transfer-Encoding") {
+	switch strings.ToLower(strings.TrimSpace(header.Get("Content-Transfer-Encoding"))) {
 	case "base64":
 		return base64Decode(section.Body())
 
 	case "quoted-printable":
 		return quotedPrintableDecode(section.Body())
 
-	default:
+	case "", "7bit", "8bit", "binary":
 		return section.Body(), nil
+
+	default:
+		return nil, ErrUnknownEncoding
 	}
 }
 
@@ -323,6 +323,18 @@ Ym9keQ==
 	assert.Equal(t, []byte("body"), body)
 }
 
+func TestSectionDecodedBodyUnknownEncoding(t *testing.T) {
+	const literal = `From: Sender <sender@pm.me>
+To: Receiver <receiver@pm.me>
+Content-Transfer-Encoding: x-uuencode
+
+body
+`
+
+	_, err := Parse([]byte(literal)).DecodedBody()
+	require.ErrorIs(t, err, ErrUnknownEncoding)
+}
+
 func FuzzParseDec(f *testing.F) {
 	f.Add([]byte(`From: Sender <sender@pm.me>
 	To: Receiver <receiver@pm.me>
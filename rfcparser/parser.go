@@ -13,13 +13,21 @@ const DefaultContinuationMessage = "Ready"
 type Parser struct {
 	scanner               *Scanner
 	literalContinuationCb func(message string) error
+	maxNonSyncLiteralSize int
 	previousToken         Token
 	currentToken          Token
 }
 
+// ErrNonSyncLiteralTooBig is the cause of the parser errors reporting non-synchronizing literals which exceed the
+// maximum size (RFC7888).
+var ErrNonSyncLiteralTooBig = errors.New("non-synchronizing literal too big")
+
 type Error struct {
 	Token   Token
 	Message string
+
+	// Cause is the error that caused this one, if any.
+	Cause error
 }
 
 type Bytes struct {
@@ -50,6 +58,10 @@ func (p *Error) Error() string {
 	return fmt.Sprintf("[Error offset=%v]: %v", p.Token.Offset, p.Message)
 }
 
+func (p *Error) Unwrap() error {
+	return p.Cause
+}
+
 func (p *Error) IsEOF() bool {
 	return p.Token.TType == TokenTypeEOF
 }
@@ -82,6 +94,12 @@ func NewParserWithLiteralContinuationCb(s *Scanner, f func(string) error) *Parse
 		}}
 }
 
+// SetMaxNonSyncLiteralSize limits the size of non-synchronizing literals as defined in RFC7888. A value of 0 means
+// that non-synchronizing literals are only subject to the regular literal size limit.
+func (p *Parser) SetMaxNonSyncLiteralSize(size int) {
+	p.maxNonSyncLiteralSize = size
+}
+
 // ParseAString parses an astring according to RFC3501.
 func (p *Parser) ParseAString() (String, error) {
 	/*
@@ -172,15 +190,17 @@ func (p *Parser) ParseQuoted() (String, error) {
 	return String{Value: string(quoted), Offset: startOffset}, nil
 }
 
-// ParseLiteral parses a literal as defined in RFC3501.
+// ParseLiteral parses a literal as defined in RFC3501 and RFC7888.
 func (p *Parser) ParseLiteral() ([]byte, error) {
 	/*
-		literal         = "{" number "}" CRLF *CHAR8
+		literal         = "{" number ["+"] "}" CRLF *CHAR8
 	*/
 	if err := p.Consume(TokenTypeLCurly, "expected '{' for literal start"); err != nil {
 		return nil, err
 	}
 
+	literalOffset := p.previousToken.Offset
+
 	literalSize, err := p.ParseNumber()
 	if err != nil {
 		return nil, err
@@ -194,6 +214,11 @@ func (p *Parser) ParseLiteral() ([]byte, error) {
 		return nil, fmt.Errorf("literal size exceeds maximum size of 30MB")
 	}
 
+	nonSync, err := p.Matches(TokenTypePlus)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := p.Consume(TokenTypeRCurly, "expected '}' for literal end"); err != nil {
 		return nil, err
 	}
@@ -204,8 +229,8 @@ func (p *Parser) ParseLiteral() ([]byte, error) {
 
 	// Call literal continuation callback here or we risk getting stuck forever trying to read the next token
 	// in the scanner due to the byte buffers implementation as there will be no more new input until the we signal
-	// for more input.
-	if p.Check(TokenTypeLF) && p.literalContinuationCb != nil {
+	// for more input. Non-synchronizing literals are sent by the client without waiting for the continuation.
+	if !nonSync && p.Check(TokenTypeLF) && p.literalContinuationCb != nil {
 		if err := p.literalContinuationCb(DefaultContinuationMessage); err != nil {
 			return nil, fmt.Errorf("error occurred during literal continuation callback:%w", err)
 		}
@@ -215,6 +240,27 @@ func (p *Parser) ParseLiteral() ([]byte, error) {
 		return nil, err
 	}
 
+	// The client has already sent the literal, so it is only rejected once it has been skipped in order to leave
+	// the scanner at a position from which the rest of the command can be discarded.
+	if nonSync && p.maxNonSyncLiteralSize > 0 && literalSize > p.maxNonSyncLiteralSize {
+		if err := p.scanner.SkipBytes(literalSize); err != nil {
+			return nil, err
+		}
+
+		if err := p.Advance(); err != nil {
+			return nil, err
+		}
+
+		return nil, &Error{
+			Token: Token{
+				TType:  TokenTypeError,
+				Offset: literalOffset,
+			},
+			Message: fmt.Sprintf("non-synchronizing literal size exceeds maximum size of %v bytes", p.maxNonSyncLiteralSize),
+			Cause:   ErrNonSyncLiteralTooBig,
+		}
+	}
+
 	literal := make([]byte, literalSize)
 
 	if err := p.scanner.ConsumeBytes(literal); err != nil {
@@ -229,6 +275,18 @@ func (p *Parser) ParseLiteral() ([]byte, error) {
 	return literal, nil
 }
 
+// ParseLiteral8 parses a literal8 as defined in RFC3516. Unlike regular literals, it may contain NUL bytes.
+func (p *Parser) ParseLiteral8() ([]byte, error) {
+	/*
+		literal8        = "~{" number ["+"] "}" CRLF *OCTET
+	*/
+	if err := p.Consume(TokenTypeTilde, "expected '~' for literal8 start"); err != nil {
+		return nil, err
+	}
+
+	return p.ParseLiteral()
+}
+
 func (p *Parser) ParseStringAfterContinuation(continuationMessage string) (String, error) {
 	if err := p.Consume(TokenTypeCR, "expected CR"); err != nil {
 		return String{}, err
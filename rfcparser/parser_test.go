@@ -2,6 +2,7 @@ package rfcparser
 
 import (
 	"bytes"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -100,6 +101,46 @@ func TestParser_ParseLiteral(t *testing.T) {
 	}
 }
 
+func TestParser_ParseLiteral8(t *testing.T) {
+	p := newTestParser([]byte("~{5}\r\n\x00h123"))
+
+	v, err := p.ParseLiteral8()
+	require.NoError(t, err)
+	require.Equal(t, []byte("\x00h123"), v)
+}
+
+func TestParser_ParseNonSyncLiteral(t *testing.T) {
+	p := NewParserWithLiteralContinuationCb(NewScanner(bytes.NewReader([]byte("{5+}\r\n h123"))), func(string) error {
+		return fmt.Errorf("unexpected continuation")
+	})
+	require.NoError(t, p.Advance())
+
+	v, err := p.ParseLiteral()
+	require.NoError(t, err)
+	require.Equal(t, []byte(` h123`), v)
+}
+
+func TestParser_ParseNonSyncLiteralTooBig(t *testing.T) {
+	p := newTestParser([]byte("{5+}\r\n h123\r\n"))
+	p.SetMaxNonSyncLiteralSize(4)
+
+	_, err := p.ParseLiteral()
+	require.Error(t, err)
+	require.True(t, IsError(err))
+	require.ErrorIs(t, err, ErrNonSyncLiteralTooBig)
+
+	// The literal has been consumed.
+	require.True(t, p.Check(TokenTypeCR))
+
+	// Synchronizing literals are not affected by the limit.
+	p = newTestParser([]byte("{5}\r\n h123"))
+	p.SetMaxNonSyncLiteralSize(4)
+
+	v, err := p.ParseLiteral()
+	require.NoError(t, err)
+	require.Equal(t, []byte(` h123`), v)
+}
+
 func TestParser_ParseAString(t *testing.T) {
 	values := map[string]string{
 		"{5}\r\n h123":         ` h123`,
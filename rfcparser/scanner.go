@@ -104,6 +104,23 @@ func (s *Scanner) ConsumeBytes(dst []byte) error {
 	return nil
 }
 
+// SkipBytes discards the given number of bytes, like ConsumeBytes but without keeping them.
+func (s *Scanner) SkipBytes(n int) error {
+	// We have already read a byte at this point, so we need to
+	// skip this one.
+	if _, err := io.CopyN(io.Discard, s.source, int64(n-1)); err != nil {
+		if errors.Is(err, io.EOF) {
+			return io.EOF
+		}
+
+		return err
+	}
+
+	s.offset += n - 1
+
+	return nil
+}
+
 func (s *Scanner) ConsumeUntilNewLine() ([]byte, error) {
 	return s.source.ReadBytes('\n')
 }
@@ -89,6 +89,12 @@ type Server struct {
 	// disableIMAPAuthenticate disables the IMAP AUTHENTICATE command (client can then only authenticate using LOGIN).
 	disableIMAPAuthenticate bool
 
+	// compression indicates whether clients may compress the connection with COMPRESS=DEFLATE.
+	compression bool
+
+	// imap4rev2 indicates whether clients may switch to IMAP4rev2 with ENABLE.
+	imap4rev2 bool
+
 	uidValidityGenerator imap.UIDValidityGenerator
 
 	panicHandler async.PanicHandler
@@ -302,6 +308,14 @@ func (s *Server) addSession(ctx context.Context, conn net.Conn) (*session.Sessio
 		s.sessions[nextID].SetTLSConfig(s.tlsConfig)
 	}
 
+	if s.compression {
+		s.sessions[nextID].EnableCompression()
+	}
+
+	if s.imap4rev2 {
+		s.sessions[nextID].EnableIMAP4rev2()
+	}
+
 	if s.inLogger != nil {
 		s.sessions[nextID].SetIncomingLogger(s.inLogger)
 	}
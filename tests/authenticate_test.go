@@ -73,7 +73,7 @@ func TestAuthenticateCapabilities(t *testing.T) {
 		c.C("A001 AUTHENTICATE PLAIN")
 		c.S("+")
 		c.C(base64AuthString("user", "pass"))
-		c.S(`A001 OK [CAPABILITY AUTH=PLAIN ID IDLE IMAP4rev1 MOVE STARTTLS UIDPLUS UNSELECT] Logged in`)
+		c.S(`A001 OK [CAPABILITY AUTH=PLAIN BINARY CATENATE CONDSTORE CREATE-SPECIAL-USE ENABLE ESEARCH ID IDLE IMAP4rev1 LIST-EXTENDED LIST-STATUS LITERAL+ METADATA MOVE MULTIAPPEND NAMESPACE NOTIFY OBJECTID PARTIAL PREVIEW QRESYNC QUOTA QUOTA=RES-MESSAGE QUOTA=RES-STORAGE REPLACE SAVEDATE SEARCHRES SORT SPECIAL-USE STARTTLS STATUS=SIZE THREAD=ORDEREDSUBJECT THREAD=REFERENCES UIDONLY UIDPLUS UNSELECT URLAUTH UTF8=ACCEPT WITHIN] Logged in`)
 	})
 }
 
@@ -0,0 +1,69 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFetchBinary(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
+		c.doAppendFromFile(`INBOX`, `testdata/multipart-mixed.eml`).expect("OK")
+
+		c.C(`A001 SELECT INBOX`)
+		c.Se(`A001 OK [READ-WRITE] SELECT`)
+
+		// The base64 attachment is returned decoded.
+		c.C(`A002 FETCH 1 (BINARY.PEEK[2])`)
+		c.S("* 1 FETCH (BINARY[2] {22}\r\nthis is my attachment\n)")
+		c.OK(`A002`)
+
+		c.C(`A003 FETCH 1 (BINARY.SIZE[2] BINARY.SIZE[1.1])`)
+		c.S(`* 1 FETCH (BINARY.SIZE[2] 22 BINARY.SIZE[1.1] 25)`)
+		c.OK(`A003`)
+
+		c.C(`A004 FETCH 1 (BINARY.PEEK[2]<5.2>)`)
+		c.S("* 1 FETCH (BINARY[2]<5> {2}\r\nis)")
+		c.OK(`A004`)
+
+		// Fetching without PEEK sets the seen flag.
+		c.C(`A005 FETCH 1 (BINARY[1.1])`)
+		c.S(lines(`* 1 FETCH (BINARY[1.1] {25}`,
+			`*this */is**/_html_`,
+			`**`,
+			` FLAGS (\Recent \Seen))`,
+		))
+		c.OK(`A005`)
+
+		c.C(`A006 FETCH 1 (BINARY[5])`)
+		c.NO(`A006`)
+	})
+}
+
+func TestFetchBinaryUnknownEncoding(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
+		c.doAppend(`INBOX`, buildRFC5322TestLiteral("To: 1@pm.me\r\nContent-Transfer-Encoding: x-foo")).expect("OK")
+
+		c.C(`A001 SELECT INBOX`)
+		c.Se(`A001 OK [READ-WRITE] SELECT`)
+
+		c.C(`A002 FETCH 1 (BINARY.PEEK[1])`)
+		c.Sx(`A002 NO \[UNKNOWN-CTE\]`)
+	})
+}
+
+func TestAppendLiteral8(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
+		literal := buildRFC5322TestLiteral("To: 1@pm.me\r\nContent-Transfer-Encoding: binary\r\n\r\na\x00b")
+
+		c.C(fmt.Sprintf("A001 APPEND INBOX ~{%v+}\r\n%v", len(literal), literal))
+		c.Sx(`A001 OK \[APPENDUID \d+ 1\] APPEND`)
+
+		c.C(`A002 SELECT INBOX`)
+		c.Se(`A002 OK [READ-WRITE] SELECT`)
+
+		// Content with NUL bytes is returned as a literal8.
+		c.C(`A003 FETCH 1 (BINARY.PEEK[1])`)
+		c.S("* 1 FETCH (BINARY[1] ~{3}\r\na\x00b)")
+		c.OK(`A003`)
+	})
+}
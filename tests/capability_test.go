@@ -7,14 +7,14 @@ import (
 func TestCapability(t *testing.T) {
 	runOneToOneTest(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
 		c.C("A001 Capability")
-		c.S(`* CAPABILITY AUTH=PLAIN ID IDLE IMAP4rev1 STARTTLS`)
+		c.S(`* CAPABILITY AUTH=PLAIN ID IDLE IMAP4rev1 LITERAL+ STARTTLS`)
 		c.S("A001 OK CAPABILITY")
 
 		c.C(`A002 login "user" "pass"`)
-		c.S(`A002 OK [CAPABILITY AUTH=PLAIN ID IDLE IMAP4rev1 MOVE STARTTLS UIDPLUS UNSELECT] Logged in`)
+		c.S(`A002 OK [CAPABILITY AUTH=PLAIN BINARY CATENATE CONDSTORE CREATE-SPECIAL-USE ENABLE ESEARCH ID IDLE IMAP4rev1 LIST-EXTENDED LIST-STATUS LITERAL+ METADATA MOVE MULTIAPPEND NAMESPACE NOTIFY OBJECTID PARTIAL PREVIEW QRESYNC QUOTA QUOTA=RES-MESSAGE QUOTA=RES-STORAGE REPLACE SAVEDATE SEARCHRES SORT SPECIAL-USE STARTTLS STATUS=SIZE THREAD=ORDEREDSUBJECT THREAD=REFERENCES UIDONLY UIDPLUS UNSELECT URLAUTH UTF8=ACCEPT WITHIN] Logged in`)
 
 		c.C("A003 Capability")
-		c.S(`* CAPABILITY AUTH=PLAIN ID IDLE IMAP4rev1 MOVE STARTTLS UIDPLUS UNSELECT`)
+		c.S(`* CAPABILITY AUTH=PLAIN BINARY CATENATE CONDSTORE CREATE-SPECIAL-USE ENABLE ESEARCH ID IDLE IMAP4rev1 LIST-EXTENDED LIST-STATUS LITERAL+ METADATA MOVE MULTIAPPEND NAMESPACE NOTIFY OBJECTID PARTIAL PREVIEW QRESYNC QUOTA QUOTA=RES-MESSAGE QUOTA=RES-STORAGE REPLACE SAVEDATE SEARCHRES SORT SPECIAL-USE STARTTLS STATUS=SIZE THREAD=ORDEREDSUBJECT THREAD=REFERENCES UIDONLY UIDPLUS UNSELECT URLAUTH UTF8=ACCEPT WITHIN`)
 		c.S("A003 OK CAPABILITY")
 	})
 }
@@ -22,14 +22,14 @@ func TestCapability(t *testing.T) {
 func TestCapabilityAuthenticateDisabled(t *testing.T) {
 	runOneToOneTest(t, defaultServerOptions(t, withDisableIMAPAuthenticate()), func(c *testConnection, _ *testSession) {
 		c.C("A001 Capability")
-		c.S(`* CAPABILITY ID IDLE IMAP4rev1 STARTTLS`)
+		c.S(`* CAPABILITY ID IDLE IMAP4rev1 LITERAL+ STARTTLS`)
 		c.S("A001 OK CAPABILITY")
 
 		c.C(`A002 login "user" "pass"`)
-		c.S(`A002 OK [CAPABILITY ID IDLE IMAP4rev1 MOVE STARTTLS UIDPLUS UNSELECT] Logged in`)
+		c.S(`A002 OK [CAPABILITY BINARY CATENATE CONDSTORE CREATE-SPECIAL-USE ENABLE ESEARCH ID IDLE IMAP4rev1 LIST-EXTENDED LIST-STATUS LITERAL+ METADATA MOVE MULTIAPPEND NAMESPACE NOTIFY OBJECTID PARTIAL PREVIEW QRESYNC QUOTA QUOTA=RES-MESSAGE QUOTA=RES-STORAGE REPLACE SAVEDATE SEARCHRES SORT SPECIAL-USE STARTTLS STATUS=SIZE THREAD=ORDEREDSUBJECT THREAD=REFERENCES UIDONLY UIDPLUS UNSELECT URLAUTH UTF8=ACCEPT WITHIN] Logged in`)
 
 		c.C("A003 Capability")
-		c.S(`* CAPABILITY ID IDLE IMAP4rev1 MOVE STARTTLS UIDPLUS UNSELECT`)
+		c.S(`* CAPABILITY BINARY CATENATE CONDSTORE CREATE-SPECIAL-USE ENABLE ESEARCH ID IDLE IMAP4rev1 LIST-EXTENDED LIST-STATUS LITERAL+ METADATA MOVE MULTIAPPEND NAMESPACE NOTIFY OBJECTID PARTIAL PREVIEW QRESYNC QUOTA QUOTA=RES-MESSAGE QUOTA=RES-STORAGE REPLACE SAVEDATE SEARCHRES SORT SPECIAL-USE STARTTLS STATUS=SIZE THREAD=ORDEREDSUBJECT THREAD=REFERENCES UIDONLY UIDPLUS UNSELECT URLAUTH UTF8=ACCEPT WITHIN`)
 		c.S("A003 OK CAPABILITY")
 	})
 }
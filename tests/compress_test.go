@@ -0,0 +1,56 @@
+package tests
+
+import (
+	"testing"
+)
+
+func TestCompressDeflate(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t, withCompression()), func(c *testConnection, _ *testSession) {
+		c.C(`A001 CAPABILITY`)
+		c.Sx(`^\* CAPABILITY .*COMPRESS=DEFLATE.*`)
+		c.OK(`A001`)
+
+		c.C(`A002 COMPRESS DEFLATE`)
+		c.S(`A002 OK DEFLATE active`)
+
+		c.compressConnection()
+
+		c.doAppend(`INBOX`, buildRFC5322TestLiteral(`To: 1@pm.me`)).expect("OK")
+
+		c.C(`A003 SELECT INBOX`)
+		c.Se(`* 1 EXISTS`)
+		c.OK(`A003`)
+
+		c.C(`A004 FETCH 1 (BODY.PEEK[HEADER.FIELDS (TO)])`)
+		c.Sx(`^\* 1 FETCH \(BODY\[HEADER.FIELDS \(TO\)\] \{\d+\}\r\nTo: 1@pm.me`)
+		c.OK(`A004`)
+
+		// Compression can only be enabled once.
+		c.C(`A005 COMPRESS DEFLATE`)
+		c.S(`A005 NO [COMPRESSIONACTIVE] compression is already active`)
+
+		// The compressed stream is ended before the connection is closed.
+		c.C(`A006 LOGOUT`)
+		c.S(`* BYE`)
+		c.Sx(`A006 OK LOGOUT`)
+		c.expectClosed()
+	})
+}
+
+func TestCompressDeflateNotAuthenticated(t *testing.T) {
+	runOneToOneTest(t, defaultServerOptions(t, withCompression()), func(c *testConnection, _ *testSession) {
+		c.C(`A001 COMPRESS DEFLATE`).NO(`A001`)
+
+		c.C(`A002 COMPRESS LZ4`).BAD(`A002`)
+
+		c.C(`A003 LOGIN user pass`).OK(`A003`)
+	})
+}
+
+func TestCompressDeflateUnavailable(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
+		c.C(`A001 COMPRESS DEFLATE`).BAD(`A001`)
+
+		c.C(`A002 NOOP`).OK(`A002`)
+	})
+}
@@ -0,0 +1,178 @@
+package tests
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCondStoreSelect(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
+		c.C("A001 SELECT INBOX (CONDSTORE)")
+		c.Sxe(`\* OK \[HIGHESTMODSEQ \d+\] Highest`)
+		c.OK("A001")
+
+		c.C("A002 EXAMINE INBOX (CONDSTORE)")
+		c.Sxe(`\* OK \[HIGHESTMODSEQ \d+\]`)
+		c.OK("A002")
+
+		c.C("A003 STATUS INBOX (HIGHESTMODSEQ)")
+		c.Sx(`\* STATUS "INBOX" \(HIGHESTMODSEQ \d+\)`)
+		c.OK("A003")
+
+		c.C("A004 SELECT INBOX (FOO)").BAD("A004")
+	})
+}
+
+func TestCondStoreFetch(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
+		c.C("A001 CREATE saved-messages")
+		c.Sx(`^A001 OK \[MAILBOXID \(\S+\)\] CREATE`)
+
+		c.doAppend(`saved-messages`, buildRFC5322TestLiteral(`To: 1@pm.me`)).expect("OK")
+		c.doAppend(`saved-messages`, buildRFC5322TestLiteral(`To: 2@pm.me`)).expect("OK")
+		c.doAppend(`saved-messages`, buildRFC5322TestLiteral(`To: 3@pm.me`)).expect("OK")
+
+		c.C(`A002 SELECT saved-messages`)
+		c.Se(`A002 OK [READ-WRITE] SELECT`)
+
+		// Fetching MODSEQ enables CONDSTORE.
+		c.C(`A003 FETCH 3 (MODSEQ)`)
+		modSeq := readModSeq(t, c, `\* 3 FETCH \(MODSEQ \((\d+)\)\)`)
+		c.OK(`A003`)
+
+		// Nothing changed since the last appended message.
+		c.C(fmt.Sprintf(`A004 FETCH 1:* (FLAGS) (CHANGEDSINCE %v)`, modSeq))
+		c.OK(`A004`)
+
+		// Only the modified message is returned.
+		c.C(`A005 STORE 2 +FLAGS (\Flagged)`)
+		c.Sx(`\* 2 FETCH \(FLAGS \(\\Flagged \\Recent\) MODSEQ \(\d+\)\)`)
+		c.OK(`A005`)
+
+		c.C(fmt.Sprintf(`A006 FETCH 1:* (FLAGS) (CHANGEDSINCE %v)`, modSeq))
+		c.Sx(`\* 2 FETCH \(FLAGS \(\\Flagged \\Recent\) MODSEQ \(\d+\)\)`)
+		c.OK(`A006`)
+
+		c.C(fmt.Sprintf(`A007 UID FETCH 1:* (FLAGS) (CHANGEDSINCE %v)`, modSeq))
+		c.Sx(`\* 2 FETCH \(FLAGS \(\\Flagged \\Recent\) MODSEQ \(\d+\) UID 2\)`)
+		c.OK(`A007`)
+	})
+}
+
+func TestCondStoreStoreUnchangedSince(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
+		c.C("A001 CREATE saved-messages")
+		c.Sx(`^A001 OK \[MAILBOXID \(\S+\)\] CREATE`)
+
+		c.doAppend(`saved-messages`, buildRFC5322TestLiteral(`To: 1@pm.me`)).expect("OK")
+		c.doAppend(`saved-messages`, buildRFC5322TestLiteral(`To: 2@pm.me`)).expect("OK")
+
+		c.C(`A002 SELECT saved-messages (CONDSTORE)`)
+		c.Se(`A002 OK [READ-WRITE] SELECT`)
+
+		c.C(`A003 FETCH 1 (MODSEQ)`)
+		modSeq := readModSeq(t, c, `\* 1 FETCH \(MODSEQ \((\d+)\)\)`)
+		c.OK(`A003`)
+
+		// The second message was modified after the first one, so only the first one is updated.
+		c.C(fmt.Sprintf(`A004 STORE 1:2 (UNCHANGEDSINCE %v) +FLAGS (\Seen)`, modSeq))
+		c.Sx(`\* 1 FETCH \(FLAGS \(\\Recent \\Seen\) MODSEQ \(\d+\)\)`)
+		c.S(`A004 OK [MODIFIED 2] Conditional STORE failed`)
+
+		c.C(`A005 UID STORE 1:2 (UNCHANGEDSINCE 0) +FLAGS (\Flagged)`)
+		c.S(`A005 OK [MODIFIED 1:2] Conditional STORE failed`)
+
+		c.C(`A006 FETCH 1:2 (FLAGS)`)
+		c.Sx(
+			`\* 1 FETCH \(FLAGS \(\\Recent \\Seen\) MODSEQ \(\d+\)\)`,
+			`\* 2 FETCH \(FLAGS \(\\Recent\) MODSEQ \(\d+\)\)`,
+		)
+		c.OK(`A006`)
+	})
+}
+
+func TestCondStoreSearchModSeq(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
+		c.C("A001 CREATE saved-messages")
+		c.Sx(`^A001 OK \[MAILBOXID \(\S+\)\] CREATE`)
+
+		c.doAppend(`saved-messages`, buildRFC5322TestLiteral(`To: 1@pm.me`)).expect("OK")
+		c.doAppend(`saved-messages`, buildRFC5322TestLiteral(`To: 2@pm.me`)).expect("OK")
+
+		c.C(`A002 SELECT saved-messages`)
+		c.Se(`A002 OK [READ-WRITE] SELECT`)
+
+		c.C(`A003 FETCH 2 (MODSEQ)`)
+		modSeq := readModSeq(t, c, `\* 2 FETCH \(MODSEQ \((\d+)\)\)`)
+		c.OK(`A003`)
+
+		c.C(`A004 SEARCH MODSEQ 1`)
+		c.S(`* SEARCH 1 2 (MODSEQ ` + strconv.FormatUint(modSeq, 10) + `)`)
+		c.OK(`A004`)
+
+		c.C(fmt.Sprintf(`A005 SEARCH MODSEQ %v`, modSeq))
+		c.S(`* SEARCH 2 (MODSEQ ` + strconv.FormatUint(modSeq, 10) + `)`)
+		c.OK(`A005`)
+
+		c.C(fmt.Sprintf(`A006 SEARCH MODSEQ %v`, modSeq+1))
+		c.S(`* SEARCH`)
+		c.OK(`A006`)
+	})
+}
+
+func TestCondStoreDeletedFlag(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
+		c.C("A001 CREATE Other")
+		c.Sx(`^A001 OK \[MAILBOXID \(\S+\)\] CREATE`)
+
+		c.doAppend(`INBOX`, buildRFC5322TestLiteral(`To: 1@pm.me`)).expect("OK")
+
+		c.C(`A002 SELECT INBOX`)
+		c.Se(`A002 OK [READ-WRITE] SELECT`)
+
+		c.C(`A003 COPY 1 Other`)
+		c.Sx(`A003 OK`)
+
+		c.C(`A004 STATUS Other (HIGHESTMODSEQ)`)
+		highestModSeq := readModSeq(t, c, `\* STATUS "Other" \(HIGHESTMODSEQ (\d+)\)`)
+		c.OK(`A004`)
+
+		c.C(`A005 FETCH 1 (MODSEQ)`)
+		modSeq := readModSeq(t, c, `\* 1 FETCH \(MODSEQ \((\d+)\)\)`)
+		c.OK(`A005`)
+
+		c.C(`A006 STORE 1 +FLAGS (\Deleted)`)
+		require.Greater(t, readModSeq(t, c, `\* 1 FETCH \(FLAGS \(\\Deleted \\Recent\) MODSEQ \((\d+)\)\)`), modSeq)
+		c.OK(`A006`)
+
+		// The \Deleted flag only applies to the message in this mailbox, so it is unchanged in the other one.
+		c.C(`A007 STATUS Other (HIGHESTMODSEQ)`)
+		c.S(fmt.Sprintf(`* STATUS "Other" (HIGHESTMODSEQ %v)`, highestModSeq))
+		c.OK(`A007`)
+
+		c.C(`A008 SELECT Other`)
+		c.Se(`A008 OK [READ-WRITE] SELECT`)
+
+		c.C(`A009 FETCH 1 (MODSEQ)`)
+		c.S(fmt.Sprintf(`* 1 FETCH (MODSEQ (%v))`, modSeq))
+		c.OK(`A009`)
+
+		c.C(fmt.Sprintf(`A010 SEARCH MODSEQ %v`, modSeq+1))
+		c.S(`* SEARCH`)
+		c.OK(`A010`)
+	})
+}
+
+func readModSeq(t *testing.T, c *testConnection, pattern string) uint64 {
+	match := regexp.MustCompile(pattern).FindSubmatch(c.read())
+	require.Len(t, match, 2)
+
+	modSeq, err := strconv.ParseUint(string(match[1]), 10, 64)
+	require.NoError(t, err)
+
+	return modSeq
+}
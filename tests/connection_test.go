@@ -2,6 +2,7 @@ package tests
 
 import (
 	"bytes"
+	"compress/flate"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
@@ -233,6 +234,37 @@ func (s *testConnection) expectClosed() {
 	require.ErrorIs(s.tb, err, io.EOF)
 }
 
+// compressConnection switches the connection to raw DEFLATE streams as defined in RFC4978.
+func (s *testConnection) compressConnection() {
+	writer, err := flate.NewWriter(s.conn, flate.DefaultCompression)
+	require.NoError(s.tb, err)
+
+	conn := &testCompressConn{Conn: s.conn, reader: flate.NewReader(s.conn), writer: writer}
+
+	s.conn = conn
+	s.liner = liner.New(conn)
+}
+
+type testCompressConn struct {
+	net.Conn
+
+	reader io.Reader
+	writer *flate.Writer
+}
+
+func (c *testCompressConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
+
+func (c *testCompressConn) Write(b []byte) (int, error) {
+	n, err := c.writer.Write(b)
+	if err != nil {
+		return n, err
+	}
+
+	return n, c.writer.Flush()
+}
+
 func (s *testConnection) upgradeConnection() {
 	cert, err := x509.ParseCertificate(testCert.Certificate[0])
 	require.NoError(s.tb, err)
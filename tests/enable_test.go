@@ -0,0 +1,40 @@
+package tests
+
+import (
+	"testing"
+)
+
+func TestEnable(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
+		c.C("A001 ENABLE CONDSTORE")
+		c.S("* ENABLED CONDSTORE")
+		c.OK("A001")
+
+		// Extensions which are already enabled or unknown are not listed.
+		c.C("A002 ENABLE CONDSTORE QRESYNC X-UNKNOWN")
+		c.S("* ENABLED QRESYNC")
+		c.OK("A002")
+
+		// Enabled extensions change the responses sent by the server.
+		c.C("A003 SELECT INBOX")
+		c.Sxe(`\* OK \[HIGHESTMODSEQ \d+\] Highest`)
+		c.OK("A003")
+
+		c.C("A004 ENABLE").BAD("A004")
+	})
+}
+
+func TestEnableNotAuthenticated(t *testing.T) {
+	runOneToOneTest(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
+		c.C("A001 ENABLE CONDSTORE").NO("A001")
+	})
+}
+
+func TestEnableNotAdvertisedExtension(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
+		// Capabilities which don't need to be enabled are ignored.
+		c.C("A001 ENABLE IDLE MOVE")
+		c.S("* ENABLED")
+		c.OK("A001")
+	})
+}
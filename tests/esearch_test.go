@@ -0,0 +1,95 @@
+package tests
+
+import (
+	"testing"
+)
+
+func TestESearch(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
+		c.doAppend(`INBOX`, buildRFC5322TestLiteral(`To: 1@pm.me`)).expect("OK")
+		c.doAppend(`INBOX`, buildRFC5322TestLiteral(`To: 2@pm.me`)).expect("OK")
+		c.doAppend(`INBOX`, buildRFC5322TestLiteral(`To: 3@pm.me`)).expect("OK")
+		c.doAppend(`INBOX`, buildRFC5322TestLiteral(`To: 4@pm.me`)).expect("OK")
+
+		c.C(`A001 SELECT INBOX`)
+		c.Se(`A001 OK [READ-WRITE] SELECT`)
+
+		c.C(`A002 SEARCH RETURN (MIN MAX COUNT) ALL`)
+		c.S(`* ESEARCH (TAG "A002") MIN 1 MAX 4 COUNT 4`)
+		c.OK(`A002`)
+
+		// An empty list of options is the same as ALL.
+		c.C(`A003 SEARCH RETURN () NOT 3`)
+		c.S(`* ESEARCH (TAG "A003") ALL 1:2,4`)
+		c.OK(`A003`)
+
+		c.C(`A004 UID SEARCH RETURN (ALL) 2:3`)
+		c.S(`* ESEARCH (TAG "A004") UID ALL 2:3`)
+		c.OK(`A004`)
+
+		// Only COUNT is returned if nothing matches.
+		c.C(`A005 SEARCH RETURN (MIN COUNT) TO nobody`)
+		c.S(`* ESEARCH (TAG "A005") COUNT 0`)
+		c.OK(`A005`)
+
+		c.C(`A006 SEARCH RETURN (FOO) ALL`).BAD(`A006`)
+	})
+}
+
+func TestSearchRes(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
+		c.doAppend(`INBOX`, buildRFC5322TestLiteral(`To: 1@pm.me`)).expect("OK")
+		c.doAppend(`INBOX`, buildRFC5322TestLiteral(`To: 2@pm.me`)).expect("OK")
+		c.doAppend(`INBOX`, buildRFC5322TestLiteral(`To: 3@pm.me`)).expect("OK")
+
+		c.C(`A001 SELECT INBOX`)
+		c.Se(`A001 OK [READ-WRITE] SELECT`)
+
+		// Nothing is returned if the result is only saved.
+		c.C(`A002 SEARCH RETURN (SAVE) 2:3`)
+		c.OK(`A002`)
+
+		c.C(`A003 FETCH $ (UID)`)
+		c.S(
+			`* 2 FETCH (UID 2)`,
+			`* 3 FETCH (UID 3)`,
+		)
+		c.OK(`A003`)
+
+		c.C(`A004 STORE $ +FLAGS.SILENT (\Deleted)`)
+		c.OK(`A004`)
+
+		c.C(`A005 SEARCH $ DELETED`)
+		c.S(`* SEARCH 2 3`)
+		c.OK(`A005`)
+
+		// Expunged messages are removed from the saved result.
+		c.C(`A006 UID EXPUNGE 3`)
+		c.S(`* 3 EXPUNGE`)
+		c.OK(`A006`)
+
+		c.C(`A007 UID SEARCH UID $`)
+		c.S(`* SEARCH 2`)
+		c.OK(`A007`)
+
+		// Only the minimum is saved if it is the only other option.
+		c.C(`A008 SEARCH RETURN (MIN SAVE) ALL`)
+		c.S(`* ESEARCH (TAG "A008") MIN 1`)
+		c.OK(`A008`)
+
+		c.C(`A009 UID FETCH $ (FLAGS)`)
+		c.S(`* 1 FETCH (FLAGS (\Recent) UID 1)`)
+		c.OK(`A009`)
+
+		// The saved result is cleared when the mailbox is closed.
+		c.C(`A010 UNSELECT`)
+		c.OK(`A010`)
+
+		c.C(`A011 SELECT INBOX`)
+		c.Se(`A011 OK [READ-WRITE] SELECT`)
+
+		c.C(`A012 SEARCH $`)
+		c.S(`* SEARCH`)
+		c.OK(`A012`)
+	})
+}
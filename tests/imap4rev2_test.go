@@ -0,0 +1,123 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestIMAP4rev2Capability(t *testing.T) {
+	runOneToOneTest(t, defaultServerOptions(t, withIMAP4rev2()), func(c *testConnection, _ *testSession) {
+		c.C(`A001 CAPABILITY`)
+		c.S(`* CAPABILITY AUTH=PLAIN ID IDLE IMAP4rev1 IMAP4rev2 LITERAL+ STARTTLS`)
+		c.OK(`A001`)
+
+		c.C(`A002 LOGIN user pass`)
+		c.Sx(`A002 OK \[CAPABILITY .* IMAP4rev1 IMAP4rev2 .*\] Logged in`)
+
+		c.C(`A003 ENABLE IMAP4rev2`)
+		c.S(`* ENABLED IMAP4rev2`)
+		c.OK(`A003`)
+	})
+}
+
+func TestIMAP4rev2NotAdvertised(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
+		c.C(`A001 ENABLE IMAP4rev2`)
+		c.S(`* ENABLED`)
+		c.OK(`A001`)
+	})
+}
+
+func TestIMAP4rev2NoRecent(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t, withIMAP4rev2()), func(c *testConnection, _ *testSession) {
+		c.doAppend(`INBOX`, buildRFC5322TestLiteral(`To: 1@pm.me`)).expect("OK")
+
+		c.C(`A001 ENABLE IMAP4rev2`)
+		c.S(`* ENABLED IMAP4rev2`)
+		c.OK(`A001`)
+
+		// Neither the RECENT response nor the UNSEEN response code are sent.
+		c.C(`A002 SELECT INBOX`)
+		c.S(
+			`* FLAGS (\Deleted \Flagged \Seen)`,
+			`* 1 EXISTS`,
+		)
+		c.Sx(`\* OK \[PERMANENTFLAGS .*\] Flags permitted`)
+		c.Sx(`\* OK \[UIDNEXT 2\] Predicted next UID`)
+		c.Sx(`\* OK \[UIDVALIDITY \d+\] UIDs valid`)
+		c.S(`* OK [MAILBOXID (0)] Mailbox ID`)
+		c.S(`A002 OK [READ-WRITE] SELECT`)
+
+		c.C(`A003 FETCH 1 (FLAGS)`)
+		c.S(`* 1 FETCH (FLAGS ())`)
+		c.OK(`A003`)
+
+		c.C(`A004 STORE 1 +FLAGS (\Seen)`)
+		c.S(`* 1 FETCH (FLAGS (\Seen))`)
+		c.OK(`A004`)
+
+		// Messages appended by the session are reported without the RECENT response.
+		literal := buildRFC5322TestLiteral(`To: 2@pm.me`)
+
+		c.C(fmt.Sprintf("A005 APPEND INBOX {%v+}\r\n%v", len(literal), literal))
+		c.S(`* 2 EXISTS`)
+		c.Sx(`A005 OK \[APPENDUID \d+ 2\] APPEND`)
+	})
+}
+
+func TestIMAP4rev2Search(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t, withIMAP4rev2()), func(c *testConnection, _ *testSession) {
+		c.doAppend(`INBOX`, buildRFC5322TestLiteral(`To: 1@pm.me`)).expect("OK")
+		c.doAppend(`INBOX`, buildRFC5322TestLiteral(`To: 2@pm.me`)).expect("OK")
+
+		c.C(`A001 ENABLE IMAP4rev2`)
+		c.S(`* ENABLED IMAP4rev2`)
+		c.OK(`A001`)
+
+		c.C(`A002 SELECT INBOX`)
+		c.Se(`A002 OK [READ-WRITE] SELECT`)
+
+		// Searches without result options return ESEARCH responses listing all the matching messages.
+		c.C(`A003 SEARCH ALL`)
+		c.S(`* ESEARCH (TAG "A003") ALL 1:2`)
+		c.OK(`A003`)
+
+		c.C(`A004 UID SEARCH TO 2@pm.me`)
+		c.S(`* ESEARCH (TAG "A004") UID ALL 2`)
+		c.OK(`A004`)
+
+		c.C(`A005 SEARCH RETURN (COUNT) ALL`)
+		c.S(`* ESEARCH (TAG "A005") COUNT 2`)
+		c.OK(`A005`)
+	})
+}
+
+func TestIMAP4rev2MailboxNames(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t, withIMAP4rev2()), func(c *testConnection, _ *testSession) {
+		c.C(`A001 CREATE "Brouillons-&AOk-t&AOk-"`)
+		c.OK(`A001`)
+
+		c.C(`A002 ENABLE IMAP4rev2`)
+		c.S(`* ENABLED IMAP4rev2`)
+		c.OK(`A002`)
+
+		// Mailbox names are exchanged in UTF-8 and listed with their subscription status.
+		c.C(`A003 LIST "" "Brouillons-été"`)
+		c.S(`* LIST (\Subscribed \Unmarked) "/" "Brouillons-été"`)
+		c.OK(`A003`)
+
+		c.C(`A004 CREATE "Entwürfe"`)
+		c.OK(`A004`)
+
+		c.C(`A005 UNSUBSCRIBE "Entwürfe"`)
+		c.OK(`A005`)
+
+		c.C(`A006 LIST "" "Entwürfe"`)
+		c.S(`* LIST (\Unmarked) "/" "Entwürfe"`)
+		c.OK(`A006`)
+
+		c.C(`A007 STATUS "Entwürfe" (MESSAGES)`)
+		c.S(`* STATUS "Entwürfe" (MESSAGES 0)`)
+		c.OK(`A007`)
+	})
+}
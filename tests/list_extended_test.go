@@ -0,0 +1,101 @@
+package tests
+
+import (
+	"testing"
+)
+
+func TestListExtendedSubscribed(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t, withDelimiter(".")), func(c *testConnection, _ *testSession) {
+		c.C(`tag CREATE foo.bar`).OK(`tag`)
+		c.C(`tag CREATE baz`).OK(`tag`)
+		c.C(`tag UNSUBSCRIBE foo`).OK(`tag`)
+		c.C(`tag UNSUBSCRIBE baz`).OK(`tag`)
+
+		c.C(`A001 LIST (SUBSCRIBED) "" "*"`)
+		c.S(
+			`* LIST (\Subscribed \Unmarked) "." "INBOX"`,
+			`* LIST (\Subscribed \Unmarked) "." "foo.bar"`,
+		)
+		c.OK(`A001`)
+
+		// Unsubscribed superiors of subscribed mailboxes are listed with RECURSIVEMATCH.
+		c.C(`A002 LIST (SUBSCRIBED RECURSIVEMATCH) "" "%"`)
+		c.S(
+			`* LIST (\Subscribed \Unmarked) "." "INBOX"`,
+			`* LIST (\Unmarked) "." "foo" ("CHILDINFO" ("SUBSCRIBED"))`,
+		)
+		c.OK(`A002`)
+
+		// Deleted mailboxes which are still subscribed don't exist anymore.
+		c.C(`tag DELETE foo.bar`).OK(`tag`)
+
+		c.C(`A003 LIST (SUBSCRIBED) "" "*"`)
+		c.S(
+			`* LIST (\Subscribed \Unmarked) "." "INBOX"`,
+			`* LIST (\NonExistent \Subscribed) "." "foo.bar"`,
+		)
+		c.OK(`A003`)
+
+		c.C(`A004 LIST (RECURSIVEMATCH) "" "*"`).BAD(`A004`)
+
+		// All the mailboxes are local, so REMOTE selects the same ones.
+		c.C(`A005 LIST (SUBSCRIBED REMOTE) "" "*"`)
+		c.S(
+			`* LIST (\Subscribed \Unmarked) "." "INBOX"`,
+			`* LIST (\NonExistent \Subscribed) "." "foo.bar"`,
+		)
+		c.OK(`A005`)
+
+		c.C(`A006 LIST (REMOTE) "" "%"`)
+		c.S(
+			`* LIST (\Unmarked) "." "INBOX"`,
+			`* LIST (\Unmarked) "." "foo"`,
+			`* LIST (\Unmarked) "." "baz"`,
+		)
+		c.OK(`A006`)
+	})
+}
+
+func TestListExtendedReturnOptions(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t, withDelimiter(".")), func(c *testConnection, _ *testSession) {
+		c.C(`tag CREATE foo.bar`).OK(`tag`)
+		c.C(`tag UNSUBSCRIBE foo`).OK(`tag`)
+
+		c.C(`A001 LIST "" "*" RETURN (CHILDREN SUBSCRIBED)`)
+		c.S(
+			`* LIST (\HasNoChildren \Subscribed \Unmarked) "." "INBOX"`,
+			`* LIST (\HasChildren \Unmarked) "." "foo"`,
+			`* LIST (\HasNoChildren \Subscribed \Unmarked) "." "foo.bar"`,
+		)
+		c.OK(`A001`)
+
+		// Several patterns can be given at once.
+		c.C(`A002 LIST "" ("INBOX" "foo.%")`)
+		c.S(
+			`* LIST (\Unmarked) "." "INBOX"`,
+			`* LIST (\Unmarked) "." "foo.bar"`,
+		)
+		c.OK(`A002`)
+	})
+}
+
+func TestListStatus(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t, withDelimiter(".")), func(c *testConnection, _ *testSession) {
+		c.C(`tag CREATE foo.bar`).OK(`tag`)
+		c.C(`tag DELETE foo`).OK(`tag`)
+
+		c.doAppend(`foo.bar`, buildRFC5322TestLiteral(`To: 1@pm.me`)).expect("OK")
+		c.doAppend(`foo.bar`, buildRFC5322TestLiteral(`To: 2@pm.me`), `\Seen`).expect("OK")
+
+		// No status is returned for mailboxes which can't be selected.
+		c.C(`A001 LIST "" "*" RETURN (STATUS (MESSAGES UNSEEN))`)
+		c.S(
+			`* LIST (\Unmarked) "." "INBOX"`,
+			`* STATUS "INBOX" (MESSAGES 0 UNSEEN 0)`,
+			`* LIST (\Noselect) "." "foo"`,
+			`* LIST (\Marked) "." "foo.bar"`,
+			`* STATUS "foo.bar" (MESSAGES 2 UNSEEN 1)`,
+		)
+		c.OK(`A001`)
+	})
+}
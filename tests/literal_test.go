@@ -0,0 +1,62 @@
+package tests
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/gluon/limits"
+)
+
+func TestLiteralPlusLogin(t *testing.T) {
+	runOneToOneTest(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
+		// Non-synchronizing literals are read without a continuation request.
+		c.C("A001 login {4+}\r\nuser {4+}\r\npass")
+		c.Sx(`^A001 OK \[CAPABILITY .*LITERAL\+.*\] Logged in`)
+	})
+}
+
+func TestLiteralPlusAppend(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
+		literal := buildRFC5322TestLiteral(`To: 1@pm.me`)
+
+		c.C(fmt.Sprintf("A001 APPEND INBOX {%v+}\r\n%v", len(literal), literal))
+		c.Sx(`^A001 OK \[APPENDUID \d+ 1\] APPEND`)
+
+		c.C(`A002 SELECT INBOX`)
+		c.Se(`* 1 EXISTS`)
+		c.OK(`A002`)
+
+		c.C("A003 SEARCH TO {5+}\r\n1@pm.")
+		c.S(`* SEARCH 1`)
+		c.OK(`A003`)
+	})
+}
+
+func TestLiteralMinus(t *testing.T) {
+	imapLimits := limits.DefaultLimits().WithMaxNonSyncLiteralSize(limits.LiteralMinusMaxSize)
+
+	runOneToOneTest(t, defaultServerOptions(t, withIMAPLimits(imapLimits)), func(c *testConnection, _ *testSession) {
+		c.C(`A001 CAPABILITY`)
+		c.S(`* CAPABILITY AUTH=PLAIN ID IDLE IMAP4rev1 LITERAL- STARTTLS`)
+		c.OK(`A001`)
+
+		c.C("A002 login {4+}\r\nuser {4+}\r\npass")
+		c.Sx(`^A002 OK \[CAPABILITY .*LITERAL-.*\] Logged in`)
+
+		// Non-synchronizing literals which are too big are rejected, but the session remains usable.
+		c.C(fmt.Sprintf("A003 APPEND INBOX {%v+}\r\n%v", limits.LiteralMinusMaxSize+1, strings.Repeat("a", limits.LiteralMinusMaxSize+1)))
+		c.Sx(`^A003 BAD \[TOOBIG\]`)
+
+		c.C(`A004 NOOP`)
+		c.OK(`A004`)
+
+		// Synchronizing literals are not affected.
+		literal := buildRFC5322TestLiteral(`To: 1@pm.me`)
+
+		c.C(fmt.Sprintf("A005 APPEND INBOX {%v}", len(literal)))
+		c.S(`+ Ready`)
+		c.C(literal)
+		c.OK(`A005`)
+	})
+}
@@ -0,0 +1,117 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/gluon/imap"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadata(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
+		c.C(`A001 SETMETADATA INBOX (/private/comment "My comment" /private/vendor/x/a "a" /private/vendor/x/b/c "c")`)
+		c.OK(`A001`)
+
+		c.C(`A002 GETMETADATA INBOX /private/comment`)
+		c.S(`* METADATA "INBOX" (/private/comment "My comment")`)
+		c.OK(`A002`)
+
+		// Entry names are case-insensitive; missing entries are reported as NIL.
+		c.C(`A003 GETMETADATA INBOX (/PRIVATE/Comment /private/foo)`)
+		c.S(`* METADATA "INBOX" (/private/comment "My comment" /private/foo NIL)`)
+		c.OK(`A003`)
+
+		c.C(`A004 GETMETADATA (DEPTH 1) INBOX /private/vendor/x`)
+		c.S(`* METADATA "INBOX" (/private/vendor/x/a "a" /private/vendor/x NIL)`)
+		c.OK(`A004`)
+
+		c.C(`A005 GETMETADATA (DEPTH infinity) INBOX /private/vendor`)
+		c.S(`* METADATA "INBOX" (/private/vendor/x/a "a" /private/vendor/x/b/c "c" /private/vendor NIL)`)
+		c.OK(`A005`)
+
+		// Entries bigger than MAXSIZE are omitted.
+		c.C(`A006 GETMETADATA (MAXSIZE 5) INBOX /private/comment`)
+		c.S(`* METADATA "INBOX" ()`)
+		c.S(`A006 OK [METADATA LONGENTRIES 10] GETMETADATA`)
+
+		// Setting NIL removes the entry.
+		c.C(`A007 SETMETADATA INBOX (/private/comment NIL)`)
+		c.OK(`A007`)
+
+		c.C(`A008 GETMETADATA INBOX /private/comment`)
+		c.S(`* METADATA "INBOX" (/private/comment NIL)`)
+		c.OK(`A008`)
+
+		// Values which can't be quoted are sent as literals.
+		c.C("A009 SETMETADATA \"\" (/private/comment {7+}\r\nfoo\r\nba)")
+		c.OK(`A009`)
+
+		c.C(`A010 GETMETADATA "" /private/comment`)
+		c.S("* METADATA \"\" (/private/comment {7}\r\nfoo\r\nba)")
+		c.OK(`A010`)
+
+		// Values with NUL bytes are sent as literal8.
+		c.C("A011 SETMETADATA \"\" (/private/blob ~{3+}\r\na\x00b)")
+		c.OK(`A011`)
+
+		c.C(`A012 GETMETADATA "" /private/blob`)
+		c.S("* METADATA \"\" (/private/blob ~{3}\r\na\x00b)")
+		c.OK(`A012`)
+
+		c.C(`A013 GETMETADATA foo /private/comment`)
+		c.NO(`A013`)
+
+		c.C(`A014 SETMETADATA INBOX (/comment "foo")`)
+		c.BAD(`A014`)
+	})
+}
+
+func TestMetadataShared(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, s *testSession) {
+		c.C(`A001 SETMETADATA INBOX (/shared/comment "Shared" /private/comment "Private")`)
+		c.OK(`A001`)
+
+		// Only shared entries are synced with the connector.
+		value, ok := s.conns[s.userIDs["user"]].GetSharedMetadata(imap.MailboxID("0"), "/shared/comment")
+		require.True(t, ok)
+		require.Equal(t, []byte("Shared"), value)
+
+		_, ok = s.conns[s.userIDs["user"]].GetSharedMetadata(imap.MailboxID("0"), "/private/comment")
+		require.False(t, ok)
+
+		c.C(`A002 SETMETADATA "" (/shared/admin "mailto:admin@pm.me")`)
+		c.OK(`A002`)
+
+		value, ok = s.conns[s.userIDs["user"]].GetSharedMetadata(imap.MailboxID(""), "/shared/admin")
+		require.True(t, ok)
+		require.Equal(t, []byte("mailto:admin@pm.me"), value)
+	})
+}
+
+func TestMetadataDeletedWithMailbox(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
+		c.C(`A001 CREATE foo`)
+		c.OK(`A001`)
+
+		c.C(`A002 SETMETADATA foo (/private/comment "foo")`)
+		c.OK(`A002`)
+
+		c.C(`A003 DELETE foo`)
+		c.OK(`A003`)
+
+		c.C(`A004 CREATE foo`)
+		c.OK(`A004`)
+
+		c.C(`A005 GETMETADATA foo /private/comment`)
+		c.S(`* METADATA "foo" (/private/comment NIL)`)
+		c.OK(`A005`)
+	})
+}
+
+func TestMetadataMaxSize(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
+		c.C("A001 SETMETADATA INBOX (/private/comment {65537+}\r\n" + strings.Repeat("a", 65537) + ")")
+		c.S(`A001 NO [METADATA MAXSIZE 65536] metadata value too large`)
+	})
+}
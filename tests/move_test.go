@@ -228,8 +228,8 @@ type simulateLabelConnector struct {
 	mboxID imap.MailboxID
 }
 
-func (r *simulateLabelConnector) CreateMailbox(ctx context.Context, cache connector.IMAPStateWrite, name []string) (imap.Mailbox, error) {
-	mbox, err := r.Dummy.CreateMailbox(ctx, cache, name)
+func (r *simulateLabelConnector) CreateMailbox(ctx context.Context, cache connector.IMAPStateWrite, name []string, specialUse imap.FlagSet) (imap.Mailbox, error) {
+	mbox, err := r.Dummy.CreateMailbox(ctx, cache, name, specialUse)
 	if err != nil {
 		return mbox, err
 	}
@@ -0,0 +1,75 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ProtonMail/gluon/imap"
+)
+
+func TestMultiAppend(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
+		c.C("A001 CREATE saved-messages")
+		c.Sx(`^A001 OK \[MAILBOXID \(\S+\)\] CREATE`)
+
+		literal1 := buildRFC5322TestLiteral(`To: 1@pm.me`)
+		literal2 := buildRFC5322TestLiteral(`To: 2@pm.me`)
+		literal3 := buildRFC5322TestLiteral(`To: 3@pm.me`)
+
+		// Each synchronizing literal gets its own continuation request.
+		c.C(fmt.Sprintf(`A002 APPEND saved-messages (\Seen) {%v}`, len(literal1)))
+		c.S(`+ Ready`)
+		c.C(fmt.Sprintf(`%v (\Flagged) {%v}`, literal1, len(literal2)))
+		c.S(`+ Ready`)
+		c.C(fmt.Sprintf(`%v {%v}`, literal2, len(literal3)))
+		c.S(`+ Ready`)
+		c.C(literal3)
+		c.Sx(`^A002 OK \[APPENDUID \d+ 1:3\] APPEND`)
+
+		c.C(`A003 SELECT saved-messages`)
+		c.Se(`* 3 EXISTS`)
+		c.OK(`A003`)
+
+		c.C(`A004 FETCH 1:3 (FLAGS)`)
+		c.S(
+			`* 1 FETCH (FLAGS (\Recent \Seen))`,
+			`* 2 FETCH (FLAGS (\Flagged \Recent))`,
+			`* 3 FETCH (FLAGS (\Recent))`,
+		)
+		c.OK(`A004`)
+
+		// Appending to the selected mailbox reports the new messages.
+		c.C(fmt.Sprintf("A005 APPEND saved-messages {%v+}\r\n%v {%v+}\r\n%v", len(literal1), literal1, len(literal2), literal2))
+		c.S(`* 5 EXISTS`, `* 5 RECENT`)
+		c.Sx(`^A005 OK \[APPENDUID \d+ 4:5\] APPEND`)
+	})
+}
+
+func TestMultiAppendAllOrNothing(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, s *testSession) {
+		literal1 := buildRFC5322TestLiteral(`To: 1@pm.me`)
+		literal2 := buildRFC5322TestLiteral(`To: 2@pm.me`)
+		invalid := "To: 3@pm.me\r\n\r\nHello"
+
+		// The second message is invalid, so the first one isn't appended either.
+		c.C(fmt.Sprintf("A001 APPEND INBOX {%v+}\r\n%v {%v+}\r\n%v", len(literal1), literal1, len(invalid), invalid))
+		c.BAD(`A001`)
+
+		c.C(`A002 STATUS INBOX (MESSAGES)`)
+		c.S(`* STATUS "INBOX" (MESSAGES 0)`)
+		c.OK(`A002`)
+
+		// All the messages count towards the quota.
+		s.conns[s.userIDs["user"]].SetQuota(imap.Quota{MessageLimit: 1})
+
+		c.C(fmt.Sprintf("A003 APPEND INBOX {%v+}\r\n%v {%v+}\r\n%v", len(literal1), literal1, len(literal2), literal2))
+		c.Sx(`^A003 NO \[OVERQUOTA\]`)
+
+		c.C(`A004 STATUS INBOX (MESSAGES)`)
+		c.S(`* STATUS "INBOX" (MESSAGES 0)`)
+		c.OK(`A004`)
+
+		c.C(fmt.Sprintf("A005 APPEND foo {%v+}\r\n%v {%v+}\r\n%v", len(literal1), literal1, len(literal2), literal2))
+		c.Sx(`^A005 NO \[TRYCREATE\]`)
+	})
+}
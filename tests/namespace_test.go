@@ -0,0 +1,40 @@
+package tests
+
+import (
+	"testing"
+)
+
+func TestNamespace(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, s *testSession) {
+		c.C(`A001 NAMESPACE`)
+		c.S(`* NAMESPACE (("" "/")) NIL NIL`)
+		c.OK(`A001`)
+	})
+}
+
+func TestNamespaceWithPrefixes(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, s *testSession) {
+		s.setFolderPrefix("user", "Folders")
+		s.setLabelsPrefix("user", "Labels")
+
+		c.C(`A001 NAMESPACE`)
+		c.S(`* NAMESPACE (("" "/")("Folders/" "/")("Labels/" "/")) NIL NIL`)
+		c.OK(`A001`)
+	})
+}
+
+func TestNamespaceWithDelimiter(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t, withDelimiter(".")), func(c *testConnection, s *testSession) {
+		s.setFolderPrefix("user", "Folders")
+
+		c.C(`A001 NAMESPACE`)
+		c.S(`* NAMESPACE (("" ".")("Folders." ".")) NIL NIL`)
+		c.OK(`A001`)
+	})
+}
+
+func TestNamespaceNotAuthenticated(t *testing.T) {
+	runOneToOneTest(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
+		c.C(`A001 NAMESPACE`).NO(`A001`)
+	})
+}
@@ -0,0 +1,124 @@
+package tests
+
+import (
+	"testing"
+)
+
+func TestNotifyMessageEvents(t *testing.T) {
+	runManyToOneTestWithAuth(t, defaultServerOptions(t), []int{1, 2}, func(c map[int]*testConnection, _ *testSession) {
+		c[1].C(`A001 CREATE saved-messages`)
+		c[1].OK(`A001`)
+
+		c[1].C(`A002 SELECT saved-messages`)
+		c[1].Se(`A002 OK [READ-WRITE] SELECT`)
+
+		// The current status of the other mailboxes is sent right away.
+		c[1].C(`A003 NOTIFY SET STATUS (selected (MessageNew MessageExpunge)) (personal (MessageNew MessageExpunge FlagChange))`)
+		c[1].S(`* STATUS "INBOX" (MESSAGES 0 UIDNEXT 1 UNSEEN 0)`)
+		c[1].OK(`A003`)
+
+		c[1].C(`A004 IDLE`)
+		c[1].S(`+ Ready`)
+
+		// New messages in other mailboxes are reported with their status.
+		c[2].doAppend(`INBOX`, buildRFC5322TestLiteral(`To: 1@pm.me`)).expect("OK")
+		c[1].S(`* STATUS "INBOX" (MESSAGES 1 UIDNEXT 2 UNSEEN 1)`)
+
+		c[2].C(`B001 SELECT INBOX`)
+		c[2].Se(`B001 OK [READ-WRITE] SELECT`)
+
+		c[2].C(`B002 STORE 1 +FLAGS (\Seen)`)
+		c[2].OK(`B002`)
+		c[1].S(`* STATUS "INBOX" (MESSAGES 1 UIDNEXT 2 UNSEEN 0)`)
+
+		c[2].C(`B003 STORE 1 +FLAGS (\Deleted)`)
+		c[2].OK(`B003`)
+		c[1].S(`* STATUS "INBOX" (MESSAGES 1 UIDNEXT 2 UNSEEN 0)`)
+
+		c[2].C(`B004 EXPUNGE`)
+		c[2].OK(`B004`)
+		c[1].S(`* STATUS "INBOX" (MESSAGES 0 UIDNEXT 2 UNSEEN 0)`)
+
+		// The selected mailbox keeps being reported with the usual responses.
+		c[2].doAppend(`saved-messages`, buildRFC5322TestLiteral(`To: 2@pm.me`)).expect("OK")
+		c[1].S(`* 1 EXISTS`, `* 1 RECENT`)
+
+		c[1].C(`DONE`)
+		c[1].OK(`A004`)
+	})
+}
+
+func TestNotifyMailboxEvents(t *testing.T) {
+	runManyToOneTestWithAuth(t, defaultServerOptions(t), []int{1, 2}, func(c map[int]*testConnection, _ *testSession) {
+		c[1].C(`A001 NOTIFY SET (subtree Folder (MailboxName SubscriptionChange))`)
+		c[1].OK(`A001`)
+
+		c[1].C(`A002 IDLE`)
+		c[1].S(`+ Ready`)
+
+		c[2].C(`B001 CREATE Other`)
+		c[2].OK(`B001`)
+
+		c[2].C(`B002 CREATE Folder/Child`)
+		c[2].OK(`B002`)
+		c[1].S(
+			`* LIST (\Subscribed) "/" "Folder"`,
+			`* LIST (\Subscribed) "/" "Folder/Child"`,
+		)
+
+		c[2].C(`B003 UNSUBSCRIBE Folder/Child`)
+		c[2].OK(`B003`)
+		c[1].S(`* LIST () "/" "Folder/Child"`)
+
+		c[2].C(`B004 RENAME Folder/Child Folder/Renamed`)
+		c[2].OK(`B004`)
+		c[1].S(`* LIST () "/" "Folder/Renamed" ("OLDNAME" ("Folder/Child"))`)
+
+		c[2].C(`B005 DELETE Folder/Renamed`)
+		c[2].OK(`B005`)
+		c[1].S(`* LIST (\NonExistent) "/" "Folder/Renamed"`)
+
+		c[1].C(`DONE`)
+		c[1].OK(`A002`)
+
+		// Pending notifications are dropped once notifications are disabled.
+		c[2].C(`B006 CREATE Folder/Other`)
+		c[2].OK(`B006`)
+
+		c[1].C(`A003 NOTIFY NONE`)
+		c[1].OK(`A003`)
+
+		c[2].C(`B007 DELETE Folder/Other`)
+		c[2].OK(`B007`)
+
+		c[1].C(`A004 NOOP`)
+		c[1].Sx(`^A004 OK`)
+	})
+}
+
+func TestNotifyInvalidEvents(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
+		c.C(`A001 NOTIFY SET (personal (MessageNew MessageExpunge AnnotationChange))`)
+		c.S(`A001 NO [BADEVENT (MessageNew MessageExpunge FlagChange MailboxName SubscriptionChange)] unsupported event`)
+
+		c.C(`A002 NOTIFY SET (personal (MessageNew))`).BAD(`A002`)
+		c.C(`A003 NOTIFY SET (personal (FlagChange))`).BAD(`A003`)
+
+		c.C(`A004 NOTIFY NONE`)
+		c.OK(`A004`)
+	})
+}
+
+func TestNotifySelectedUnsupported(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
+		c.C(`A001 NOTIFY SET (SELECTED (MessageNew (UID BODY.PEEK[HEADER]) MessageExpunge))`)
+		c.S(`A001 NO unsupported filter: SELECTED with MessageNew fetch attributes`)
+
+		c.C(`A002 NOTIFY SET (SELECTED-DELAYED NONE) (personal (MailboxName))`)
+		c.S(`A002 NO unsupported filter: SELECTED-DELAYED NONE`)
+
+		// The selected mailbox is already reported with the usual untagged responses.
+		c.C(`A003 NOTIFY SET (SELECTED (MessageNew MessageExpunge FlagChange)) (personal (MailboxName))`)
+		c.OK(`A003`)
+	})
+}
@@ -0,0 +1,95 @@
+package tests
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/gluon/imap"
+	"github.com/ProtonMail/gluon/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectIDMailbox(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, s *testSession) {
+		c.C(`A001 CREATE Archive`)
+
+		match := regexp.MustCompile(`^A001 OK \[MAILBOXID \((\S+)\)\] CREATE\r\n$`).FindSubmatch(c.read())
+		require.NotNil(t, match)
+
+		// The same object ID is reported by STATUS and when the mailbox is selected.
+		c.C(`A002 STATUS Archive (MESSAGES MAILBOXID)`)
+		c.S(fmt.Sprintf(`* STATUS "Archive" (MESSAGES 0 MAILBOXID (%s))`, match[1]))
+		c.OK(`A002`)
+
+		c.C(`A003 EXAMINE Archive`)
+		c.Se(fmt.Sprintf(`* OK [MAILBOXID (%s)]`, match[1]))
+		c.OK(`A003`)
+
+		// Remote IDs are escaped to only use the characters allowed in object IDs.
+		mboxID := s.mailboxCreated("user", []string{"Folder"})
+
+		c.C(`A004 STATUS Folder (MAILBOXID)`)
+		c.S(fmt.Sprintf(`* STATUS "Folder" (MAILBOXID (%v))`, mboxID.ObjectID()))
+		c.OK(`A004`)
+	})
+}
+
+func TestObjectIDMessage(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, s *testSession) {
+		mboxID := s.mailboxCreated("user", []string{"Folder"})
+
+		messageCreated := func(id imap.MessageID, threadID string) {
+			require.NoError(t, s.conns[s.userIDs["user"]].MessageCreated(
+				imap.Message{ID: id, Flags: imap.NewFlagSet(), Date: time.Now(), ThreadID: threadID},
+				[]byte(buildRFC5322TestLiteral(`To: 1@pm.me`)),
+				[]imap.MailboxID{mboxID},
+			))
+
+			s.conns[s.userIDs["user"]].Flush()
+		}
+
+		messageID1 := imap.MessageID(utils.NewRandomMessageID())
+		messageID2 := imap.MessageID("message+2/==")
+		messageID3 := imap.MessageID(utils.NewRandomMessageID())
+
+		messageCreated(messageID1, "thread/1")
+		messageCreated(messageID2, "thread/1")
+		messageCreated(messageID3, "")
+
+		c.C(`A001 SELECT Folder`)
+		c.Se(`A001 OK [READ-WRITE] SELECT`)
+
+		c.C(`A002 FETCH 1:3 (EMAILID THREADID)`)
+		c.S(
+			fmt.Sprintf(`* 1 FETCH (EMAILID (%v) THREADID (thread_2F1))`, messageID1.ObjectID()),
+			`* 2 FETCH (EMAILID (message_2B2_2F_3D_3D) THREADID (thread_2F1))`,
+			fmt.Sprintf(`* 3 FETCH (EMAILID (%v) THREADID NIL)`, messageID3.ObjectID()),
+		)
+		c.OK(`A002`)
+
+		c.C(fmt.Sprintf(`A003 SEARCH EMAILID %v`, messageID3.ObjectID()))
+		c.S(`* SEARCH 3`)
+		c.OK(`A003`)
+
+		c.C(`A004 SEARCH THREADID thread_2F1`)
+		c.S(`* SEARCH 1 2`)
+		c.OK(`A004`)
+
+		c.C(`A005 SEARCH THREADID thread`)
+		c.S(`* SEARCH`)
+		c.OK(`A005`)
+
+		// A copied message keeps its object ID.
+		c.C(`A006 COPY 2 INBOX`)
+		c.OK(`A006`)
+
+		c.C(`A007 EXAMINE INBOX`)
+		c.Se(`A007 OK [READ-ONLY] EXAMINE`)
+
+		c.C(`A008 FETCH 1 (EMAILID THREADID)`)
+		c.S(`* 1 FETCH (EMAILID (message_2B2_2F_3D_3D) THREADID (thread_2F1))`)
+		c.OK(`A008`)
+	})
+}
@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestPartialSearch(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
+		c.C("A001 CREATE saved-messages")
+		c.Sx(`^A001 OK \[MAILBOXID \(\S+\)\] CREATE`)
+
+		for i := 1; i <= 5; i++ {
+			c.doAppend(`saved-messages`, buildRFC5322TestLiteral(fmt.Sprintf(`To: %v@pm.me`, i))).expect("OK")
+		}
+
+		c.C(`A002 SELECT saved-messages`)
+		c.Se(`A002 OK [READ-WRITE] SELECT`)
+
+		c.C(`A003 UID SEARCH RETURN (PARTIAL 1:2) ALL`)
+		c.S(`* ESEARCH (TAG "A003") UID PARTIAL (1:2 1:2)`)
+		c.OK(`A003`)
+
+		// Negative ranges count from the end of the result; other options still apply to the whole result.
+		c.C(`A004 SEARCH RETURN (COUNT MIN PARTIAL -1:-2) ALL`)
+		c.S(`* ESEARCH (TAG "A004") MIN 1 COUNT 5 PARTIAL (-1:-2 4:5)`)
+		c.OK(`A004`)
+
+		c.C(`A005 SEARCH RETURN (PARTIAL 10:20) ALL`)
+		c.S(`* ESEARCH (TAG "A005") PARTIAL (10:20 NIL)`)
+		c.OK(`A005`)
+
+		c.C(`A006 SEARCH RETURN (PARTIAL 1:2 ALL) ALL`).BAD(`A006`)
+
+		// Only the returned range of the result is saved.
+		c.C(`A007 SEARCH RETURN (SAVE PARTIAL -1:-1) ALL`)
+		c.S(`* ESEARCH (TAG "A007") PARTIAL (-1:-1 5)`)
+		c.OK(`A007`)
+
+		c.C(`A008 FETCH $ (UID)`)
+		c.S(`* 5 FETCH (UID 5)`)
+		c.OK(`A008`)
+	})
+}
+
+func TestPartialFetch(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
+		c.C("A001 CREATE saved-messages")
+		c.Sx(`^A001 OK \[MAILBOXID \(\S+\)\] CREATE`)
+
+		for i := 1; i <= 5; i++ {
+			c.doAppend(`saved-messages`, buildRFC5322TestLiteral(fmt.Sprintf(`To: %v@pm.me`, i))).expect("OK")
+		}
+
+		c.C(`A002 SELECT saved-messages`)
+		c.Se(`A002 OK [READ-WRITE] SELECT`)
+
+		c.C(`A003 FETCH 1:* (UID) (PARTIAL 2:3)`)
+		c.S(
+			`* 2 FETCH (UID 2)`,
+			`* 3 FETCH (UID 3)`,
+		)
+		c.OK(`A003`)
+
+		c.C(`A004 UID FETCH 1:* (UID) (PARTIAL -2:-1)`)
+		c.S(
+			`* 4 FETCH (UID 4)`,
+			`* 5 FETCH (UID 5)`,
+		)
+		c.OK(`A004`)
+
+		c.C(`A005 UID FETCH 1:* (UID) (PARTIAL 6:10)`)
+		c.OK(`A005`)
+
+		// The range applies to the messages which changed since the given mod-sequence.
+		c.C(`A006 FETCH 5 (MODSEQ)`)
+		modSeq := readModSeq(t, c, `\* 5 FETCH \(MODSEQ \((\d+)\)\)`)
+		c.OK(`A006`)
+
+		c.C(`A007 STORE 1,3,4 +FLAGS.SILENT (\Flagged)`)
+		c.OK(`A007`)
+
+		c.C(fmt.Sprintf(`A008 FETCH 1:* (UID) (CHANGEDSINCE %v PARTIAL -1:-2)`, modSeq))
+		c.Sx(
+			`\* 3 FETCH \(UID 3 MODSEQ \(\d+\)\)`,
+			`\* 4 FETCH \(UID 4 MODSEQ \(\d+\)\)`,
+		)
+		c.OK(`A008`)
+	})
+}
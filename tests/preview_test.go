@@ -0,0 +1,77 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/gluon/imap"
+	"github.com/ProtonMail/gluon/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreview(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, s *testSession) {
+		c.doAppend(`INBOX`, buildRFC5322TestLiteral("Subject: Plain\r\n\r\nHello,\r\nhow are you?\r\n")).expect("OK")
+		c.doAppend(`INBOX`, buildRFC5322TestLiteral("Content-Type: multipart/alternative; boundary=b\r\n\r\n"+
+			"--b\r\nContent-Type: text/html; charset=utf-8\r\n\r\n<p>Gr&uuml;&szlig;e aus <b>Z&uuml;rich</b></p>\r\n"+
+			"--b--\r\n")).expect("OK")
+
+		c.C(`A001 SELECT INBOX`)
+		c.Se(`A001 OK [READ-WRITE] SELECT`)
+
+		c.C(`A002 FETCH 1:2 (PREVIEW)`)
+		c.S(
+			`* 1 FETCH (PREVIEW "Hello, how are you?")`,
+			"* 2 FETCH (PREVIEW {19}\r\nGrüße aus Zürich)",
+		)
+		c.OK(`A002`)
+
+		c.C(`A003 UID FETCH 1 PREVIEW (LAZY)`)
+		c.S(`* 1 FETCH (PREVIEW "Hello, how are you?" UID 1)`)
+		c.OK(`A003`)
+
+		// Fetching the preview doesn't mark the message as seen.
+		c.C(`A004 FETCH 1 (FLAGS)`)
+		c.S(`* 1 FETCH (FLAGS (\Recent))`)
+		c.OK(`A004`)
+	})
+}
+
+func TestPreviewBroken(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, s *testSession) {
+		c.doAppend(`INBOX`, buildRFC5322TestLiteral("Content-Transfer-Encoding: base64\r\n\r\n!!! not base64 !!!\r\n")).expect("OK")
+
+		c.C(`A001 SELECT INBOX`)
+		c.Se(`A001 OK [READ-WRITE] SELECT`)
+
+		// Messages whose preview can't be built have no preview rather than an empty one.
+		c.C(`A002 FETCH 1 (PREVIEW (LAZY))`)
+		c.S(`* 1 FETCH (PREVIEW NIL)`)
+		c.OK(`A002`)
+
+		c.C(`A003 FETCH 1 (PREVIEW)`)
+		c.S(`* 1 FETCH (PREVIEW NIL)`)
+		c.OK(`A003`)
+	})
+}
+
+func TestPreviewConnectorMessage(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, s *testSession) {
+		mboxID := s.mailboxCreated("user", []string{"Folder"})
+
+		require.NoError(t, s.conns[s.userIDs["user"]].MessageCreated(
+			imap.Message{ID: imap.MessageID(utils.NewRandomMessageID()), Flags: imap.NewFlagSet(), Date: time.Now()},
+			[]byte(buildRFC5322TestLiteral("\r\nConnector message")),
+			[]imap.MailboxID{mboxID},
+		))
+
+		s.conns[s.userIDs["user"]].Flush()
+
+		c.C(`A001 EXAMINE Folder`)
+		c.Se(`A001 OK [READ-ONLY] EXAMINE`)
+
+		c.C(`A002 FETCH 1 (PREVIEW)`)
+		c.S(`* 1 FETCH (PREVIEW "Connector message")`)
+		c.OK(`A002`)
+	})
+}
@@ -0,0 +1,182 @@
+package tests
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQResyncEnable(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
+		// QRESYNC must be enabled before it can be used.
+		c.C("A001 SELECT INBOX (QRESYNC (1 1))").BAD("A001")
+
+		c.C("A002 ENABLE QRESYNC")
+		c.S("* ENABLED QRESYNC")
+		c.OK("A002")
+
+		// Enabling it again is a no-op; unknown extensions are ignored.
+		c.C("A003 ENABLE QRESYNC FOO")
+		c.S("* ENABLED")
+		c.OK("A003")
+
+		c.C("A004 SELECT INBOX (QRESYNC (1 1))")
+		c.Sxe(`\* OK \[HIGHESTMODSEQ \d+\] Highest`)
+		c.OK("A004")
+
+		// Selecting another mailbox reports that the previous one was closed.
+		c.C("A005 EXAMINE INBOX")
+		c.Se(`* OK [CLOSED] Previous mailbox closed`)
+		c.OK("A005")
+	})
+}
+
+func TestQResyncVanished(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
+		c.C("A001 CREATE saved-messages")
+		c.Sx(`^A001 OK \[MAILBOXID \(\S+\)\] CREATE`)
+
+		c.doAppend(`saved-messages`, buildRFC5322TestLiteral(`To: 1@pm.me`)).expect("OK")
+		c.doAppend(`saved-messages`, buildRFC5322TestLiteral(`To: 2@pm.me`)).expect("OK")
+		c.doAppend(`saved-messages`, buildRFC5322TestLiteral(`To: 3@pm.me`)).expect("OK")
+
+		c.C("A002 ENABLE QRESYNC")
+		c.S("* ENABLED QRESYNC")
+		c.OK("A002")
+
+		c.C(`A003 SELECT saved-messages`)
+		c.Se(`A003 OK [READ-WRITE] SELECT`)
+
+		c.C(`A004 STORE 2,3 +FLAGS.SILENT (\Deleted)`)
+		c.OK(`A004`)
+
+		// Expunged messages are reported by UID.
+		c.C(`A005 EXPUNGE`)
+		c.S(`* VANISHED 2:3`)
+		c.OK(`A005`)
+	})
+}
+
+func TestQResyncSelect(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
+		c.C("A001 CREATE saved-messages")
+		c.Sx(`^A001 OK \[MAILBOXID \(\S+\)\] CREATE`)
+
+		c.doAppend(`saved-messages`, buildRFC5322TestLiteral(`To: 1@pm.me`)).expect("OK")
+		c.doAppend(`saved-messages`, buildRFC5322TestLiteral(`To: 2@pm.me`)).expect("OK")
+		c.doAppend(`saved-messages`, buildRFC5322TestLiteral(`To: 3@pm.me`)).expect("OK")
+
+		c.C("A002 ENABLE QRESYNC")
+		c.S("* ENABLED QRESYNC")
+		c.OK("A002")
+
+		c.C(`A003 SELECT saved-messages`)
+		c.Se(`A003 OK [READ-WRITE] SELECT`)
+
+		// Remember the state the client is in before going offline.
+		c.C(`A004 STATUS saved-messages (UIDVALIDITY HIGHESTMODSEQ)`)
+		uidValidity, modSeq := readUIDValidityAndHighestModSeq(t, c)
+		c.OK(`A004`)
+
+		c.C(`A005 STORE 1 +FLAGS.SILENT (\Seen)`)
+		c.OK(`A005`)
+
+		c.C(`A006 STORE 2 +FLAGS.SILENT (\Deleted)`)
+		c.OK(`A006`)
+
+		c.C(`A007 EXPUNGE`)
+		c.S(`* VANISHED 2`)
+		c.OK(`A007`)
+
+		c.C(`A008 UNSELECT`)
+		c.OK(`A008`)
+
+		// Reselecting with the old state reports the expunged UID and the changed flags.
+		c.C(fmt.Sprintf(`A009 SELECT saved-messages (QRESYNC (%v %v))`, uidValidity, modSeq))
+		c.Sxe(
+			`\* VANISHED \(EARLIER\) 2\r\n`,
+			`\* 1 FETCH \(FLAGS \(\\Seen\) MODSEQ \(\d+\) UID 1\)\r\n`,
+		)
+		c.OK(`A009`)
+
+		// Restricting the known UIDs hides changes outside of them.
+		c.C(fmt.Sprintf(`A010 SELECT saved-messages (QRESYNC (%v %v 3))`, uidValidity, modSeq))
+		c.Sxe(`\* OK \[CLOSED\]`)
+		c.Se(`A010 OK [READ-WRITE] SELECT`)
+
+		// A mismatching UID validity means a full resync is needed.
+		c.C(fmt.Sprintf(`A011 SELECT saved-messages (QRESYNC (%v %v))`, uidValidity+1, modSeq))
+		c.Sxe(`\* OK \[CLOSED\]`)
+		c.Se(`A011 OK [READ-WRITE] SELECT`)
+
+		c.C(fmt.Sprintf(`A012 UID FETCH 1:* (FLAGS) (CHANGEDSINCE %v VANISHED)`, modSeq))
+		c.S(
+			`* VANISHED (EARLIER) 2`,
+			`* 1 FETCH (FLAGS (\Seen) MODSEQ (`+strconv.FormatUint(modSeq+1, 10)+`) UID 1)`,
+		)
+		c.OK(`A012`)
+
+		c.C(fmt.Sprintf(`A013 FETCH 1:* (FLAGS) (CHANGEDSINCE %v VANISHED)`, modSeq)).BAD(`A013`)
+	})
+}
+
+func TestQResyncVanishedPruned(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, s *testSession) {
+		mboxID := s.mailboxCreated("user", []string{"mbox"})
+
+		// Only UIDs 5000 and 10004 survive; the other expunged UIDs are too many to be kept and get pruned.
+		s.batchMessageCreated("user", mboxID, 10005, func(i int) ([]byte, []string) {
+			if i == 4999 || i == 10003 {
+				return []byte(buildRFC5322TestLiteral("To: 1@pm.me")), nil
+			}
+
+			return []byte(buildRFC5322TestLiteral("To: 1@pm.me")), []string{`\Deleted`}
+		})
+
+		c.C(`A001 ENABLE QRESYNC`)
+		c.S(`* ENABLED QRESYNC`)
+		c.OK(`A001`)
+
+		c.C(`A002 STATUS mbox (UIDVALIDITY HIGHESTMODSEQ)`)
+		uidValidity, modSeq := readUIDValidityAndHighestModSeq(t, c)
+		c.OK(`A002`)
+
+		c.C(`A003 SELECT mbox`)
+		c.Se(`A003 OK [READ-WRITE] SELECT`)
+
+		c.C(`A004 EXPUNGE`)
+		c.Se(`A004 OK EXPUNGE`)
+
+		// The UIDs which are neither listed as expunged nor present are reported as ranges.
+		c.C(fmt.Sprintf(`A005 UID FETCH 1:* (FLAGS) (CHANGEDSINCE %v VANISHED)`, modSeq))
+		c.S(`* VANISHED (EARLIER) 1:4999,5001:10003,10005`)
+		c.OK(`A005`)
+
+		c.C(fmt.Sprintf(`A006 UID FETCH 4990:5010,10004:* (FLAGS) (CHANGEDSINCE %v VANISHED)`, modSeq))
+		c.S(`* VANISHED (EARLIER) 4990:4999,5001:5010,10005`)
+		c.OK(`A006`)
+
+		c.C(`A007 UNSELECT`)
+		c.OK(`A007`)
+
+		c.C(fmt.Sprintf(`A008 SELECT mbox (QRESYNC (%v %v 9000:*))`, uidValidity, modSeq))
+		c.Se(`* VANISHED (EARLIER) 9000:10003,10005`)
+		c.Se(`A008 OK [READ-WRITE] SELECT`)
+	})
+}
+
+func readUIDValidityAndHighestModSeq(t *testing.T, c *testConnection) (uint64, uint64) {
+	match := regexp.MustCompile(`UIDVALIDITY (\d+) HIGHESTMODSEQ (\d+)`).FindSubmatch(c.read())
+	require.Len(t, match, 3)
+
+	uidValidity, err := strconv.ParseUint(string(match[1]), 10, 32)
+	require.NoError(t, err)
+
+	modSeq, err := strconv.ParseUint(string(match[2]), 10, 64)
+	require.NoError(t, err)
+
+	return uidValidity, modSeq
+}
@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/gluon/imap"
+)
+
+func TestQuota(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, s *testSession) {
+		s.conns[s.userIDs["user"]].SetQuota(imap.Quota{StorageLimit: 1024 * 1024, MessageLimit: 2})
+
+		c.C(`A001 GETQUOTAROOT INBOX`)
+		c.S(
+			`* QUOTAROOT "INBOX" ""`,
+			`* QUOTA "" (STORAGE 0 1024 MESSAGE 0 2)`,
+		)
+		c.OK(`A001`)
+
+		c.doAppend(`INBOX`, buildRFC5322TestLiteral(`To: 1@pm.me`)).expect("OK")
+		c.doAppend(`INBOX`, buildRFC5322TestLiteral(`To: 2@pm.me`)).expect("OK")
+
+		// The usage is computed locally as the connector doesn't report it.
+		c.C(`A002 GETQUOTA ""`)
+		c.S(`* QUOTA "" (STORAGE 1 1024 MESSAGE 2 2)`)
+		c.OK(`A002`)
+
+		// Messages exceeding the quota are rejected before reaching the connector.
+		c.doAppend(`INBOX`, buildRFC5322TestLiteral(`To: 3@pm.me`)).expect(`NO \[OVERQUOTA\]`)
+
+		c.C(`A003 GETQUOTA "foo"`)
+		c.NO(`A003`)
+
+		c.C(`A004 GETQUOTAROOT foo`)
+		c.NO(`A004`)
+	})
+}
+
+func TestQuotaStorage(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, s *testSession) {
+		s.conns[s.userIDs["user"]].SetQuota(imap.Quota{StorageUsage: 10*1024 - 1, StorageLimit: 10 * 1024})
+
+		c.C(`A001 GETQUOTA ""`)
+		c.S(`* QUOTA "" (STORAGE 10 10)`)
+		c.OK(`A001`)
+
+		c.doAppend(`INBOX`, buildRFC5322TestLiteral(`To: 1@pm.me`)).expect(`NO \[OVERQUOTA\]`)
+
+		c.C(`A002 STATUS INBOX (MESSAGES SIZE)`)
+		c.S(`* STATUS "INBOX" (MESSAGES 0 SIZE 0)`)
+		c.OK(`A002`)
+	})
+}
+
+func TestStatusSize(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
+		c.doAppend(`INBOX`, buildRFC5322TestLiteral(`To: 1@pm.me`)).expect("OK")
+		c.doAppend(`INBOX`, buildRFC5322TestLiteral(`To: 2@pm.me`)).expect("OK")
+
+		c.C(`A001 STATUS INBOX (SIZE)`)
+		c.Sx(`\* STATUS "INBOX" \(SIZE [1-9]\d*\)`)
+		c.OK(`A001`)
+	})
+}
+
+func TestQuotaExpunged(t *testing.T) {
+	runManyToOneTestWithAuth(t, defaultServerOptions(t), []int{1, 2}, func(c map[int]*testConnection, s *testSession) {
+		s.conns[s.userIDs["user"]].SetQuota(imap.Quota{MessageLimit: 2})
+
+		mboxID := s.mailboxCreated("user", []string{"mbox"})
+		messageID1 := s.messageCreated("user", mboxID, []byte(buildRFC5322TestLiteral(`To: 1@pm.me`)), time.Now())
+		messageID2 := s.messageCreated("user", mboxID, []byte(buildRFC5322TestLiteral(`To: 2@pm.me`)), time.Now())
+
+		// The second client keeps the messages in its snapshot so they are not removed from the database yet.
+		c[2].C(`A001 SELECT mbox`).OK(`A001`)
+
+		s.messageDeleted("user", messageID1)
+		s.messageDeleted("user", messageID2)
+
+		// Messages that have been expunged everywhere don't count towards the quota.
+		c[1].C(`A002 GETQUOTA ""`)
+		c[1].S(`* QUOTA "" (MESSAGE 0 2)`)
+		c[1].OK(`A002`)
+
+		c[1].doAppend(`INBOX`, buildRFC5322TestLiteral(`To: 3@pm.me`)).expect("OK")
+		c[1].doAppend(`INBOX`, buildRFC5322TestLiteral(`To: 4@pm.me`)).expect("OK")
+		c[1].doAppend(`INBOX`, buildRFC5322TestLiteral(`To: 5@pm.me`)).expect(`NO \[OVERQUOTA\]`)
+	})
+}
@@ -0,0 +1,157 @@
+package tests
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/gluon/imap"
+)
+
+func TestReplace(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, s *testSession) {
+		s.mailboxCreatedWithAttributes("user", []string{"Drafts"}, imap.NewFlagSet(imap.AttrDrafts))
+
+		c.doAppend(`Drafts`, buildRFC5322TestLiteral(`To: 1@pm.me`), `\Draft`).expect("OK")
+		c.doAppend(`Drafts`, buildRFC5322TestLiteral(`To: 2@pm.me`), `\Draft`).expect("OK")
+
+		c.C(`A001 SELECT Drafts`)
+		c.Se(`A001 OK [READ-WRITE] SELECT`)
+
+		literal := buildRFC5322TestLiteral(`To: 3@pm.me`)
+
+		c.C(fmt.Sprintf(`A002 REPLACE 1 Drafts (\Seen \Draft) {%v}`, len(literal)))
+		c.S(`+ Ready`)
+		c.C(literal)
+		c.Sx(`^\* OK \[APPENDUID \d+ 3\] Replacement message ready`)
+		c.S(`* 1 EXPUNGE`)
+		c.S(`* 2 EXISTS`)
+		c.OK(`A002`)
+
+		s.flush("user")
+
+		c.C(`A003 UID FETCH 1:* (FLAGS BODY.PEEK[HEADER.FIELDS (To)])`)
+		c.S(
+			"* 1 FETCH (FLAGS (\\Draft \\Recent) BODY[HEADER.FIELDS (TO)] {11}\r\nTo: 2@pm.me UID 2)",
+			"* 2 FETCH (FLAGS (\\Draft \\Recent \\Seen) BODY[HEADER.FIELDS (TO)] {11}\r\nTo: 3@pm.me UID 3)",
+		)
+		c.OK(`A003`)
+
+		c.C(fmt.Sprintf(`A004 UID REPLACE 99 Drafts {%v}`, len(literal)))
+		c.S(`+ Ready`)
+		c.C(literal)
+		c.Sx(`A004 NO`)
+
+		c.C(fmt.Sprintf(`A005 REPLACE 1 Missing {%v}`, len(literal)))
+		c.S(`+ Ready`)
+		c.C(literal)
+		c.Sx(`A005 NO \[TRYCREATE\]`)
+	})
+}
+
+func TestReplaceToOtherMailbox(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, s *testSession) {
+		c.C(`A001 CREATE Other`).OK(`A001`)
+
+		c.doAppend(`INBOX`, buildRFC5322TestLiteral(`To: 1@pm.me`)).expect("OK")
+
+		c.C(`A002 SELECT INBOX`)
+		c.Se(`A002 OK [READ-WRITE] SELECT`)
+
+		literal := buildRFC5322TestLiteral(`To: 2@pm.me`)
+
+		// Messages replaced outside the drafts mailbox must have valid headers.
+		invalid := "To: 3@pm.me\r\n\r\nHello"
+
+		c.C(fmt.Sprintf(`A003 UID REPLACE 1 Other {%v}`, len(invalid)))
+		c.S(`+ Ready`)
+		c.C(invalid)
+		c.BAD(`A003`)
+
+		c.C(fmt.Sprintf(`A004 UID REPLACE 1 Other {%v}`, len(literal)))
+		c.S(`+ Ready`)
+		c.C(literal)
+		c.Sx(`^\* OK \[APPENDUID \d+ 1\] Replacement message ready`)
+		c.S(`* 1 EXPUNGE`)
+		c.OK(`A004`)
+
+		c.C(`A005 STATUS INBOX (MESSAGES)`)
+		c.S(`* STATUS "INBOX" (MESSAGES 0)`)
+		c.OK(`A005`)
+
+		c.C(`A006 STATUS Other (MESSAGES)`)
+		c.S(`* STATUS "Other" (MESSAGES 1)`)
+		c.OK(`A006`)
+	})
+}
+
+func TestReplaceKeepsOtherMailboxes(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, s *testSession) {
+		s.mailboxCreatedWithAttributes("user", []string{"Drafts"}, imap.NewFlagSet(imap.AttrDrafts))
+
+		c.C(`A001 CREATE Label`).OK(`A001`)
+
+		c.doAppend(`Drafts`, buildRFC5322TestLiteral(`To: 1@pm.me`), `\Draft`).expect("OK")
+
+		c.C(`A002 SELECT Drafts`)
+		c.Se(`A002 OK [READ-WRITE] SELECT`)
+
+		c.C(`A003 COPY 1 Label`)
+		c.Sx(`A003 OK`)
+
+		literal := buildRFC5322TestLiteral(`To: 2@pm.me`)
+
+		// The message is updated in place, so it stays in its other mailboxes.
+		c.C(fmt.Sprintf(`A004 REPLACE 1 Drafts {%v}`, len(literal)))
+		c.S(`+ Ready`)
+		c.C(literal)
+		c.Sx(`^\* OK \[APPENDUID \d+ 2\] Replacement message ready`)
+		c.S(`* 1 EXPUNGE`)
+		c.S(`* 1 EXISTS`)
+		c.OK(`A004`)
+
+		c.C(`A005 SELECT Label`)
+		c.Se(`A005 OK [READ-WRITE] SELECT`)
+
+		c.C(`A006 FETCH 1:* (BODY.PEEK[HEADER.FIELDS (To)])`)
+		c.S("* 1 FETCH (BODY[HEADER.FIELDS (TO)] {11}\r\nTo: 2@pm.me)")
+		c.OK(`A006`)
+
+		s.flush("user")
+
+		c.C(`A007 STATUS Label (MESSAGES)`)
+		c.S(`* STATUS "Label" (MESSAGES 1)`)
+		c.OK(`A007`)
+	})
+}
+
+func TestReplaceAtQuota(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, s *testSession) {
+		s.mailboxCreatedWithAttributes("user", []string{"Drafts"}, imap.NewFlagSet(imap.AttrDrafts))
+
+		literal := buildRFC5322TestLiteral(`To: 1@pm.me`)
+
+		c.doAppend(`Drafts`, literal, `\Draft`).expect("OK")
+
+		s.conns[s.userIDs["user"]].SetQuota(imap.Quota{StorageUsage: 1024, StorageLimit: 1024})
+
+		c.C(`A001 SELECT Drafts`)
+		c.Se(`A001 OK [READ-WRITE] SELECT`)
+
+		// Only the growth of the replacement counts towards the quota.
+		c.C(fmt.Sprintf(`A002 REPLACE 1 Drafts {%v}`, len(literal)))
+		c.S(`+ Ready`)
+		c.C(literal)
+		c.Sx(`^\* OK \[APPENDUID \d+ 2\] Replacement message ready`)
+		c.S(`* 1 EXPUNGE`)
+		c.S(`* 1 EXISTS`)
+		c.OK(`A002`)
+
+		larger := buildRFC5322TestLiteral("To: 1@pm.me\r\n\r\n" + strings.Repeat("a", 1024))
+
+		c.C(fmt.Sprintf(`A003 REPLACE 1 Drafts {%v}`, len(larger)))
+		c.S(`+ Ready`)
+		c.C(larger)
+		c.Sx(`A003 NO \[OVERQUOTA\]`)
+	})
+}
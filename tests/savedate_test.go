@@ -0,0 +1,85 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestSaveDate(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, s *testSession) {
+		appendWithDate := func(tag, subject string, date time.Time) {
+			literal := buildRFC5322TestLiteral(fmt.Sprintf("Subject: %v\r\n\r\nHello\r\n", subject))
+
+			c.Cf(`%v APPEND INBOX () "%v" {%v}`, tag, date.Format("02-Jan-2006 15:04:05 -0700"), len(literal))
+			c.Sx(`\+.*`)
+			c.C(literal)
+			c.Sx(tag + ` OK.*`)
+		}
+
+		appendWithDate(`A000`, `Old`, time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC))
+		appendWithDate(`A001`, `New`, time.Now())
+
+		c.C(`A002 SELECT INBOX`)
+		c.Se(`A002 OK [READ-WRITE] SELECT`)
+
+		// The save date is when the message was appended, not its internal date.
+		c.C(`A003 FETCH 1 (SAVEDATE INTERNALDATE)`)
+		c.Sx(`^\* 1 FETCH \(SAVEDATE "\d{2}-\w{3}-\d{4} \d{2}:\d{2}:\d{2} \+0000" INTERNALDATE "01-Jan-2000 00:00:00 \+0000"\)`)
+		c.OK(`A003`)
+
+		today := time.Now().UTC().Format("2-Jan-2006")
+
+		c.C(`A004 SEARCH SAVEDBEFORE 1-Jan-2001`)
+		c.S(`* SEARCH`)
+		c.OK(`A004`)
+
+		c.Cf(`A005 SEARCH SAVEDON %v`, today)
+		c.S(`* SEARCH 1 2`)
+		c.OK(`A005`)
+
+		c.Cf(`A006 SEARCH SAVEDSINCE %v`, today)
+		c.S(`* SEARCH 1 2`)
+		c.OK(`A006`)
+
+		c.C(`A007 SEARCH SAVEDATESUPPORTED`)
+		c.S(`* SEARCH 1 2`)
+		c.OK(`A007`)
+
+		// OLDER and YOUNGER use the internal date.
+		c.C(`A008 SEARCH OLDER 86400`)
+		c.S(`* SEARCH 1`)
+		c.OK(`A008`)
+
+		c.C(`A009 SEARCH YOUNGER 86400`)
+		c.S(`* SEARCH 2`)
+		c.OK(`A009`)
+	})
+}
+
+func TestSaveDateMove(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, s *testSession) {
+		c.doAppend(`INBOX`, buildRFC5322TestLiteral("Subject: Hi\r\n\r\nHello\r\n")).expect("OK")
+
+		c.C(`A001 CREATE Trash`)
+		c.Sx(`^A001 OK \[MAILBOXID \(\S+\)\] CREATE`)
+
+		c.C(`A002 SELECT INBOX`)
+		c.Se(`A002 OK [READ-WRITE] SELECT`)
+
+		c.C(`A003 MOVE 1 Trash`)
+		c.Sxe(`A003 OK.*`)
+
+		c.C(`A004 SELECT Trash`)
+		c.Se(`A004 OK [READ-WRITE] SELECT`)
+
+		// The message was saved in Trash when it was moved there.
+		c.Cf(`A005 SEARCH SAVEDSINCE %v`, time.Now().UTC().Format("2-Jan-2006"))
+		c.S(`* SEARCH 1`)
+		c.OK(`A005`)
+
+		c.C(`A006 FETCH 1 (SAVEDATE)`)
+		c.Sx(fmt.Sprintf(`^\* 1 FETCH \(SAVEDATE "%v \d{2}:\d{2}:\d{2} \+0000"\)`, time.Now().UTC().Format("02-Jan-2006")))
+		c.OK(`A006`)
+	})
+}
@@ -22,7 +22,8 @@ func TestSelect(t *testing.T) {
 			`* OK [UNSEEN 2] Unseen messages`,
 			`* OK [PERMANENTFLAGS (\Deleted \Flagged \Seen)] Flags permitted`,
 			`* OK [UIDNEXT 3] Predicted next UID`,
-			`* OK [UIDVALIDITY 1] UIDs valid`)
+			`* OK [UIDVALIDITY 1] UIDs valid`,
+			`* OK [MAILBOXID (0)] Mailbox ID`)
 		c.S("A006 OK [READ-WRITE] SELECT")
 
 		// Selecting again modifies the RECENT value.
@@ -33,7 +34,8 @@ func TestSelect(t *testing.T) {
 			`* OK [UNSEEN 2] Unseen messages`,
 			`* OK [PERMANENTFLAGS (\Deleted \Flagged \Seen)] Flags permitted`,
 			`* OK [UIDNEXT 3] Predicted next UID`,
-			`* OK [UIDVALIDITY 1] UIDs valid`)
+			`* OK [UIDVALIDITY 1] UIDs valid`,
+			`* OK [MAILBOXID (0)] Mailbox ID`)
 		c.S("A006 OK [READ-WRITE] SELECT")
 
 		c.C("A007 select Archive")
@@ -43,6 +45,7 @@ func TestSelect(t *testing.T) {
 			`* OK [PERMANENTFLAGS (\Deleted \Flagged \Seen)] Flags permitted`,
 			`* OK [UIDNEXT 2] Predicted next UID`,
 			`* OK [UIDVALIDITY 1] UIDs valid`)
+		c.Sx(`^\* OK \[MAILBOXID \(\S+\)\] Mailbox ID`)
 		c.S(`A007 OK [READ-WRITE] SELECT`)
 	})
 }
@@ -78,6 +78,8 @@ type serverOptions struct {
 	disableParallelism      bool
 	imapLimits              limits.IMAP
 	disableIMAPAuthenticate bool
+	compression             bool
+	imap4rev2               bool
 	reporter                reporter.Reporter
 	uidValidityGenerator    imap.UIDValidityGenerator
 	database                db.ClientInterface
@@ -197,6 +199,18 @@ func (disableIMAPAuthenticateOption) apply(options *serverOptions) {
 	options.disableIMAPAuthenticate = true
 }
 
+type compressionOption struct{}
+
+func (compressionOption) apply(options *serverOptions) {
+	options.compression = true
+}
+
+type imap4rev2Option struct{}
+
+func (imap4rev2Option) apply(options *serverOptions) {
+	options.imap4rev2 = true
+}
+
 func (u uidValidityGeneratorOption) apply(options *serverOptions) {
 	options.uidValidityGenerator = u.generator
 }
@@ -253,6 +267,14 @@ func withDisableIMAPAuthenticate() serverOption {
 	return &disableIMAPAuthenticateOption{}
 }
 
+func withCompression() serverOption {
+	return &compressionOption{}
+}
+
+func withIMAP4rev2() serverOption {
+	return &imap4rev2Option{}
+}
+
 func defaultServerOptions(tb testing.TB, modifiers ...serverOption) *serverOptions {
 	options := &serverOptions{
 		credentials: []credentials{{
@@ -335,6 +357,14 @@ func runServer(tb testing.TB, options *serverOptions, tests func(session *testSe
 		gluonOptions = append(gluonOptions, gluon.WithDisableIMAPAuthenticate())
 	}
 
+	if options.compression {
+		gluonOptions = append(gluonOptions, gluon.WithCompression())
+	}
+
+	if options.imap4rev2 {
+		gluonOptions = append(gluonOptions, gluon.WithIMAP4rev2())
+	}
+
 	// Create a new gluon server.
 	server, err := gluon.New(gluonOptions...)
 	require.NoError(tb, err)
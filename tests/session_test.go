@@ -31,6 +31,8 @@ type Connector interface {
 	MailboxCreated(imap.Mailbox) error
 	MailboxDeleted(imap.MailboxID) error
 	SetMailboxVisibility(imap.MailboxID, imap.MailboxVisibility)
+	SetQuota(imap.Quota)
+	GetSharedMetadata(imap.MailboxID, string) ([]byte, bool)
 	RenameMailbox(id imap.MailboxID, newName []string) error
 
 	SetAllowMessageCreateWithUnknownMailboxID(value bool)
@@ -0,0 +1,54 @@
+package tests
+
+import (
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
+		c.doAppend(`INBOX`, buildRFC5322TestLiteral("Date: Mon, 02 Jan 2023 10:00:00 +0000\r\nFrom: c@pm.me\r\nTo: x@pm.me\r\nSubject: Re: banana\r\n\r\nmedium body")).expect("OK")
+		c.doAppend(`INBOX`, buildRFC5322TestLiteral("Date: Sat, 02 Jan 2021 10:00:00 +0000\r\nFrom: A@pm.me\r\nSubject: apple\r\n\r\nthe longest body of all")).expect("OK")
+		c.doAppend(`INBOX`, buildRFC5322TestLiteral("Date: Sun, 02 Jan 2022 10:00:00 +0000\r\nFrom: b@pm.me\r\nSubject: [list] Cherry\r\n\r\nbody")).expect("OK")
+
+		c.C(`A001 SELECT INBOX`)
+		c.Se(`A001 OK [READ-WRITE] SELECT`)
+
+		c.C(`A002 SORT (SUBJECT) UTF-8 ALL`)
+		c.S(`* SORT 2 1 3`)
+		c.OK(`A002`)
+
+		c.C(`A003 SORT (FROM) UTF-8 ALL`)
+		c.S(`* SORT 2 3 1`)
+		c.OK(`A003`)
+
+		c.C(`A004 SORT (REVERSE DATE) UTF-8 ALL`)
+		c.S(`* SORT 1 3 2`)
+		c.OK(`A004`)
+
+		c.C(`A005 SORT (ARRIVAL) UTF-8 ALL`)
+		c.S(`* SORT 1 2 3`)
+		c.OK(`A005`)
+
+		c.C(`A006 SORT (SIZE) UTF-8 ALL`)
+		c.S(`* SORT 3 2 1`)
+		c.OK(`A006`)
+
+		// Messages without a To address sort first, ties are broken by the next criterion.
+		c.C(`A007 SORT (TO SUBJECT) UTF-8 ALL`)
+		c.S(`* SORT 2 3 1`)
+		c.OK(`A007`)
+
+		c.C(`A008 UID SORT (REVERSE SUBJECT) UTF-8 SUBJECT a`)
+		c.S(`* SORT 1 2`)
+		c.OK(`A008`)
+
+		c.C(`A009 SORT (SUBJECT) UTF-8 SUBJECT nothing`)
+		c.S(`* SORT`)
+		c.OK(`A009`)
+
+		c.C(`A010 SORT (SUBJECT) FOO-BAR ALL`)
+		c.S(`A010 NO [BADCHARSET]`)
+
+		c.C(`A011 SORT (FOO) UTF-8 ALL`).BAD(`A011`)
+	})
+}
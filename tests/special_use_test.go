@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/ProtonMail/gluon/imap"
+)
+
+func TestListSelectSpecialUse(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, s *testSession) {
+		s.mailboxCreatedWithAttributes("user", []string{"Drafts"}, imap.NewFlagSet(imap.AttrDrafts))
+		s.mailboxCreatedWithAttributes("user", []string{"Sent"}, imap.NewFlagSet(imap.AttrSent))
+		s.mailboxCreated("user", []string{"Other"})
+
+		c.C(`A001 LIST (SPECIAL-USE) "" "*"`)
+		c.S(
+			`* LIST (\Drafts \Unmarked) "/" "Drafts"`,
+			`* LIST (\Sent \Unmarked) "/" "Sent"`,
+		)
+		c.OK(`A001`)
+
+		c.C(`A002 LIST "" "*" RETURN (SPECIAL-USE)`)
+		c.S(
+			`* LIST (\Unmarked) "/" "INBOX"`,
+			`* LIST (\Drafts \Unmarked) "/" "Drafts"`,
+			`* LIST (\Sent \Unmarked) "/" "Sent"`,
+			`* LIST (\Unmarked) "/" "Other"`,
+		)
+		c.OK(`A002`)
+
+		c.C(`A003 LIST (FOO) "" "*"`).BAD(`A003`)
+
+		// Clients asking for other return options only get the special-use attributes if they ask for them too.
+		c.C(`A004 LIST "" "*" RETURN (CHILDREN)`)
+		c.S(
+			`* LIST (\HasNoChildren \Unmarked) "/" "INBOX"`,
+			`* LIST (\HasNoChildren \Unmarked) "/" "Drafts"`,
+			`* LIST (\HasNoChildren \Unmarked) "/" "Sent"`,
+			`* LIST (\HasNoChildren \Unmarked) "/" "Other"`,
+		)
+		c.OK(`A004`)
+
+		c.C(`A005 LIST "" "*" RETURN (CHILDREN SPECIAL-USE)`)
+		c.S(
+			`* LIST (\HasNoChildren \Unmarked) "/" "INBOX"`,
+			`* LIST (\Drafts \HasNoChildren \Unmarked) "/" "Drafts"`,
+			`* LIST (\HasNoChildren \Sent \Unmarked) "/" "Sent"`,
+			`* LIST (\HasNoChildren \Unmarked) "/" "Other"`,
+		)
+		c.OK(`A005`)
+	})
+}
+
+func TestCreateSpecialUse(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
+		c.C(`A001 CREATE MySent (USE (\Sent))`)
+		c.OK(`A001`)
+
+		// Only the mailbox itself gets the special use, not its superiors.
+		c.C(`A002 CREATE Parent/MyTrash (USE (\Trash))`)
+		c.OK(`A002`)
+
+		c.C(`A003 LIST (SPECIAL-USE) "" "*"`)
+		c.S(
+			`* LIST (\Sent \Unmarked) "/" "MySent"`,
+			`* LIST (\Trash \Unmarked) "/" "Parent/MyTrash"`,
+		)
+		c.OK(`A003`)
+
+		c.C(`A004 CREATE Other (USE (\Bogus))`)
+		c.Sx(`A004 NO \[USEATTR\]`)
+
+		c.C(`A005 LIST "" "Other"`)
+		c.OK(`A005`)
+	})
+}
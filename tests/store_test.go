@@ -15,7 +15,7 @@ import (
 func TestStore(t *testing.T) {
 	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
 		c.C("b001 CREATE saved-messages")
-		c.S("b001 OK CREATE")
+		c.Sx(`^b001 OK \[MAILBOXID \(\S+\)\] CREATE`)
 
 		c.doAppend(`saved-messages`, buildRFC5322TestLiteral(`To: 1@pm.me`), `\Seen`).expect("OK")
 		c.doAppend(`saved-messages`, buildRFC5322TestLiteral(`To: 2@pm.me`)).expect("OK")
@@ -65,7 +65,7 @@ func TestStoreForwarding(t *testing.T) {
 	// Ensure forwarding sets and removes all known forwarding flags.
 	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
 		c.C("b001 CREATE saved-messages")
-		c.S("b001 OK CREATE")
+		c.Sx(`^b001 OK \[MAILBOXID \(\S+\)\] CREATE`)
 
 		c.doAppend(`saved-messages`, buildRFC5322TestLiteral(`To: 1@pm.me`)).expect("OK")
 		c.doAppend(`saved-messages`, buildRFC5322TestLiteral(`To: 2@pm.me`)).expect("OK")
@@ -136,7 +136,7 @@ func TestStoreForwarding(t *testing.T) {
 func TestSetStoreDeletedDoesNotCrash(t *testing.T) {
 	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
 		c.C("b001 CREATE saved-messages")
-		c.S("b001 OK CREATE")
+		c.Sx(`^b001 OK \[MAILBOXID \(\S+\)\] CREATE`)
 
 		c.doAppend(`saved-messages`, buildRFC5322TestLiteral(`To: 1@pm.me`)).expect("OK")
 
@@ -246,7 +246,7 @@ func TestStoreReadUnread(t *testing.T) {
 func TestUIDStore(t *testing.T) {
 	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
 		c.C("b001 CREATE saved-messages")
-		c.S("b001 OK CREATE")
+		c.Sx(`^b001 OK \[MAILBOXID \(\S+\)\] CREATE`)
 
 		c.doAppend(`saved-messages`, buildRFC5322TestLiteral(`To: 1@pm.me`), `\Seen`).expect("OK")
 		c.doAppend(`saved-messages`, buildRFC5322TestLiteral(`To: 2@pm.me`)).expect("OK")
@@ -349,7 +349,7 @@ func TestStoreFlagsPersistBetweenRuns(t *testing.T) {
 
 	runOneToOneTestWithAuth(t, options, func(c *testConnection, _ *testSession) {
 		c.C("b001 CREATE saved-messages")
-		c.S("b001 OK CREATE")
+		c.Sx(`^b001 OK \[MAILBOXID \(\S+\)\] CREATE`)
 		c.doAppend(`saved-messages`, buildRFC5322TestLiteral(`To: 2@pm.me`)).expect("OK")
 	})
 
@@ -7,7 +7,7 @@ import (
 func TestSubscribe(t *testing.T) {
 	runOneToOneTestWithAuth(t, defaultServerOptions(t, withDelimiter(".")), func(c *testConnection, _ *testSession) {
 		c.C("A002 CREATE #news.comp.mail.mime")
-		c.S("A002 OK CREATE")
+		c.Sx(`^A002 OK \[MAILBOXID \(\S+\)\] CREATE`)
 
 		c.C("A003 SUBSCRIBE #this.name.does.not.exist")
 		c.S("A003 NO no such mailbox")
@@ -0,0 +1,39 @@
+package tests
+
+import (
+	"testing"
+)
+
+func TestThread(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
+		c.doAppend(`INBOX`, buildRFC5322TestLiteral("Date: Fri, 01 Jan 2021 10:00:00 +0000\r\nMessage-ID: <a@pm.me>\r\nSubject: fruit\r\n\r\nbody")).expect("OK")
+		c.doAppend(`INBOX`, buildRFC5322TestLiteral("Date: Sun, 03 Jan 2021 10:00:00 +0000\r\nMessage-ID: <c@pm.me>\r\nReferences: <a@pm.me>\r\nSubject: Re: fruit\r\n\r\nbody")).expect("OK")
+		c.doAppend(`INBOX`, buildRFC5322TestLiteral("Date: Sat, 02 Jan 2021 10:00:00 +0000\r\nMessage-ID: <b@pm.me>\r\nSubject: other\r\n\r\nbody")).expect("OK")
+		c.doAppend(`INBOX`, buildRFC5322TestLiteral("Date: Mon, 04 Jan 2021 10:00:00 +0000\r\nMessage-ID: <d@pm.me>\r\nIn-Reply-To: <c@pm.me>\r\nSubject: Re: fruit\r\n\r\nbody")).expect("OK")
+
+		c.C(`A001 SELECT INBOX`)
+		c.Se(`A001 OK [READ-WRITE] SELECT`)
+
+		c.C(`A002 THREAD REFERENCES UTF-8 ALL`)
+		c.S(`* THREAD (1 2 4)(3)`)
+		c.OK(`A002`)
+
+		// Messages sharing a base subject are children of the earliest one.
+		c.C(`A003 THREAD ORDEREDSUBJECT UTF-8 ALL`)
+		c.S(`* THREAD (1 (2)(4))(3)`)
+		c.OK(`A003`)
+
+		c.C(`A004 UID THREAD REFERENCES UTF-8 SUBJECT fruit`)
+		c.S(`* THREAD (1 2 4)`)
+		c.OK(`A004`)
+
+		c.C(`A005 THREAD REFERENCES UTF-8 SUBJECT nothing`)
+		c.S(`* THREAD`)
+		c.OK(`A005`)
+
+		c.C(`A006 THREAD REFERENCES FOO-BAR ALL`)
+		c.S(`A006 NO [BADCHARSET]`)
+
+		c.C(`A007 THREAD FOO UTF-8 ALL`).BAD(`A007`)
+	})
+}
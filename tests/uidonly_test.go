@@ -0,0 +1,65 @@
+package tests
+
+import (
+	"testing"
+)
+
+func TestUIDOnly(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
+		c.C("A001 CREATE saved-messages")
+		c.Sx(`^A001 OK \[MAILBOXID \(\S+\)\] CREATE`)
+
+		c.doAppend(`saved-messages`, buildRFC5322TestLiteral(`To: 1@pm.me`)).expect("OK")
+		c.doAppend(`saved-messages`, buildRFC5322TestLiteral(`To: 2@pm.me`)).expect("OK")
+		c.doAppend(`saved-messages`, buildRFC5322TestLiteral(`To: 3@pm.me`)).expect("OK")
+
+		c.C("A002 ENABLE UIDONLY")
+		c.S("* ENABLED UIDONLY")
+		c.OK("A002")
+
+		c.C(`A003 SELECT saved-messages`)
+		c.Se(`A003 OK [READ-WRITE] SELECT`)
+
+		// Commands using message sequence numbers are rejected.
+		c.C(`A004 FETCH 1 (FLAGS)`)
+		c.Sx(`^A004 BAD \[UIDREQUIRED\]`)
+
+		c.C(`A005 STORE 1 +FLAGS (\Seen)`)
+		c.Sx(`^A005 BAD \[UIDREQUIRED\]`)
+
+		c.C(`A006 COPY 1 INBOX`)
+		c.Sx(`^A006 BAD \[UIDREQUIRED\]`)
+
+		c.C(`A007 UID SEARCH 1:2`)
+		c.Sx(`^A007 BAD \[UIDREQUIRED\]`)
+
+		// Messages are reported by UID.
+		c.C(`A008 UID FETCH 2:3 (FLAGS)`)
+		c.S(
+			`* 2 UIDFETCH (FLAGS (\Recent))`,
+			`* 3 UIDFETCH (FLAGS (\Recent))`,
+		)
+		c.OK(`A008`)
+
+		c.C(`A009 UID FETCH 1 (UID)`)
+		c.S(`* 1 UIDFETCH (UID 1)`)
+		c.OK(`A009`)
+
+		c.C(`A010 UID STORE 2 +FLAGS (\Deleted)`)
+		c.S(`* 2 UIDFETCH (FLAGS (\Deleted \Recent))`)
+		c.OK(`A010`)
+
+		c.C(`A011 UID SEARCH DELETED`)
+		c.S(`* SEARCH 2`)
+		c.OK(`A011`)
+
+		// Expunged messages are reported with VANISHED.
+		c.C(`A012 UID EXPUNGE 2`)
+		c.S(`* VANISHED 2`)
+		c.OK(`A012`)
+
+		c.C(`A013 UID SEARCH ALL`)
+		c.S(`* SEARCH 1 3`)
+		c.OK(`A013`)
+	})
+}
@@ -7,7 +7,7 @@ import (
 func TestUnselect(t *testing.T) {
 	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
 		c.C("b001 CREATE saved-messages")
-		c.S("b001 OK CREATE")
+		c.Sx(`^b001 OK \[MAILBOXID \(\S+\)\] CREATE`)
 
 		c.C(`A002 SELECT INBOX`)
 		c.Se(`A002 OK [READ-WRITE] SELECT`)
@@ -0,0 +1,195 @@
+package tests
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestCatenate(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
+		original := buildRFC5322TestLiteral(strings.Join([]string{
+			`Subject: Original`,
+			`Content-Type: multipart/mixed; boundary="boundary"`,
+			``,
+			`--boundary`,
+			`Content-Type: text/plain`,
+			``,
+			`Hello`,
+			`--boundary`,
+			`Content-Type: application/octet-stream`,
+			``,
+			`ATTACHMENT`,
+			`--boundary--`,
+			``,
+		}, "\r\n"))
+
+		c.doAppend(`INBOX`, original).expect(`OK`)
+
+		header := buildRFC5322TestLiteral(strings.Join([]string{
+			`Subject: Fwd: Original`,
+			`Content-Type: multipart/mixed; boundary="boundary"`,
+			``,
+			`--boundary`,
+			`Content-Type: text/plain`,
+			``,
+			`See attached`,
+			`--boundary`,
+			``,
+		}, "\r\n"))
+		trailer := "\r\n--boundary--\r\n"
+
+		// The forwarded attachment is copied from the original message on the server.
+		c.C(fmt.Sprintf(`A001 APPEND INBOX CATENATE (TEXT {%v+}`, len(header)))
+		c.C(fmt.Sprintf(`%v URL "/INBOX/;UID=1/;SECTION=2.MIME" URL "/INBOX/;UID=1/;SECTION=2" TEXT {%v+}`, header, len(trailer)))
+		c.C(trailer + ")")
+		c.Sx(`^A001 OK \[APPENDUID \d+ 2\] APPEND`)
+
+		c.C(`A002 SELECT INBOX`)
+		c.Se(`A002 OK [READ-WRITE] SELECT`)
+
+		c.C(`A003 FETCH 2 (BODY.PEEK[1] BODY.PEEK[2])`)
+		c.S(`* 2 FETCH (BODY[1] {12}` + "\r\n" + `See attached BODY[2] {10}` + "\r\n" + `ATTACHMENT)`)
+		c.OK(`A003`)
+
+		// Nothing is appended if a URL can't be resolved.
+		c.C(`A004 APPEND INBOX CATENATE (URL "/INBOX/;UID=1" URL "/INBOX/;UID=5")`)
+		c.S(`A004 NO [BADURL /INBOX/;UID=5] no such message`)
+
+		c.C(`A005 APPEND INBOX CATENATE (URL "/Archive/;UID=1")`)
+		c.S(`A005 NO [BADURL /Archive/;UID=1] no such mailbox`)
+
+		c.C(`A006 STATUS INBOX (MESSAGES)`)
+		c.S(`* STATUS "INBOX" (MESSAGES 2)`)
+		c.OK(`A006`)
+	})
+}
+
+func TestURLAuth(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
+		c.doAppend(`INBOX`, buildRFC5322TestLiteral("To: 1@pm.me\r\n\r\nHello")).expect(`OK`)
+
+		genURLAuth := func(tag, rump string) string {
+			c.C(fmt.Sprintf(`%v GENURLAUTH "%v" INTERNAL`, tag, rump))
+
+			match := regexp.MustCompile(`^\* GENURLAUTH "(.*):internal:([0-9a-f]{40})"\r\n$`).FindSubmatch(c.read())
+			if match == nil || string(match[1]) != rump {
+				t.Fatalf("unexpected GENURLAUTH response: %q", match)
+			}
+
+			c.OK(tag)
+
+			return fmt.Sprintf("%v:internal:%v", rump, string(match[2]))
+		}
+
+		rump := `imap://user@localhost/INBOX/;UID=1/;SECTION=TEXT;URLAUTH=anonymous`
+		url := genURLAuth(`A001`, rump)
+
+		// The authorized URL can be fetched, unlike the rump URL or one with a different token.
+		tampered := url[:len(url)-4] + "0000"
+		if tampered == url {
+			tampered = url[:len(url)-4] + "1111"
+		}
+
+		c.C(fmt.Sprintf(`A002 URLFETCH "%v" "%v" "%v"`, url, rump, tampered))
+		c.S(fmt.Sprintf(`* URLFETCH "%v" {5}`+"\r\n"+`Hello "%v" NIL "%v" NIL`, url, rump, tampered))
+		c.OK(`A002`)
+
+		// The same URL authorizes the same content.
+		c.C(fmt.Sprintf(`A003 GENURLAUTH "%v" INTERNAL`, rump))
+		c.S(fmt.Sprintf(`* GENURLAUTH "%v"`, url))
+		c.OK(`A003`)
+
+		// Authorized URLs can be used with CATENATE too.
+		header := buildRFC5322TestLiteral("Subject: Copy\r\n\r\n")
+
+		c.C(fmt.Sprintf(`A004 APPEND INBOX CATENATE (TEXT {%v+}`, len(header)))
+		c.C(fmt.Sprintf(`%v URL "%v")`, header, url))
+		c.Sx(`^A004 OK \[APPENDUID \d+ 2\] APPEND`)
+
+		// Access identifiers are checked against the user fetching the URL.
+		own := genURLAuth(`A005`, `imap://user@localhost/INBOX/;UID=1/;PARTIAL=0.2;URLAUTH=user+user`)
+		other := genURLAuth(`A006`, `imap://user@localhost/INBOX/;UID=1;URLAUTH=user+other`)
+
+		c.C(fmt.Sprintf(`A007 URLFETCH "%v" "%v"`, own, other))
+		c.Sx(`^\* URLFETCH ".*" \{2\}` + "\r\n" + `X- ".*" NIL\r\n$`)
+		c.OK(`A007`)
+
+		// Resetting the access key invalidates the authorized URLs.
+		c.C(`A008 RESETKEY INBOX`)
+		c.OK(`A008`)
+
+		c.C(fmt.Sprintf(`A009 URLFETCH "%v"`, url))
+		c.S(fmt.Sprintf(`* URLFETCH "%v" NIL`, url))
+		c.OK(`A009`)
+
+		// Only URLs of the user's own messages can be authorized.
+		c.C(`A010 GENURLAUTH "imap://other@localhost/INBOX/;UID=1;URLAUTH=anonymous" INTERNAL`)
+		c.NO(`A010`)
+
+		c.C(fmt.Sprintf(`A011 GENURLAUTH "%v" EXTERNAL`, rump))
+		c.NO(`A011`)
+
+		c.C(`A012 GENURLAUTH "/INBOX/;UID=1" INTERNAL`)
+		c.BAD(`A012`)
+
+		// Only message submission servers may use submit+ URLs, so they can't be authorized.
+		c.C(`A013 GENURLAUTH "imap://user@localhost/INBOX/;UID=1;URLAUTH=submit+user" INTERNAL`)
+		c.NO(`A013`)
+	})
+}
+
+func TestURLAuthOtherUser(t *testing.T) {
+	runTest(t, defaultServerOptions(t, withCredentials([]credentials{
+		{usernames: []string{"user1"}, password: "pass"},
+		{usernames: []string{"user2"}, password: "pass"},
+		{usernames: []string{"user3"}, password: "pass"},
+	})), []int{1, 2, 3}, func(c map[int]*testConnection, _ *testSession) {
+		c[1].C(`A001 LOGIN user1 pass`).OK(`A001`)
+		c[2].C(`B001 LOGIN user2 pass`).OK(`B001`)
+		c[3].C(`C001 LOGIN user3 pass`).OK(`C001`)
+
+		c[1].doAppend(`INBOX`, buildRFC5322TestLiteral("To: 1@pm.me\r\n\r\nHello")).expect(`OK`)
+
+		genURLAuth := func(rump string) string {
+			c[1].C(fmt.Sprintf(`A002 GENURLAUTH "%v" INTERNAL`, rump))
+
+			match := regexp.MustCompile(`^\* GENURLAUTH "(.*)"\r\n$`).FindSubmatch(c[1].read())
+			if match == nil {
+				t.Fatalf("unexpected GENURLAUTH response")
+			}
+
+			c[1].OK(`A002`)
+
+			return string(match[1])
+		}
+
+		anonymous := genURLAuth(`imap://user1@localhost/INBOX/;UID=1/;SECTION=TEXT;URLAUTH=anonymous`)
+		user2 := genURLAuth(`imap://user1@localhost/INBOX/;UID=1/;SECTION=TEXT;URLAUTH=user+user2`)
+
+		// Other users can fetch the URLs their access identifier grants them access to.
+		c[2].C(fmt.Sprintf(`B002 URLFETCH "%v" "%v"`, anonymous, user2))
+		c[2].S(fmt.Sprintf(`* URLFETCH "%v" {5}`+"\r\n"+`Hello "%v" {5}`+"\r\n"+`Hello`, anonymous, user2))
+		c[2].OK(`B002`)
+
+		c[3].C(fmt.Sprintf(`C002 URLFETCH "%v" "%v"`, anonymous, user2))
+		c[3].S(fmt.Sprintf(`* URLFETCH "%v" {5}`+"\r\n"+`Hello "%v" NIL`, anonymous, user2))
+		c[3].OK(`C002`)
+
+		// The messages of other users can't be accessed without authorization.
+		c[2].C(`B003 URLFETCH "imap://user1@localhost/INBOX/;UID=1"`)
+		c[2].S(`* URLFETCH "imap://user1@localhost/INBOX/;UID=1" NIL`)
+		c[2].OK(`B003`)
+
+		c[2].C(`B004 APPEND INBOX CATENATE (URL "imap://user1@localhost/INBOX/;UID=1")`)
+		c[2].Sx(`^B004 NO \[BADURL`)
+
+		// Authorized URLs of other users can be used with CATENATE too.
+		header := buildRFC5322TestLiteral("Subject: Copy\r\n\r\n")
+
+		c[2].C(fmt.Sprintf(`B005 APPEND INBOX CATENATE (TEXT {%v+}`, len(header)))
+		c[2].C(fmt.Sprintf(`%v URL "%v")`, header, user2))
+		c[2].Sx(`^B005 OK \[APPENDUID \d+ 1\] APPEND`)
+	})
+}
@@ -0,0 +1,56 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestUTF8AcceptMailboxNames(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
+		// Before UTF8=ACCEPT is enabled, mailbox names are encoded in modified UTF-7.
+		c.C(`A001 CREATE "Gr&APwA3w-e"`)
+		c.OK(`A001`)
+
+		c.C(`A002 ENABLE UTF8=ACCEPT`)
+		c.S(`* ENABLED UTF8=ACCEPT`)
+		c.OK(`A002`)
+
+		// Afterwards they are sent and parsed as raw UTF-8.
+		c.C(`A003 LIST "" "Gr*"`)
+		c.S(`* LIST (\Unmarked) "/" "Grüße"`)
+		c.OK(`A003`)
+
+		c.C(`A004 CREATE "Привет"`)
+		c.OK(`A004`)
+
+		c.C(`A005 LIST "" "Привет"`)
+		c.S(`* LIST (\Unmarked) "/" "Привет"`)
+		c.OK(`A005`)
+
+		c.C(`A006 SELECT "Grüße"`)
+		c.Se(`A006 OK [READ-WRITE] SELECT`)
+	})
+}
+
+func TestUTF8AcceptAppend(t *testing.T) {
+	runOneToOneTestWithAuth(t, defaultServerOptions(t), func(c *testConnection, _ *testSession) {
+		literal := buildRFC5322TestLiteral("To: Jürgen <jürgen@pm.me>\r\nSubject: Grüße aus Zürich\r\n\r\nHallo!")
+
+		// The UTF8 data extension may only be used once UTF8=ACCEPT is enabled.
+		c.C(fmt.Sprintf("A001 APPEND INBOX UTF8 (~{%v+}\r\n%v)", len(literal), literal)).BAD(`A001`)
+
+		c.C(`A002 ENABLE UTF8=ACCEPT`)
+		c.S(`* ENABLED UTF8=ACCEPT`)
+		c.OK(`A002`)
+
+		c.C(fmt.Sprintf("A003 APPEND INBOX UTF8 (~{%v+}\r\n%v)", len(literal), literal))
+		c.Sx(`A003 OK \[APPENDUID \d+ 1\] APPEND`)
+
+		c.C(`A004 SELECT INBOX`)
+		c.Se(`A004 OK [READ-WRITE] SELECT`)
+
+		c.C(`A005 FETCH 1 (BODY.PEEK[HEADER.FIELDS (SUBJECT)])`)
+		c.S("* 1 FETCH (BODY[HEADER.FIELDS (SUBJECT)] {32}\r\nSubject: Grüße aus Zürich\r\n\r\n)")
+		c.OK(`A005`)
+	})
+}
@@ -7,7 +7,9 @@ import (
 	"os"
 	"time"
 
+	"github.com/emersion/go-imap"
 	"github.com/emersion/go-imap/client"
+	"github.com/emersion/go-imap/utf7"
 )
 
 var (
@@ -15,8 +17,31 @@ var (
 	userName     = flag.String("user-name", "user", "IMAP user name")
 	userPassword = flag.String("user-pwd", "password", "IMAP user password")
 	mbox         = flag.String("mbox", "INBOX", "IMAP mailbox to append to")
+	batchSize    = flag.Int("batch-size", 100, "Number of messages appended with a single command when the server supports MULTIAPPEND")
 )
 
+// multiAppend is an APPEND command carrying several messages (RFC3502).
+type multiAppend struct {
+	mailbox  string
+	date     time.Time
+	messages []imap.Literal
+}
+
+func (cmd *multiAppend) Command() *imap.Command {
+	mailbox, _ := utf7.Encoding.NewEncoder().String(cmd.mailbox)
+
+	args := []interface{}{imap.FormatMailboxName(mailbox)}
+
+	for _, message := range cmd.messages {
+		args = append(args, cmd.date, message)
+	}
+
+	return &imap.Command{
+		Name:      "APPEND",
+		Arguments: args,
+	}
+}
+
 func main() {
 	flag.Parse()
 	flag.Usage = func() {
@@ -46,14 +71,44 @@ func main() {
 		panic(fmt.Errorf("failed to login to server: %w", err))
 	}
 
-	for _, v := range args {
-		fileData, err := os.ReadFile(v)
-		if err != nil {
-			panic(fmt.Errorf("failed to read file:%v - %w", v, err))
+	multiAppendSupported, err := client.Support("MULTIAPPEND")
+	if err != nil {
+		panic(fmt.Errorf("failed to check server capabilities: %w", err))
+	}
+
+	if !multiAppendSupported || *batchSize < 2 {
+		for _, v := range args {
+			if err := client.Append(*mbox, []string{}, time.Now(), bytes.NewReader(readFile(v))); err != nil {
+				panic(fmt.Errorf("failed to upload file:%v - %w", v, err))
+			}
 		}
 
-		if err := client.Append(*mbox, []string{}, time.Now(), bytes.NewReader(fileData)); err != nil {
-			panic(fmt.Errorf("failed to upload file:%v - %w", v, err))
+		return
+	}
+
+	for len(args) > 0 {
+		batch := args[:min(*batchSize, len(args))]
+		args = args[len(batch):]
+
+		cmd := &multiAppend{mailbox: *mbox, date: time.Now()}
+
+		for _, v := range batch {
+			cmd.messages = append(cmd.messages, bytes.NewReader(readFile(v)))
+		}
+
+		if status, err := client.Execute(cmd, nil); err != nil {
+			panic(fmt.Errorf("failed to upload files:%v - %w", batch, err))
+		} else if err := status.Err(); err != nil {
+			panic(fmt.Errorf("failed to upload files:%v - %w", batch, err))
 		}
 	}
 }
+
+func readFile(path string) []byte {
+	fileData, err := os.ReadFile(path)
+	if err != nil {
+		panic(fmt.Errorf("failed to read file:%v - %w", path, err))
+	}
+
+	return fileData
+}